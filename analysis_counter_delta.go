@@ -0,0 +1,79 @@
+package cisco
+
+import (
+	"time"
+)
+
+// InterfaceCounterDelta is how much the counters of one interface grew between two
+// "show interface" samples, and the resulting average rates.
+type InterfaceCounterDelta struct {
+	Interface string
+	Interval  time.Duration
+
+	// Counter deltas between the two samples, with 32-bit wraps accounted for
+	BytesInput    uint64
+	BytesOutput   uint64
+	PacketsInput  uint64
+	PacketsOutput uint64
+	InputErrors   uint64
+	OutputErrors  uint64
+	CrcErrors     uint64
+	InputDrops    uint64
+	OutputDrops   uint64
+
+	// Average rates over the interval; zero when the interval is not positive
+	InputBps              float64
+	OutputBps             float64
+	InputPps              float64
+	OutputPps             float64
+	InputErrorsPerMinute  float64
+	OutputErrorsPerMinute float64
+}
+
+// DiffInterfaceCounters compares two samples of "show interface" taken interval apart
+// and returns the counter deltas and rates of every interface present in both, in
+// the order of current. Feed it successive Show_interfaces results for trending.
+func DiffInterfaceCounters(previous []InterfaceDetails, current []InterfaceDetails, interval time.Duration) []InterfaceCounterDelta {
+	previousByName := make(map[string]InterfaceDetails, len(previous))
+	for _, iface := range previous {
+		previousByName[iface.Interface] = iface
+	}
+
+	var deltas []InterfaceCounterDelta
+	for _, iface := range current {
+		prev, ok := previousByName[iface.Interface]
+		if !ok {
+			continue
+		}
+		deltas = append(deltas, diffInterfaceCounters(prev, iface, interval))
+	}
+	return deltas
+}
+
+// diffInterfaceCounters computes the deltas and rates of a single interface.
+func diffInterfaceCounters(previous InterfaceDetails, current InterfaceDetails, interval time.Duration) InterfaceCounterDelta {
+	delta := InterfaceCounterDelta{
+		Interface:     current.Interface,
+		Interval:      interval,
+		BytesInput:    counterDelta(previous.BytesInput, current.BytesInput),
+		BytesOutput:   counterDelta(previous.BytesOutput, current.BytesOutput),
+		PacketsInput:  counterDelta(previous.PacketsInput, current.PacketsInput),
+		PacketsOutput: counterDelta(previous.PacketsOutput, current.PacketsOutput),
+		InputErrors:   counterDelta(previous.InputErrors, current.InputErrors),
+		OutputErrors:  counterDelta(previous.OutputErrors, current.OutputErrors),
+		CrcErrors:     counterDelta(previous.CrcErrors, current.CrcErrors),
+		InputDrops:    counterDelta(previous.InputDrops, current.InputDrops),
+		OutputDrops:   counterDelta(previous.OutputDrops, current.OutputDrops),
+	}
+
+	if seconds := interval.Seconds(); seconds > 0 {
+		delta.InputBps = float64(delta.BytesInput) * 8 / seconds
+		delta.OutputBps = float64(delta.BytesOutput) * 8 / seconds
+		delta.InputPps = float64(delta.PacketsInput) / seconds
+		delta.OutputPps = float64(delta.PacketsOutput) / seconds
+		delta.InputErrorsPerMinute = float64(delta.InputErrors) / interval.Minutes()
+		delta.OutputErrorsPerMinute = float64(delta.OutputErrors) / interval.Minutes()
+	}
+
+	return delta
+}
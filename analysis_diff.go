@@ -0,0 +1,100 @@
+package cisco
+
+import "reflect"
+
+// EntryChange is an entry present before and after, with different contents.
+type EntryChange[T any] struct {
+	Before T
+	After  T
+}
+
+// EntryDiff lists the entries added, removed and changed between two runs of the
+// same show command, e.g. before and after a maintenance.
+type EntryDiff[T any] struct {
+	Added   []T
+	Removed []T
+	Changed []EntryChange[T]
+}
+
+// Empty reports whether nothing changed.
+func (d EntryDiff[T]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffEntries compares two lists of parsed entries matched by key. Entries with the
+// same key are changed when equal reports false; a nil equal compares all fields.
+// Removed and changed entries keep the order of before, added entries that of after.
+func DiffEntries[T any, K comparable](before []T, after []T, key func(T) K, equal func(a T, b T) bool) EntryDiff[T] {
+	if equal == nil {
+		equal = func(a T, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+
+	afterByKey := make(map[K]T, len(after))
+	for _, entry := range after {
+		afterByKey[key(entry)] = entry
+	}
+	beforeKeys := make(map[K]bool, len(before))
+
+	var diff EntryDiff[T]
+	for _, entry := range before {
+		k := key(entry)
+		beforeKeys[k] = true
+		now, ok := afterByKey[k]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, entry)
+		case !equal(entry, now):
+			diff.Changed = append(diff.Changed, EntryChange[T]{Before: entry, After: now})
+		}
+	}
+	for _, entry := range after {
+		if !beforeKeys[key(entry)] {
+			diff.Added = append(diff.Added, entry)
+		}
+	}
+
+	return diff
+}
+
+// Diff_interfaces_status compares "show interface status" results by interface, so a
+// port that went notconnect or moved VLAN shows up as changed.
+func Diff_interfaces_status(before []InterfaceStatus, after []InterfaceStatus) EntryDiff[InterfaceStatus] {
+	return DiffEntries(before, after, func(s InterfaceStatus) string { return shortInterfaceName(s.Interface) }, nil)
+}
+
+// Diff_vlans compares "show vlan" results by VLAN ID; name, status and port changes
+// show up as changed.
+func Diff_vlans(before []VlanInfo, after []VlanInfo) EntryDiff[VlanInfo] {
+	return DiffEntries(before, after, func(v VlanInfo) string { return v.VLANID }, nil)
+}
+
+// Diff_mac_address_table compares MAC address tables by MAC and VLAN, so a host that
+// moved to another port shows up as changed.
+func Diff_mac_address_table(before []MacAddressEntry, after []MacAddressEntry) EntryDiff[MacAddressEntry] {
+	return DiffEntries(before, after,
+		func(e MacAddressEntry) string { return normalizeMacAddress(e.MacAddress) + " " + e.VlanID },
+		func(a MacAddressEntry, b MacAddressEntry) bool {
+			return shortInterfaceName(a.Interface) == shortInterfaceName(b.Interface) && a.Type == b.Type
+		})
+}
+
+// Diff_cdp_neighbors compares CDP neighbors by local interface and neighbor. The hold
+// time counts down continuously and is ignored.
+func Diff_cdp_neighbors(before []CdpNeighbor, after []CdpNeighbor) EntryDiff[CdpNeighbor] {
+	return DiffEntries(before, after,
+		func(n CdpNeighbor) string { return shortInterfaceName(n.Interface) + " " + n.Neighbor },
+		func(a CdpNeighbor, b CdpNeighbor) bool {
+			return a.Capability == b.Capability && a.Platform == b.Platform &&
+				shortInterfaceName(a.NeighborInterface) == shortInterfaceName(b.NeighborInterface)
+		})
+}
+
+// Diff_lldp_neighbors compares LLDP neighbors by local interface and neighbor. The hold
+// time is ignored.
+func Diff_lldp_neighbors(before []LldpNeighbor, after []LldpNeighbor) EntryDiff[LldpNeighbor] {
+	return DiffEntries(before, after,
+		func(n LldpNeighbor) string { return shortInterfaceName(n.Interface) + " " + n.Neighbor },
+		func(a LldpNeighbor, b LldpNeighbor) bool {
+			return a.Capability == b.Capability && shortInterfaceName(a.NeighborInterface) == shortInterfaceName(b.NeighborInterface)
+		})
+}
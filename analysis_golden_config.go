@@ -0,0 +1,249 @@
+package cisco
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// ChangedConfigLine is a golden line whose command is configured with a different value.
+type ChangedConfigLine struct {
+	Expected string
+	Actual   string
+}
+
+// ConfigSectionDiff lists the differences found in one configuration section. Section
+// is the top-level line (e.g., "interface Gi1/0/1"), or "" for global single-line commands.
+type ConfigSectionDiff struct {
+	Section        string
+	MissingSection bool // The whole section is absent from the running config
+	ExtraSection   bool // The section exists only in the running config
+	Missing        []string
+	Extra          []string
+	Changed        []ChangedConfigLine
+}
+
+// GoldenConfigAudit is the result of comparing a running configuration against its
+// golden template.
+type GoldenConfigAudit struct {
+	Switch   string
+	Sections []ConfigSectionDiff
+}
+
+// Compliant reports whether the running configuration matched the golden config.
+func (a GoldenConfigAudit) Compliant() bool {
+	return len(a.Sections) == 0
+}
+
+// configSection is one top-level configuration line and its indented children.
+type configSection struct {
+	header string
+	lines  []string
+}
+
+// reCommandEcho matches the prompt and command echoed back by the shell, e.g. "switch01#show running-config".
+var reCommandEcho = regexp.MustCompile(`^[\w\-\.]+[>#]`)
+
+// Render_golden_config renders a golden config template for one device with
+// text/template, e.g. "hostname {{.Hostname}}" with a struct holding the device's
+// variables. Keep one template per device role.
+func Render_golden_config(goldenTemplate string, data any) (string, error) {
+	tmpl, err := template.New("golden").Option("missingkey=error").Parse(goldenTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse golden template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render golden template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Audit_golden_config fetches the running configuration of a switch and compares it
+// against a rendered golden config.
+func Audit_golden_config(switch_hostname string, goldenConfig string) (GoldenConfigAudit, error) {
+	return Audit_golden_config_context(context.Background(), switch_hostname, goldenConfig)
+}
+
+// Audit_golden_config_context is Audit_golden_config with a context that cancels the command.
+func Audit_golden_config_context(ctx context.Context, switch_hostname string, goldenConfig string) (GoldenConfigAudit, error) {
+	outputString, err := RunCommandContext(ctx, switch_hostname, "show running-config")
+	if err != nil {
+		return GoldenConfigAudit{}, err
+	}
+
+	audit := Compare_golden_config(goldenConfig, outputString)
+	audit.Switch = switch_hostname
+	return audit, nil
+}
+
+// Compare_golden_config compares a running configuration against a golden config
+// section by section and returns the missing, extra and changed lines. Comments,
+// banners of "!" and the "Building configuration" preamble are ignored, and interface
+// section names are compared in their normalized form.
+func Compare_golden_config(goldenConfig string, runningConfig string) GoldenConfigAudit {
+	var audit GoldenConfigAudit
+
+	golden := splitConfigSections(goldenConfig)
+	running := splitConfigSections(runningConfig)
+
+	runningIndex := make(map[string]configSection, len(running))
+	for _, section := range running {
+		runningIndex[configSectionKey(section.header)] = section
+	}
+	goldenIndex := make(map[string]bool, len(golden))
+
+	for _, expected := range golden {
+		key := configSectionKey(expected.header)
+		goldenIndex[key] = true
+
+		actual, ok := runningIndex[key]
+		if !ok {
+			audit.Sections = append(audit.Sections, ConfigSectionDiff{Section: expected.header, MissingSection: true, Missing: expected.lines})
+			continue
+		}
+		if diff, differs := diffConfigSection(expected, actual); differs {
+			audit.Sections = append(audit.Sections, diff)
+		}
+	}
+
+	for _, actual := range running {
+		if !goldenIndex[configSectionKey(actual.header)] {
+			audit.Sections = append(audit.Sections, ConfigSectionDiff{Section: actual.header, ExtraSection: true, Extra: actual.lines})
+		}
+	}
+
+	return audit
+}
+
+// diffConfigSection compares the lines of one section. A missing and an extra line are
+// reported as changed when they only differ in their trailing argument, e.g.
+// "switchport access vlan 10" and "switchport access vlan 20".
+func diffConfigSection(expected configSection, actual configSection) (ConfigSectionDiff, bool) {
+	diff := ConfigSectionDiff{Section: expected.header}
+
+	actualLines := make(map[string]bool, len(actual.lines))
+	for _, line := range actual.lines {
+		actualLines[line] = true
+	}
+	expectedLines := make(map[string]bool, len(expected.lines))
+	for _, line := range expected.lines {
+		expectedLines[line] = true
+	}
+
+	var missing, extra []string
+	for _, line := range expected.lines {
+		if !actualLines[line] {
+			missing = append(missing, line)
+		}
+	}
+	for _, line := range actual.lines {
+		if !expectedLines[line] {
+			extra = append(extra, line)
+		}
+	}
+
+	paired := make(map[int]bool)
+	for _, want := range missing {
+		match := -1
+		for i, got := range extra {
+			if !paired[i] && sameConfigCommand(want, got) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			diff.Missing = append(diff.Missing, want)
+			continue
+		}
+		paired[match] = true
+		diff.Changed = append(diff.Changed, ChangedConfigLine{Expected: want, Actual: extra[match]})
+	}
+	for i, got := range extra {
+		if !paired[i] {
+			diff.Extra = append(diff.Extra, got)
+		}
+	}
+
+	return diff, len(diff.Missing) > 0 || len(diff.Extra) > 0 || len(diff.Changed) > 0
+}
+
+// sameConfigCommand reports whether two lines configure the same command, i.e. share
+// every word but the last. Single-word lines such as "shutdown" never match.
+func sameConfigCommand(a string, b string) bool {
+	aFields := strings.Fields(a)
+	bFields := strings.Fields(b)
+	if len(aFields) < 2 || len(bFields) < 2 || len(aFields) != len(bFields) {
+		// "description" is free text and may have any number of words.
+		return len(aFields) > 0 && len(bFields) > 0 && aFields[0] == "description" && bFields[0] == "description"
+	}
+	for i := 0; i < len(aFields)-1; i++ {
+		if aFields[i] != bFields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// splitConfigSections splits a configuration into top-level sections. Top-level lines
+// without children are collected in a single global section with an empty header.
+func splitConfigSections(config string) []configSection {
+	global := configSection{}
+	var sections []configSection
+	var current *configSection
+
+	for _, line := range strings.Split(strings.ReplaceAll(config, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if ignoredConfigLine(trimmed) {
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			if current != nil {
+				current.lines = append(current.lines, trimmed)
+			}
+			continue
+		}
+
+		sections = append(sections, configSection{header: trimmed})
+		current = &sections[len(sections)-1]
+	}
+
+	// Headers that never got children are global commands.
+	var result []configSection
+	for _, section := range sections {
+		if len(section.lines) == 0 && !strings.HasPrefix(section.header, "interface ") {
+			global.lines = append(global.lines, section.header)
+			continue
+		}
+		result = append(result, section)
+	}
+	if len(global.lines) > 0 {
+		result = append([]configSection{global}, result...)
+	}
+	return result
+}
+
+func ignoredConfigLine(line string) bool {
+	switch {
+	case line == "", line == "end", strings.HasPrefix(line, "!"):
+		return true
+	case strings.HasPrefix(line, "Building configuration"), strings.HasPrefix(line, "Current configuration"):
+		return true
+	case reCommandEcho.MatchString(line):
+		return true
+	}
+	return false
+}
+
+// configSectionKey normalizes a section header for comparison.
+func configSectionKey(header string) string {
+	if match := interfaceStartRegex.FindStringSubmatch(header); match != nil {
+		return "interface " + shortInterfaceName(match[1])
+	}
+	return header
+}
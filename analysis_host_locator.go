@@ -0,0 +1,198 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// HostLocation is the access port a host was found on.
+type HostLocation struct {
+	IPAddress   string // From the gateway's ARP table, if known
+	MacAddress  string // Cisco dotted format, e.g., 0011.2233.4455
+	Switch      string
+	Interface   string
+	VlanID      string
+	Description string
+	Vendor      string // From the OUI database set with SetOUIDatabase
+}
+
+// HostLocator finds hosts by combining the gateway's ARP table with the MAC address
+// tables of the access switches.
+type HostLocator struct {
+	Gateway  string   // Router or L3 switch holding the ARP entries
+	Switches []string // Switches whose MAC tables are searched
+}
+
+// NewHostLocator returns a HostLocator using gateway for ARP lookups and searching
+// the MAC address tables of switches.
+func NewHostLocator(gateway string, switches ...string) *HostLocator {
+	return &HostLocator{Gateway: gateway, Switches: switches}
+}
+
+// LocateHost finds the switch port of a host given its IP or MAC address. An IP address
+// is resolved to a MAC through the gateway's ARP table; the MAC is then searched in the
+// MAC address table of every switch, skipping uplinks (ports with a switch or router
+// CDP/LLDP neighbor), until it is found on an access port. ctx is checked between
+// devices, so a canceled search stops at the next switch.
+func (l *HostLocator) LocateHost(ctx context.Context, ipOrMac string) (HostLocation, error) {
+	var location HostLocation
+
+	if ip := net.ParseIP(strings.TrimSpace(ipOrMac)); ip != nil {
+		location.IPAddress = ip.String()
+	} else if mac := normalizeMacAddress(ipOrMac); mac != "" {
+		location.MacAddress = mac
+	} else {
+		return HostLocation{}, fmt.Errorf("%q is neither an IP nor a MAC address", ipOrMac)
+	}
+
+	if l.Gateway != "" {
+		query := location.IPAddress
+		if query == "" {
+			query = location.MacAddress
+		}
+		outputString, err := RunCommand(l.Gateway, "show ip arp "+query)
+		if err != nil {
+			return HostLocation{}, fmt.Errorf("%s: %w", l.Gateway, err)
+		}
+		ip, mac, found := findArpEntry(outputString, location.IPAddress, location.MacAddress)
+		if found {
+			location.IPAddress, location.MacAddress = ip, mac
+		}
+	}
+	if location.MacAddress == "" {
+		return HostLocation{}, fmt.Errorf("no ARP entry for %s on %s", location.IPAddress, l.Gateway)
+	}
+	location.Vendor = activeOUIDatabase().Vendor(location.MacAddress)
+
+	for _, switch_hostname := range l.Switches {
+		if err := ctx.Err(); err != nil {
+			return HostLocation{}, err
+		}
+
+		found, err := l.searchSwitch(switch_hostname, &location)
+		if err != nil {
+			return HostLocation{}, err
+		}
+		if found {
+			return location, nil
+		}
+	}
+
+	return HostLocation{}, fmt.Errorf("%s not found on an access port of %d switches", location.MacAddress, len(l.Switches))
+}
+
+// searchSwitch looks the MAC up on one switch and fills in location if it was learned on
+// an access port.
+func (l *HostLocator) searchSwitch(switch_hostname string, location *HostLocation) (bool, error) {
+	client, err := Connect(switch_hostname)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	outputString, err := client.RunCommand("show mac address-table address " + location.MacAddress)
+	if err != nil {
+		return false, err
+	}
+	entries, err := ParseMacAddressTable(outputString)
+	if err != nil || len(entries) == 0 {
+		return false, err
+	}
+
+	outputs, err := client.RunCommandsConcurrently([]string{"show cdp neighbors", "show lldp neighbors", "show interface status"})
+	if err != nil {
+		return false, err
+	}
+	cdpNeighbors, _ := ParseCdpNeighbors(outputs[0])
+	lldpNeighbors, _ := ParseLldpNeighbors(outputs[1])
+	statuses, _ := ParseInterfaceStatus(outputs[2])
+
+	for _, entry := range entries {
+		if normalizeMacAddress(entry.MacAddress) != location.MacAddress {
+			continue
+		}
+		if l.isUplink(entry.Interface, cdpNeighbors, lldpNeighbors) {
+			continue
+		}
+
+		location.Switch = switch_hostname
+		location.Interface = normalizeInterfaceName(entry.Interface)
+		location.VlanID = entry.VlanID
+		if status, ok := findInterfaceStatus(statuses, entry.Interface); ok {
+			location.Description = status.Description
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isUplink reports whether a port leads to another switch or router rather than to an
+// end host. Phones and access points are CDP/LLDP neighbors too, but hosts behind them
+// are still located on that port.
+func (l *HostLocator) isUplink(name string, cdpNeighbors []CdpNeighbor, lldpNeighbors []LldpNeighbor) bool {
+	name = shortInterfaceName(name)
+	if strings.HasPrefix(name, "Po") {
+		return true
+	}
+
+	for _, neighbor := range cdpNeighbors {
+		if shortInterfaceName(neighbor.Interface) != name {
+			continue
+		}
+		if strings.ContainsAny(neighbor.Capability, "SR") || l.isSwitch(neighbor.Neighbor) {
+			return true
+		}
+	}
+	for _, neighbor := range lldpNeighbors {
+		if shortInterfaceName(neighbor.Interface) != name {
+			continue
+		}
+		if strings.Contains(neighbor.Capability, "R") || l.isSwitch(neighbor.Neighbor) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *HostLocator) isSwitch(deviceID string) bool {
+	for _, switch_hostname := range l.Switches {
+		if sameDevice(deviceID, switch_hostname) {
+			return true
+		}
+	}
+	return sameDevice(deviceID, l.Gateway)
+}
+
+// findArpEntry returns the first resolved "show ip arp" entry matching ip or mac.
+func findArpEntry(rawOutput string, ip string, mac string) (string, string, bool) {
+	entries, _ := ParseArp(rawOutput)
+	for _, entry := range entries {
+		entryMac := normalizeMacAddress(entry.MacAddress)
+		if entryMac == "" {
+			continue // Incomplete
+		}
+		if (ip != "" && entry.IPAddress == ip) || (mac != "" && entryMac == mac) {
+			return entry.IPAddress, entryMac, true
+		}
+	}
+	return "", "", false
+}
+
+// normalizeMacAddress converts a MAC address in any common notation
+// (00:11:22:33:44:55, 00-11-22-33-44-55, 0011.2233.4455, 001122334455) to the
+// lowercase dotted Cisco format. It returns "" if s is not a MAC address.
+func normalizeMacAddress(s string) string {
+	hex := strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+	if len(hex) != 12 {
+		return ""
+	}
+	for _, c := range hex {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return ""
+		}
+	}
+	return hex[0:4] + "." + hex[4:8] + "." + hex[8:12]
+}
@@ -0,0 +1,126 @@
+package cisco
+
+import (
+	"fmt"
+	"sort"
+)
+
+// InspectionPort is a port where DHCP snooping or dynamic ARP inspection points at a
+// spoofing attempt or a misconfigured trust setting.
+type InspectionPort struct {
+	Interface string
+	Problem   string
+}
+
+// InspectionReport combines the DHCP snooping and ARP inspection counters of a switch
+// with the ports that need a look.
+type InspectionReport struct {
+	Switch        string
+	DhcpSnooping  DhcpSnoopingStatistics
+	ArpInspection []ArpInspectionVlanStatistics
+	Findings      []string // Switch and VLAN level observations, e.g. drops without a DHCP binding
+	Ports         []InspectionPort
+}
+
+// Report_inspection_drops collects DHCP snooping and ARP inspection statistics, the
+// ARP inspection trust settings, err-disabled ports and CDP neighbors from a switch
+// over one connection, and summarizes them with Summarize_inspection_drops.
+func Report_inspection_drops(switch_hostname string) (InspectionReport, error) {
+	client, err := connectToSwitch(switch_hostname)
+	if err != nil {
+		return InspectionReport{}, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrently([]string{
+		"show ip dhcp snooping statistics detail",
+		"show ip arp inspection statistics",
+		"show ip arp inspection interfaces",
+		"show interfaces status err-disabled",
+		"show cdp neighbors",
+	})
+	if err != nil {
+		return InspectionReport{}, err
+	}
+
+	snooping, err := ParseDhcpSnoopingStatistics(outputs[0])
+	if err != nil {
+		return InspectionReport{}, fmt.Errorf("%s :: show ip dhcp snooping statistics detail :: %w", switch_hostname, err)
+	}
+	vlans, err := ParseArpInspectionStatistics(outputs[1])
+	if err != nil {
+		return InspectionReport{}, fmt.Errorf("%s :: show ip arp inspection statistics :: %w", switch_hostname, err)
+	}
+	// Trust settings and neighbors only add port findings; the counters stand without them.
+	interfaces, _ := ParseArpInspectionInterfaces(outputs[2])
+	errDisabled := ParseErrDisabledStatus(outputs[3])
+	neighbors, _ := ParseCdpNeighbors(outputs[4])
+
+	return Summarize_inspection_drops(switch_hostname, snooping, vlans, interfaces, errDisabled, neighbors), nil
+}
+
+// Summarize_inspection_drops turns the counters into findings and flags ports that
+// were err-disabled by the DHCP snooping or ARP inspection rate limits, uplinks to
+// switches and routers left untrusted for ARP inspection, and trusted ports leading
+// to end devices.
+func Summarize_inspection_drops(switch_hostname string, snooping DhcpSnoopingStatistics, vlans []ArpInspectionVlanStatistics, interfaces []ArpInspectionInterface, errDisabled []ErrDisabledPort, neighbors []CdpNeighbor) InspectionReport {
+	report := InspectionReport{Switch: switch_hostname, DhcpSnooping: snooping, ArpInspection: vlans}
+
+	if snooping.DroppedUntrusted > 0 {
+		report.Findings = append(report.Findings, fmt.Sprintf("%d DHCP server messages dropped on untrusted ports: a rogue DHCP server, or an uplink to the real one not trusted", snooping.DroppedUntrusted))
+	}
+	reasons := make([]string, 0, len(snooping.DropReasons))
+	for reason := range snooping.DropReasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		if count := snooping.DropReasons[reason]; count > 0 && reason != "Received on untrusted ports" {
+			report.Findings = append(report.Findings, fmt.Sprintf("%d DHCP packets dropped: %s", count, reason))
+		}
+	}
+
+	for _, vlan := range vlans {
+		if vlan.DhcpDrops > 0 {
+			report.Findings = append(report.Findings, fmt.Sprintf("VLAN %s: %d ARP packets without a DHCP snooping binding: spoofing, or a static address without an ARP ACL", vlan.Vlan, vlan.DhcpDrops))
+		}
+		if validation := vlan.SourceMacFailures + vlan.DestMacFailures + vlan.IPValidationFailures + vlan.InvalidProtocolData; validation > 0 {
+			report.Findings = append(report.Findings, fmt.Sprintf("VLAN %s: %d ARP packets failed validation", vlan.Vlan, validation))
+		}
+	}
+
+	for _, port := range errDisabled {
+		switch port.Cause {
+		case "dhcp-rate-limit":
+			report.Ports = append(report.Ports, InspectionPort{Interface: port.Interface, Problem: "err-disabled by the DHCP snooping rate limit"})
+		case "arp-inspection":
+			report.Ports = append(report.Ports, InspectionPort{Interface: port.Interface, Problem: "err-disabled by the ARP inspection rate limit"})
+		}
+	}
+
+	for _, iface := range interfaces {
+		for _, neighbor := range neighbors {
+			if shortInterfaceName(neighbor.Interface) != shortInterfaceName(iface.Interface) {
+				continue
+			}
+			switch {
+			case !iface.Trusted && (neighbor.Kind == DeviceSwitch || neighbor.Kind == DeviceRouter):
+				report.Ports = append(report.Ports, InspectionPort{Interface: iface.Interface, Problem: fmt.Sprintf("untrusted for ARP inspection but leads to %s %s", neighbor.Kind, neighbor.Neighbor)})
+			case iface.Trusted && (neighbor.Kind == DevicePhone || neighbor.Kind == DeviceHost || neighbor.Kind == DeviceCamera):
+				report.Ports = append(report.Ports, InspectionPort{Interface: iface.Interface, Problem: fmt.Sprintf("trusted for ARP inspection but leads to %s %s", neighbor.Kind, neighbor.Neighbor)})
+			}
+		}
+	}
+
+	SortByInterface(report.Ports, func(p InspectionPort) string { return p.Interface })
+	for i := range report.Ports {
+		report.Ports[i].Interface = normalizeInterfaceName(report.Ports[i].Interface)
+	}
+
+	return report
+}
+
+// Problems reports whether the report found anything to look at.
+func (r InspectionReport) Problems() bool {
+	return len(r.Findings) > 0 || len(r.Ports) > 0
+}
@@ -0,0 +1,150 @@
+package cisco
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LinkHealth is the health assessment of one interface between two "show interface" samples.
+type LinkHealth struct {
+	Interface string
+	Interval  time.Duration
+
+	// Counter deltas between the two samples
+	PacketsInput  uint64
+	PacketsOutput uint64
+	InputErrors   uint64
+	OutputErrors  uint64
+	CrcErrors     uint64
+	InputDrops    uint64
+	OutputDrops   uint64
+
+	// Average utilization over the interval, in percent of the interface bandwidth
+	InputUtilization  float64
+	OutputUtilization float64
+
+	Score   int      // 100 is a perfectly healthy link, 0 the worst
+	Reasons []string // Why points were deducted
+}
+
+// Score_link_health compares two samples of "show interface" taken interval apart and
+// scores every interface present in both, returning the results worst first so
+// dashboards can rank links fleet-wide.
+func Score_link_health(previous []InterfaceDetails, current []InterfaceDetails, interval time.Duration) []LinkHealth {
+	previousByName := make(map[string]InterfaceDetails, len(previous))
+	for _, iface := range previous {
+		previousByName[iface.Interface] = iface
+	}
+
+	var results []LinkHealth
+	for _, iface := range current {
+		prev, ok := previousByName[iface.Interface]
+		if !ok {
+			continue
+		}
+		results = append(results, ScoreLinkHealth(prev, iface, interval))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score < results[j].Score
+	})
+
+	return results
+}
+
+// ScoreLinkHealth scores a single interface from two samples taken interval apart.
+func ScoreLinkHealth(previous InterfaceDetails, current InterfaceDetails, interval time.Duration) LinkHealth {
+	health := LinkHealth{
+		Interface:     current.Interface,
+		Interval:      interval,
+		PacketsInput:  counterDelta(previous.PacketsInput, current.PacketsInput),
+		PacketsOutput: counterDelta(previous.PacketsOutput, current.PacketsOutput),
+		InputErrors:   counterDelta(previous.InputErrors, current.InputErrors),
+		OutputErrors:  counterDelta(previous.OutputErrors, current.OutputErrors),
+		CrcErrors:     counterDelta(previous.CrcErrors, current.CrcErrors),
+		InputDrops:    counterDelta(previous.InputDrops, current.InputDrops),
+		OutputDrops:   counterDelta(previous.OutputDrops, current.OutputDrops),
+		Score:         100,
+	}
+
+	deduct := func(points int, reason string) {
+		health.Score -= points
+		health.Reasons = append(health.Reasons, reason)
+	}
+
+	if bandwidthKbit, err := strconv.ParseFloat(current.Bandwidth, 64); err == nil && bandwidthKbit > 0 && interval > 0 {
+		capacity := bandwidthKbit * 1000 * interval.Seconds()
+		health.InputUtilization = float64(counterDelta(previous.BytesInput, current.BytesInput)) * 8 / capacity * 100
+		health.OutputUtilization = float64(counterDelta(previous.BytesOutput, current.BytesOutput)) * 8 / capacity * 100
+	}
+
+	if previous.LinkStatus == "up" && current.LinkStatus != "up" {
+		deduct(50, fmt.Sprintf("link went %s", current.LinkStatus))
+	} else if current.LinkStatus == "up" && current.ProtocolStatus != "up" {
+		deduct(40, fmt.Sprintf("line protocol is %s", current.ProtocolStatus))
+	}
+
+	if health.CrcErrors > 0 {
+		deduct(errorPenalty(health.CrcErrors, health.PacketsInput, 30), fmt.Sprintf("%d CRC errors", health.CrcErrors))
+	}
+	if otherInputErrors := health.InputErrors - min(health.InputErrors, health.CrcErrors); otherInputErrors > 0 {
+		deduct(errorPenalty(otherInputErrors, health.PacketsInput, 20), fmt.Sprintf("%d input errors", otherInputErrors))
+	}
+	if health.OutputErrors > 0 {
+		deduct(errorPenalty(health.OutputErrors, health.PacketsOutput, 20), fmt.Sprintf("%d output errors", health.OutputErrors))
+	}
+	if health.InputDrops > 0 {
+		deduct(errorPenalty(health.InputDrops, health.PacketsInput, 15), fmt.Sprintf("%d input drops", health.InputDrops))
+	}
+	if health.OutputDrops > 0 {
+		deduct(errorPenalty(health.OutputDrops, health.PacketsOutput, 15), fmt.Sprintf("%d output drops", health.OutputDrops))
+	}
+
+	if peak := math.Max(health.InputUtilization, health.OutputUtilization); peak >= 90 {
+		deduct(20, fmt.Sprintf("utilization at %.1f%%", peak))
+	} else if peak >= 70 {
+		deduct(10, fmt.Sprintf("utilization at %.1f%%", peak))
+	}
+
+	if current.LinkStatus == "up" && strings.HasPrefix(strings.ToLower(current.Duplex), "half") {
+		deduct(10, "running half-duplex")
+	}
+
+	if health.Score < 0 {
+		health.Score = 0
+	}
+
+	return health
+}
+
+// errorPenalty scales a deduction by how bad an error count is relative to the traffic:
+// a handful of errors over millions of packets costs a third of maxPoints, while an
+// error ratio of 0.1% or more costs the full amount.
+func errorPenalty(errors uint64, packets uint64, maxPoints int) int {
+	if packets == 0 || float64(errors)/float64(packets) >= 0.001 {
+		return maxPoints
+	}
+	return max(1, maxPoints/3)
+}
+
+// counterDelta returns the increase of an interface counter between two samples. When the
+// counter went backwards it either wrapped at 32 bits or was cleared; in the latter case
+// the current value is the best estimate. Unparseable values count as zero.
+func counterDelta(previous string, current string) uint64 {
+	prev, errPrev := strconv.ParseUint(previous, 10, 64)
+	curr, errCurr := strconv.ParseUint(current, 10, 64)
+	if errPrev != nil || errCurr != nil {
+		return 0
+	}
+	if curr >= prev {
+		return curr - prev
+	}
+	if prev <= math.MaxUint32 && prev-curr > math.MaxUint32/2 {
+		return curr + (math.MaxUint32 - prev) + 1
+	}
+	return curr
+}
@@ -0,0 +1,199 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LinkEndpoint is one side of a link between two switches.
+type LinkEndpoint struct {
+	Switch    string
+	Interface string
+	Duplex    string
+	Speed     string
+}
+
+// LinkMismatch is a link whose two sides negotiated different duplex or speed settings.
+type LinkMismatch struct {
+	Local   LinkEndpoint
+	Remote  LinkEndpoint
+	Reasons []string
+}
+
+// Find_link_mismatches collects "show interface status" and CDP/LLDP neighbors from every
+// switch and reports links whose two ends disagree on duplex or speed. Only links between
+// switches in switch_hostnames can be compared. Switches that fail to respond are
+// skipped and their errors returned alongside the mismatches found on the others.
+func Find_link_mismatches(switch_hostnames []string) ([]LinkMismatch, error) {
+	var snapshots []DeviceSnapshot
+	var errs []error
+
+	for _, switch_hostname := range switch_hostnames {
+		snapshot := DeviceSnapshot{SwitchHostname: switch_hostname}
+		client, err := connectToSwitch(switch_hostname)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+			continue
+		}
+
+		if snapshot.InterfacesStatus, err = client.ShowInterfacesStatus(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+			client.Close()
+			continue
+		}
+		if snapshot.CdpNeighbors, err = client.ShowCdpNeighbors(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+		}
+		if snapshot.LldpNeighbors, err = client.ShowLldpNeighbors(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+		}
+		client.Close()
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return Detect_link_mismatches(snapshots), errors.Join(errs...)
+}
+
+// Detect_link_mismatches pairs both ends of every link through the CDP and LLDP neighbor
+// tables in the snapshots and compares the duplex and speed each side reports in its
+// interface status. Each mismatched link is reported once.
+func Detect_link_mismatches(snapshots []DeviceSnapshot) []LinkMismatch {
+	var mismatches []LinkMismatch
+	seen := make(map[string]bool)
+
+	for _, local := range snapshots {
+		for _, link := range neighborLinks(local) {
+			remote, ok := findSnapshot(snapshots, link.neighbor)
+			if !ok {
+				continue
+			}
+
+			localStatus, ok := findInterfaceStatus(local.InterfacesStatus, link.localInterface)
+			if !ok {
+				continue
+			}
+			remoteStatus, ok := findInterfaceStatus(remote.InterfacesStatus, link.remoteInterface)
+			if !ok {
+				continue
+			}
+			if localStatus.Status != "connected" || remoteStatus.Status != "connected" {
+				continue
+			}
+
+			key := linkKey(local.SwitchHostname, localStatus.Interface, remote.SwitchHostname, remoteStatus.Interface)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			var reasons []string
+			localDuplex, remoteDuplex := normalizeDuplex(localStatus.Duplex), normalizeDuplex(remoteStatus.Duplex)
+			if localDuplex != "" && remoteDuplex != "" && localDuplex != remoteDuplex {
+				reasons = append(reasons, fmt.Sprintf("duplex %s vs %s", localDuplex, remoteDuplex))
+			}
+			localSpeed, remoteSpeed := normalizeSpeed(localStatus.Speed), normalizeSpeed(remoteStatus.Speed)
+			if localSpeed != "" && remoteSpeed != "" && localSpeed != remoteSpeed {
+				reasons = append(reasons, fmt.Sprintf("speed %s vs %s", localSpeed, remoteSpeed))
+			}
+			if len(reasons) == 0 {
+				continue
+			}
+
+			mismatches = append(mismatches, LinkMismatch{
+				Local:   LinkEndpoint{Switch: local.SwitchHostname, Interface: localStatus.Interface, Duplex: localStatus.Duplex, Speed: localStatus.Speed},
+				Remote:  LinkEndpoint{Switch: remote.SwitchHostname, Interface: remoteStatus.Interface, Duplex: remoteStatus.Duplex, Speed: remoteStatus.Speed},
+				Reasons: reasons,
+			})
+		}
+	}
+
+	return mismatches
+}
+
+// neighborLink is a link learned from CDP or LLDP.
+type neighborLink struct {
+	localInterface  string
+	neighbor        string
+	remoteInterface string
+}
+
+// neighborLinks returns the CDP and LLDP links of a snapshot.
+func neighborLinks(snapshot DeviceSnapshot) []neighborLink {
+	var links []neighborLink
+	for _, n := range snapshot.CdpNeighbors {
+		links = append(links, neighborLink{localInterface: n.Interface, neighbor: n.Neighbor, remoteInterface: n.NeighborInterface})
+	}
+	for _, n := range snapshot.LldpNeighbors {
+		links = append(links, neighborLink{localInterface: n.Interface, neighbor: n.Neighbor, remoteInterface: n.NeighborInterface})
+	}
+	return links
+}
+
+// findSnapshot finds the snapshot of the switch a neighbor entry refers to.
+func findSnapshot(snapshots []DeviceSnapshot, neighbor string) (DeviceSnapshot, bool) {
+	for _, snapshot := range snapshots {
+		if sameDevice(neighbor, snapshot.SwitchHostname) {
+			return snapshot, true
+		}
+	}
+	return DeviceSnapshot{}, false
+}
+
+// sameDevice reports whether a CDP/LLDP device ID refers to hostname. Device IDs may be
+// fully qualified, or carry the serial number in parentheses on NX-OS ("core01(FOX123)").
+func sameDevice(deviceID string, hostname string) bool {
+	shortName := func(name string) string {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i := strings.Index(name, "("); i > 0 {
+			name = name[:i]
+		}
+		if i := strings.Index(name, "."); i > 0 {
+			name = name[:i]
+		}
+		return name
+	}
+	return deviceID != "" && shortName(deviceID) == shortName(hostname)
+}
+
+// findInterfaceStatus looks up an interface by name, comparing normalized names.
+func findInterfaceStatus(statuses []InterfaceStatus, name string) (InterfaceStatus, bool) {
+	name = shortInterfaceName(name)
+	for _, status := range statuses {
+		if shortInterfaceName(status.Interface) == name {
+			return status, true
+		}
+	}
+	return InterfaceStatus{}, false
+}
+
+// linkKey identifies a link independently of which side it was seen from.
+func linkKey(switchA, interfaceA, switchB, interfaceB string) string {
+	a := strings.ToLower(switchA) + "|" + interfaceA
+	b := strings.ToLower(switchB) + "|" + interfaceB
+	if a > b {
+		a, b = b, a
+	}
+	return a + "<>" + b
+}
+
+// normalizeDuplex turns "a-full", "full", "a-half" into "full" or "half" ("" if unknown).
+func normalizeDuplex(duplex string) string {
+	duplex = strings.TrimPrefix(strings.ToLower(duplex), "a-")
+	if duplex == "full" || duplex == "half" {
+		return duplex
+	}
+	return ""
+}
+
+// normalizeSpeed turns "a-1000", "1000", "1G", "10G" into megabits ("" if unknown).
+func normalizeSpeed(speed string) string {
+	speed = strings.TrimPrefix(strings.ToLower(speed), "a-")
+	if strings.HasSuffix(speed, "g") {
+		return strings.TrimSuffix(speed, "g") + "000"
+	}
+	if speed == "" || speed == "auto" {
+		return ""
+	}
+	return speed
+}
@@ -0,0 +1,167 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// PoeAddition is a planned batch of powered devices, e.g. 12 new access points at 25.5W.
+// Set Switch to plan them on a specific switch, or only Closet to plan them somewhere
+// in that closet.
+type PoeAddition struct {
+	Description string
+	Switch      string
+	Closet      string
+	Count       int
+	Watts       float64 // Per device
+}
+
+// PoeCapacity is the PoE budget of a single switch, summed over its power modules.
+type PoeCapacity struct {
+	Switch       string
+	Closet       string
+	Available    float64 // Watts
+	Used         float64
+	Remaining    float64
+	Planned      float64 // Watts of planned additions assigned to this switch
+	Headroom     float64 // Remaining - Planned
+	FreePoePorts int     // PoE capable ports currently not powering a device
+	PlannedPorts int
+	OverBudget   bool
+	Reasons      []string
+}
+
+// ClosetPoeCapacity aggregates the PoE budget of every switch in a closet.
+type ClosetPoeCapacity struct {
+	Closet       string
+	Switches     []string
+	Available    float64
+	Used         float64
+	Remaining    float64
+	Planned      float64 // Switch and closet level additions
+	Headroom     float64
+	FreePoePorts int
+	PlannedPorts int
+	OverBudget   bool
+	Reasons      []string
+}
+
+// Find_poe_capacity collects "show power inline" from every switch and runs
+// Analyze_poe_capacity. closets maps switch hostnames to closet names. Switches that
+// fail to respond are skipped and their errors returned alongside the analysis.
+func Find_poe_capacity(switch_hostnames []string, closets map[string]string, additions []PoeAddition) ([]PoeCapacity, []ClosetPoeCapacity, error) {
+	var snapshots []DeviceSnapshot
+	var errs []error
+
+	for _, switch_hostname := range switch_hostnames {
+		modules, interfaces, err := Show_power_inline(switch_hostname)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+			continue
+		}
+		snapshots = append(snapshots, DeviceSnapshot{SwitchHostname: switch_hostname, PowerModules: modules, PowerInterfaces: interfaces})
+	}
+
+	switches, closetCapacity := Analyze_poe_capacity(snapshots, closets, additions)
+	return switches, closetCapacity, errors.Join(errs...)
+}
+
+// Analyze_poe_capacity sums the power inline data of every switch and closet, subtracts
+// the planned additions and flags switches and closets that would exceed their power
+// budget or run out of free PoE ports.
+func Analyze_poe_capacity(snapshots []DeviceSnapshot, closets map[string]string, additions []PoeAddition) ([]PoeCapacity, []ClosetPoeCapacity) {
+	var switches []PoeCapacity
+	closetIndex := make(map[string]*ClosetPoeCapacity)
+
+	for _, snapshot := range snapshots {
+		capacity := PoeCapacity{Switch: snapshot.SwitchHostname, Closet: closets[snapshot.SwitchHostname]}
+
+		for _, module := range snapshot.PowerModules {
+			capacity.Available += parseWatts(module.Available)
+			capacity.Used += parseWatts(module.Used)
+			capacity.Remaining += parseWatts(module.Remaining)
+		}
+		for _, iface := range snapshot.PowerInterfaces {
+			if iface.Admin != "off" && iface.Oper == "off" {
+				capacity.FreePoePorts++
+			}
+		}
+		for _, addition := range additions {
+			if addition.Switch != "" && sameDevice(addition.Switch, snapshot.SwitchHostname) {
+				capacity.Planned += float64(addition.Count) * addition.Watts
+				capacity.PlannedPorts += addition.Count
+			}
+		}
+
+		capacity.Headroom = capacity.Remaining - capacity.Planned
+		if capacity.Headroom < 0 {
+			capacity.OverBudget = true
+			capacity.Reasons = append(capacity.Reasons, fmt.Sprintf("planned %.1fW exceeds remaining %.1fW", capacity.Planned, capacity.Remaining))
+		}
+		if capacity.PlannedPorts > capacity.FreePoePorts {
+			capacity.OverBudget = true
+			capacity.Reasons = append(capacity.Reasons, fmt.Sprintf("%d planned devices but only %d free PoE ports", capacity.PlannedPorts, capacity.FreePoePorts))
+		}
+		switches = append(switches, capacity)
+
+		if capacity.Closet == "" {
+			continue
+		}
+		closet, ok := closetIndex[capacity.Closet]
+		if !ok {
+			closet = &ClosetPoeCapacity{Closet: capacity.Closet}
+			closetIndex[capacity.Closet] = closet
+		}
+		closet.Switches = append(closet.Switches, capacity.Switch)
+		closet.Available += capacity.Available
+		closet.Used += capacity.Used
+		closet.Remaining += capacity.Remaining
+		closet.Planned += capacity.Planned
+		closet.FreePoePorts += capacity.FreePoePorts
+		closet.PlannedPorts += capacity.PlannedPorts
+	}
+
+	// Closet level additions only count against the closet aggregate.
+	for _, addition := range additions {
+		if addition.Switch != "" || addition.Closet == "" {
+			continue
+		}
+		closet, ok := closetIndex[addition.Closet]
+		if !ok {
+			closet = &ClosetPoeCapacity{Closet: addition.Closet}
+			closetIndex[addition.Closet] = closet
+		}
+		closet.Planned += float64(addition.Count) * addition.Watts
+		closet.PlannedPorts += addition.Count
+	}
+
+	var closetCapacity []ClosetPoeCapacity
+	for _, closet := range closetIndex {
+		closet.Headroom = closet.Remaining - closet.Planned
+		if closet.Headroom < 0 {
+			closet.OverBudget = true
+			closet.Reasons = append(closet.Reasons, fmt.Sprintf("planned %.1fW exceeds remaining %.1fW", closet.Planned, closet.Remaining))
+		}
+		if closet.PlannedPorts > closet.FreePoePorts {
+			closet.OverBudget = true
+			closet.Reasons = append(closet.Reasons, fmt.Sprintf("%d planned devices but only %d free PoE ports", closet.PlannedPorts, closet.FreePoePorts))
+		}
+		closetCapacity = append(closetCapacity, *closet)
+	}
+	sort.Slice(closetCapacity, func(i, j int) bool {
+		return closetCapacity[i].Closet < closetCapacity[j].Closet
+	})
+
+	return switches, closetCapacity
+}
+
+// parseWatts parses a wattage column such as "740.0", returning 0 for "n/a" or blanks.
+func parseWatts(value string) float64 {
+	watts, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return watts
+}
@@ -0,0 +1,120 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PortCounts tallies port states for a switch or one of its modules.
+type PortCounts struct {
+	Module      string // e.g., "1/0" for Gi1/0/1; empty for the switch total
+	Total       int
+	Connected   int
+	NotConnect  int
+	Disabled    int
+	ErrDisabled int
+	Trunks      int
+	// FreeAccess counts access ports that are notconnect or disabled, keyed by the
+	// port type column (e.g., "10/100/1000BaseTX"), which reflects the port speed.
+	FreeAccess map[string]int
+}
+
+// PortCapacityReport summarizes port usage of a switch, in total and per module.
+type PortCapacityReport struct {
+	Switch  string
+	Totals  PortCounts
+	Modules []PortCounts
+}
+
+// Report_port_capacity collects "show interface status" from every switch and returns a
+// capacity report for each. Switches that fail to respond are skipped and their errors
+// returned alongside the reports of the others.
+func Report_port_capacity(switch_hostnames []string) ([]PortCapacityReport, error) {
+	var reports []PortCapacityReport
+	var errs []error
+
+	for _, switch_hostname := range switch_hostnames {
+		statuses, err := Show_interfaces_status(switch_hostname)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+			continue
+		}
+		reports = append(reports, Summarize_port_capacity(switch_hostname, statuses))
+	}
+
+	return reports, errors.Join(errs...)
+}
+
+// Summarize_port_capacity counts port states from "show interface status" output.
+// Logical interfaces (VLANs, port-channels, loopbacks, tunnels) are not counted.
+func Summarize_port_capacity(switch_hostname string, statuses []InterfaceStatus) PortCapacityReport {
+	report := PortCapacityReport{Switch: switch_hostname, Totals: PortCounts{FreeAccess: make(map[string]int)}}
+	modules := make(map[string]*PortCounts)
+
+	for _, status := range statuses {
+		module, ok := portModule(status.Interface)
+		if !ok {
+			continue
+		}
+		counts, exists := modules[module]
+		if !exists {
+			counts = &PortCounts{Module: module, FreeAccess: make(map[string]int)}
+			modules[module] = counts
+		}
+		countPort(counts, status)
+		countPort(&report.Totals, status)
+	}
+
+	for _, counts := range modules {
+		report.Modules = append(report.Modules, *counts)
+	}
+	sort.Slice(report.Modules, func(i, j int) bool {
+		return report.Modules[i].Module < report.Modules[j].Module
+	})
+
+	return report
+}
+
+func countPort(counts *PortCounts, status InterfaceStatus) {
+	counts.Total++
+
+	trunk := status.VlanID == "trunk"
+	if trunk {
+		counts.Trunks++
+	}
+
+	switch status.Status {
+	case "connected":
+		counts.Connected++
+	case "notconnect":
+		counts.NotConnect++
+	case "disabled":
+		counts.Disabled++
+	case "err-disabled":
+		counts.ErrDisabled++
+	}
+
+	if !trunk && status.VlanID != "routed" && (status.Status == "notconnect" || status.Status == "disabled") {
+		counts.FreeAccess[status.Type]++
+	}
+}
+
+// portModule returns the module part of a physical interface name, e.g. "1/0" for
+// "Gi1/0/24" or "1" for "Eth1/49". It reports false for logical interfaces.
+func portModule(name string) (string, bool) {
+	name = shortInterfaceName(name)
+	for _, prefix := range []string{"Vl", "Po", "Lo", "Tu", "Nu", "Ap"} {
+		if strings.HasPrefix(name, prefix) {
+			return "", false
+		}
+	}
+
+	digits := strings.IndexAny(name, "0123456789")
+	slash := strings.LastIndex(name, "/")
+	if digits < 0 || slash < digits {
+		return "", false
+	}
+	return name[digits:slash], true
+}
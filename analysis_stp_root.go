@@ -0,0 +1,171 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StpRootViolation reports a VLAN whose spanning-tree root or root port is not where it should be.
+type StpRootViolation struct {
+	Vlan    string // VLAN ID, e.g., 10
+	Switch  string // Switch reporting the problem
+	Problem string
+}
+
+// Validate_stp_root_placement collects "show spanning-tree root" and CDP neighbors from every
+// switch and checks them against expectedRoots (VLAN ID → hostname of the intended root
+// bridge). Switches that fail to respond are skipped and their errors returned alongside
+// the violations found on the others.
+func Validate_stp_root_placement(expectedRoots map[string]string, switch_hostnames []string) ([]StpRootViolation, error) {
+	var snapshots []DeviceSnapshot
+	var errs []error
+
+	for _, switch_hostname := range switch_hostnames {
+		snapshot := DeviceSnapshot{SwitchHostname: switch_hostname}
+		client, err := connectToSwitch(switch_hostname)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+			continue
+		}
+
+		if snapshot.SpanningTreeRoot, err = client.ShowSpanningTreeRoot(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+			client.Close()
+			continue
+		}
+		if snapshot.CdpNeighbors, err = client.ShowCdpNeighbors(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", switch_hostname, err))
+		}
+		client.Close()
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return Check_stp_root_placement(expectedRoots, snapshots), errors.Join(errs...)
+}
+
+// Check_stp_root_placement validates spanning-tree root placement from collected snapshots:
+//   - the expected root switch must consider itself root for the VLAN,
+//   - no other switch may consider itself root,
+//   - every switch must agree on the same root bridge MAC,
+//   - every root port must lead to a neighbor that is closer to the root (lower root cost),
+//     so traffic towards the root does not take an unexpected direction.
+func Check_stp_root_placement(expectedRoots map[string]string, snapshots []DeviceSnapshot) []StpRootViolation {
+	var violations []StpRootViolation
+
+	// Index each switch's view of each VLAN.
+	views := make(map[string]map[string]SpanningTreeRoot) // VLAN ID → switch → root entry
+	for _, snapshot := range snapshots {
+		for _, root := range snapshot.SpanningTreeRoot {
+			vlan := stpVlanID(root.Vlan)
+			if views[vlan] == nil {
+				views[vlan] = make(map[string]SpanningTreeRoot)
+			}
+			views[vlan][snapshot.SwitchHostname] = root
+		}
+	}
+
+	vlans := make([]string, 0, len(expectedRoots))
+	for vlan := range expectedRoots {
+		vlans = append(vlans, vlan)
+	}
+	sort.Slice(vlans, func(i, j int) bool {
+		a, _ := strconv.Atoi(vlans[i])
+		b, _ := strconv.Atoi(vlans[j])
+		return a < b
+	})
+
+	for _, vlan := range vlans {
+		expectedRoot := expectedRoots[vlan]
+		vlanViews := views[stpVlanID(vlan)]
+		if len(vlanViews) == 0 {
+			violations = append(violations, StpRootViolation{Vlan: vlan, Problem: "no switch reports spanning-tree for this VLAN"})
+			continue
+		}
+
+		var expectedView SpanningTreeRoot
+		expectedFound := false
+		for _, snapshot := range snapshots {
+			view, ok := vlanViews[snapshot.SwitchHostname]
+			if !ok {
+				continue
+			}
+			isExpected := sameDevice(expectedRoot, snapshot.SwitchHostname)
+			isRoot := view.RootPort == ""
+
+			switch {
+			case isExpected && !isRoot:
+				violations = append(violations, StpRootViolation{Vlan: vlan, Switch: snapshot.SwitchHostname,
+					Problem: fmt.Sprintf("expected to be root but root is %s %s via %s", view.RootPriority, view.RootMac, view.RootPort)})
+			case !isExpected && isRoot:
+				violations = append(violations, StpRootViolation{Vlan: vlan, Switch: snapshot.SwitchHostname,
+					Problem: fmt.Sprintf("is root bridge (priority %s) instead of %s", view.RootPriority, expectedRoot)})
+			}
+			if isExpected {
+				expectedView = view
+				expectedFound = true
+			}
+		}
+
+		// Every switch should agree with the expected root's view of the root bridge.
+		if expectedFound {
+			for _, snapshot := range snapshots {
+				view, ok := vlanViews[snapshot.SwitchHostname]
+				if ok && view.RootMac != expectedView.RootMac {
+					violations = append(violations, StpRootViolation{Vlan: vlan, Switch: snapshot.SwitchHostname,
+						Problem: fmt.Sprintf("sees root bridge %s, %s sees %s", view.RootMac, expectedRoot, expectedView.RootMac)})
+				}
+			}
+		}
+
+		// Root ports must point towards a neighbor closer to the root.
+		for _, snapshot := range snapshots {
+			view, ok := vlanViews[snapshot.SwitchHostname]
+			if !ok || view.RootPort == "" {
+				continue
+			}
+			neighbor, ok := cdpNeighborOn(snapshot.CdpNeighbors, view.RootPort)
+			if !ok {
+				continue
+			}
+			neighborSnapshot, ok := findSnapshot(snapshots, neighbor.Neighbor)
+			if !ok {
+				continue
+			}
+			neighborView, ok := vlanViews[neighborSnapshot.SwitchHostname]
+			if !ok {
+				continue
+			}
+			ownCost, errOwn := strconv.Atoi(view.RootCost)
+			neighborCost, errNeighbor := strconv.Atoi(neighborView.RootCost)
+			if errOwn == nil && errNeighbor == nil && neighborCost >= ownCost {
+				violations = append(violations, StpRootViolation{Vlan: vlan, Switch: snapshot.SwitchHostname,
+					Problem: fmt.Sprintf("root port %s leads to %s whose root cost %d is not lower than %d", view.RootPort, neighborSnapshot.SwitchHostname, neighborCost, ownCost)})
+			}
+		}
+	}
+
+	return violations
+}
+
+// stpVlanID turns "VLAN0010" or "10" into "10".
+func stpVlanID(vlan string) string {
+	vlan = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(vlan)), "VLAN")
+	if n, err := strconv.Atoi(vlan); err == nil {
+		return strconv.Itoa(n)
+	}
+	return vlan
+}
+
+// cdpNeighborOn returns the CDP neighbor seen on a local interface.
+func cdpNeighborOn(neighbors []CdpNeighbor, localInterface string) (CdpNeighbor, bool) {
+	localInterface = shortInterfaceName(localInterface)
+	for _, neighbor := range neighbors {
+		if shortInterfaceName(neighbor.Interface) == localInterface {
+			return neighbor, true
+		}
+	}
+	return CdpNeighbor{}, false
+}
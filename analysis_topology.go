@@ -0,0 +1,148 @@
+package cisco
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TopologyNode is a device in the topology: a collected switch or a CDP/LLDP neighbor.
+type TopologyNode struct {
+	ID        string `json:"id"`
+	Platform  string `json:"platform,omitempty"` // From CDP, when a neighbor reported it
+	Collected bool   `json:"collected"`          // A snapshot of this device was part of the input
+}
+
+// TopologyLink is a link between two devices, seen by CDP, LLDP or both.
+type TopologyLink struct {
+	Source          string   `json:"source"`
+	SourceInterface string   `json:"source_interface"`
+	Target          string   `json:"target"`
+	TargetInterface string   `json:"target_interface"`
+	Protocols       []string `json:"protocols"`
+}
+
+// Topology is the network graph built from CDP and LLDP neighbor tables.
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Links []TopologyLink `json:"links"`
+}
+
+// Build_topology turns the CDP and LLDP neighbors of the snapshots into a graph. A link
+// seen from both ends, or by both protocols, appears once. Neighbors without a snapshot
+// (phones, access points, switches outside the input) become leaf nodes.
+func Build_topology(snapshots []DeviceSnapshot) Topology {
+	nodes := make(map[string]*TopologyNode)
+	node := func(id string) *TopologyNode {
+		if nodes[id] == nil {
+			nodes[id] = &TopologyNode{ID: id}
+		}
+		return nodes[id]
+	}
+	nodeID := func(deviceID string) string {
+		if snapshot, ok := findSnapshot(snapshots, deviceID); ok {
+			return snapshot.SwitchHostname
+		}
+		return shortNeighborName(deviceID)
+	}
+
+	links := make(map[string]*TopologyLink)
+	var order []string
+	addLink := func(protocol string, source string, sourceInterface string, neighbor string, targetInterface string) {
+		target := nodeID(neighbor)
+		node(target)
+		key := linkKey(source, shortInterfaceName(sourceInterface), target, shortInterfaceName(targetInterface))
+		link, ok := links[key]
+		if !ok {
+			link = &TopologyLink{Source: source, SourceInterface: sourceInterface, Target: target, TargetInterface: targetInterface}
+			links[key] = link
+			order = append(order, key)
+		}
+		for _, p := range link.Protocols {
+			if p == protocol {
+				return
+			}
+		}
+		link.Protocols = append(link.Protocols, protocol)
+	}
+
+	for _, snapshot := range snapshots {
+		node(snapshot.SwitchHostname).Collected = true
+		for _, n := range snapshot.CdpNeighbors {
+			addLink("cdp", snapshot.SwitchHostname, n.Interface, n.Neighbor, n.NeighborInterface)
+			if n.Platform != "" {
+				node(nodeID(n.Neighbor)).Platform = n.Platform
+			}
+		}
+		for _, n := range snapshot.LldpNeighbors {
+			addLink("lldp", snapshot.SwitchHostname, n.Interface, n.Neighbor, n.NeighborInterface)
+		}
+	}
+
+	var topology Topology
+	for _, n := range nodes {
+		topology.Nodes = append(topology.Nodes, *n)
+	}
+	sort.Slice(topology.Nodes, func(i, j int) bool { return topology.Nodes[i].ID < topology.Nodes[j].ID })
+	for _, key := range order {
+		sort.Strings(links[key].Protocols)
+		topology.Links = append(topology.Links, *links[key])
+	}
+
+	return topology
+}
+
+// DOT renders the topology as a Graphviz graph with the interfaces as edge end labels,
+// e.g. for "dot -Tsvg". Collected switches are drawn as boxes.
+func (t Topology) DOT() string {
+	var b strings.Builder
+	b.WriteString("graph topology {\n")
+	for _, n := range t.Nodes {
+		label := n.ID
+		if n.Platform != "" {
+			label += "\n" + n.Platform
+		}
+		shape := "ellipse"
+		if n.Collected {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(n.ID), dotQuote(label), shape)
+	}
+	for _, l := range t.Links {
+		fmt.Fprintf(&b, "  %s -- %s [taillabel=%s, headlabel=%s];\n",
+			dotQuote(l.Source), dotQuote(l.Target), dotQuote(l.SourceInterface), dotQuote(l.TargetInterface))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// JSON renders the topology in the nodes/links format D3 force layouts use; links
+// refer to nodes by id.
+func (t Topology) JSON() ([]byte, error) {
+	if t.Nodes == nil {
+		t.Nodes = []TopologyNode{}
+	}
+	if t.Links == nil {
+		t.Links = []TopologyLink{}
+	}
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// shortNeighborName drops the domain and the serial number suffix some platforms
+// append in parentheses from a CDP or LLDP device ID, e.g. "core01.example.com" and
+// "nexus01(FOX1234ABCD)" become "core01" and "nexus01".
+func shortNeighborName(deviceID string) string {
+	if i := strings.Index(deviceID, "("); i > 0 {
+		deviceID = deviceID[:i]
+	}
+	if i := strings.Index(deviceID, "."); i > 0 {
+		deviceID = deviceID[:i]
+	}
+	return deviceID
+}
+
+// dotQuote quotes a Graphviz ID, escaping quotes and newlines.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
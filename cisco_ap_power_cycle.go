@@ -0,0 +1,236 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotAccessPoint is returned by Power_cycle_access_point when nothing on the
+	// interface identifies as an access point; the port is left alone.
+	ErrNotAccessPoint = errors.New("no access point found on interface")
+	// ErrAccessPointNotBack is returned by Power_cycle_access_point when the access
+	// point did not draw power and reappear as a neighbor in time.
+	ErrAccessPointNotBack = errors.New("access point did not come back")
+)
+
+// DefaultPowerCycleTimeout is how long Power_cycle_access_point waits for the access
+// point to come back when PowerCycleOptions.Timeout is not set; access points take
+// a few minutes to boot.
+const DefaultPowerCycleTimeout = 5 * time.Minute
+
+// DefaultPowerOffTime is how long Power_cycle_access_point keeps the power off when
+// PowerCycleOptions.OffTime is not set.
+const DefaultPowerOffTime = 5 * time.Second
+
+// powerCyclePollInterval is how often Power_cycle_access_point checks on the access point.
+const powerCyclePollInterval = 10 * time.Second
+
+// AccessPointPort is a switch port with a wireless access point behind it.
+type AccessPointPort struct {
+	Interface string
+	Name      string // Neighbor name from CDP or LLDP; empty when only PoE identified it
+	Platform  string // CDP platform or the PoE device name, e.g., AIR-AP2802I-B-K9
+	Power     string // Watts drawn; empty when the port has no PoE data
+	Source    string // What identified the access point: cdp, lldp or poe
+}
+
+// PowerCycleOptions tune Power_cycle_access_point.
+type PowerCycleOptions struct {
+	OffTime time.Duration // How long the power stays off; DefaultPowerOffTime when zero
+	Timeout time.Duration // How long to wait for the access point; DefaultPowerCycleTimeout when zero
+}
+
+// PowerCycleReport describes what Power_cycle_access_point did.
+type PowerCycleReport struct {
+	Switch         string
+	Interface      string
+	AccessPoint    AccessPointPort
+	Output         string // Switch output of the power off and power on configuration
+	PowerRestored  bool   // The port delivers power again
+	Power          string // Watts drawn after the cycle
+	NeighborIsBack bool   // The access point is a CDP or LLDP neighbor again
+	Duration       time.Duration
+}
+
+// Find_access_point_ports collects CDP and LLDP neighbors and the PoE devices of a
+// switch over one connection and returns the ports with an access point behind them,
+// see Identify_access_point_ports.
+func Find_access_point_ports(switch_hostname string) ([]AccessPointPort, error) {
+	client, err := connectToSwitch(switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrently([]string{"show cdp neighbors", "show lldp neighbors", "show power inline"})
+	if err != nil {
+		return nil, err
+	}
+	// Any one source is enough to identify access points.
+	cdp, _ := ParseCdpNeighbors(outputs[0])
+	lldp, _ := ParseLldpNeighbors(outputs[1])
+	_, power, _ := ParsePowerInline(outputs[2])
+
+	return Identify_access_point_ports(cdp, lldp, power), nil
+}
+
+// Identify_access_point_ports returns the ports whose CDP or LLDP neighbor classifies
+// as an access point, or whose PoE device name is an access point platform. CDP is
+// preferred over LLDP, and LLDP over PoE, for the name and platform.
+func Identify_access_point_ports(cdp []CdpNeighbor, lldp []LldpNeighbor, power []PowerInterfaceInfo) []AccessPointPort {
+	var ports []AccessPointPort
+	index := make(map[string]int)
+	add := func(port AccessPointPort) {
+		key := shortInterfaceName(port.Interface)
+		if _, ok := index[key]; ok {
+			return
+		}
+		index[key] = len(ports)
+		ports = append(ports, port)
+	}
+
+	for _, neighbor := range cdp {
+		if neighbor.Kind == DeviceAccessPoint {
+			add(AccessPointPort{Interface: neighbor.Interface, Name: neighbor.Neighbor, Platform: neighbor.Platform, Source: "cdp"})
+		}
+	}
+	for _, neighbor := range lldp {
+		if neighbor.Kind == DeviceAccessPoint {
+			add(AccessPointPort{Interface: neighbor.Interface, Name: neighbor.Neighbor, Source: "lldp"})
+		}
+	}
+	for _, poe := range power {
+		if hasAnyPrefix(poe.Device, accessPointPlatforms) {
+			add(AccessPointPort{Interface: poe.Interface, Platform: poe.Device, Source: "poe"})
+		}
+	}
+
+	// Fill in what PoE knows about the ports found through neighbors.
+	for _, poe := range power {
+		i, ok := index[shortInterfaceName(poe.Interface)]
+		if !ok || poe.Oper != "on" {
+			continue
+		}
+		ports[i].Power = poe.Power
+		if ports[i].Platform == "" && poe.Device != "n/a" {
+			ports[i].Platform = poe.Device
+		}
+	}
+
+	SortByInterface(ports, func(port AccessPointPort) string { return port.Interface })
+	for i := range ports {
+		ports[i].Interface = normalizeInterfaceName(ports[i].Interface)
+	}
+	return ports
+}
+
+// Power_cycle_access_point reboots the access point on an interface by turning PoE
+// off and on again, then waits until the port draws power and the access point is a
+// CDP or LLDP neighbor again. Interfaces without an access point are refused with
+// ErrNotAccessPoint. The power inline setting of the interface is restored from the
+// running configuration, or set to auto when it has none.
+func Power_cycle_access_point(switch_hostname string, switch_interface string, opts PowerCycleOptions) (PowerCycleReport, error) {
+	report := PowerCycleReport{Switch: switch_hostname, Interface: normalizeInterfaceName(switch_interface)}
+	if err := checkInterfaceParameter(switch_interface); err != nil {
+		return report, err
+	}
+
+	client, err := connectForConfig(switch_hostname)
+	if err != nil {
+		return report, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrently([]string{
+		"show cdp neighbors " + switch_interface,
+		"show lldp neighbors " + switch_interface,
+		"show power inline " + switch_interface,
+		"show running-config interface " + switch_interface,
+	})
+	if err != nil {
+		return report, err
+	}
+	cdp, _ := ParseCdpNeighbors(outputs[0])
+	lldp, _ := ParseLldpNeighbors(outputs[1])
+	_, power, _ := ParsePowerInline(outputs[2])
+	accessPoints := Identify_access_point_ports(cdp, lldp, power)
+	if len(accessPoints) == 0 {
+		return report, fmt.Errorf("%s :: %s :: %w", switch_hostname, switch_interface, ErrNotAccessPoint)
+	}
+	report.AccessPoint = accessPoints[0]
+
+	powerOn := "power inline auto"
+	if configs, err := ParseInterfaceConfig(outputs[3]); err == nil && len(configs) > 0 {
+		for _, line := range configs[0].ConfigLines {
+			if fields := strings.Fields(line); len(fields) > 2 && fields[0] == "power" && fields[1] == "inline" && (fields[2] == "auto" || fields[2] == "static") {
+				powerOn = line
+			}
+		}
+	}
+
+	offTime := opts.OffTime
+	if offTime <= 0 {
+		offTime = DefaultPowerOffTime
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultPowerCycleTimeout
+	}
+
+	start := time.Now()
+	var output strings.Builder
+	offOutput, err := client.configure("power cycle", []string{"interface " + switch_interface, "power inline never"}, client.commandTimeout(10*time.Second))
+	output.WriteString(offOutput)
+	report.Output = output.String()
+	if err != nil {
+		return report, err
+	}
+	time.Sleep(offTime)
+	onOutput, err := client.configure("power cycle", []string{"interface " + switch_interface, powerOn}, client.commandTimeout(10*time.Second))
+	output.WriteString(onOutput)
+	report.Output = output.String()
+	if err != nil {
+		// The access point is left without power; say so loudly.
+		client.logf(slog.LevelError, "%s :: %s :: PoE could not be turned back on: %v", switch_hostname, switch_interface, err)
+		return report, err
+	}
+	client.logf(slog.LevelInfo, "%s :: %s :: Power cycled access point %s", switch_hostname, switch_interface, report.AccessPoint.Name)
+
+	deadline := start.Add(timeout)
+	for {
+		outputs, err := client.RunCommandsConcurrently([]string{
+			"show power inline " + switch_interface,
+			"show cdp neighbors " + switch_interface,
+			"show lldp neighbors " + switch_interface,
+		})
+		if err != nil {
+			return report, err
+		}
+		_, power, _ := ParsePowerInline(outputs[0])
+		cdp, _ := ParseCdpNeighbors(outputs[1])
+		lldp, _ := ParseLldpNeighbors(outputs[2])
+
+		report.PowerRestored, report.Power = false, ""
+		if len(power) > 0 && power[0].Oper == "on" {
+			report.PowerRestored, report.Power = true, power[0].Power
+		}
+		// The link went down with the power, which flushed the old neighbor entries.
+		report.NeighborIsBack = len(cdp) > 0 || len(lldp) > 0
+		report.Duration = time.Since(start)
+
+		if report.PowerRestored && report.NeighborIsBack {
+			client.logf(slog.LevelInfo, "Successfully power cycled access point on interface %s on %s in %s.", switch_interface, switch_hostname, report.Duration.Round(time.Second))
+			return report, nil
+		}
+		if time.Now().Add(powerCyclePollInterval).After(deadline) {
+			break
+		}
+		time.Sleep(powerCyclePollInterval)
+	}
+
+	return report, fmt.Errorf("%s :: %s :: %w after %s (power restored %t, neighbor back %t)", switch_hostname, switch_interface, ErrAccessPointNotBack, timeout, report.PowerRestored, report.NeighborIsBack)
+}
@@ -0,0 +1,183 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// KeyboardInteractiveFunc answers the questions of a keyboard-interactive login,
+// one answer per question.
+type KeyboardInteractiveFunc func(name string, instruction string, questions []string, echos []bool) ([]string, error)
+
+// AuthConfig is how connections log in besides the password. Methods are tried in
+// the order public key, password, keyboard-interactive; the password methods only
+// when there is a password or a KeyboardInteractive function.
+type AuthConfig struct {
+	KeyFiles   []string // Private key files, PEM or OpenSSH format
+	Keys       [][]byte // Private keys in memory, e.g. from a secrets store
+	Passphrase string   // Decrypts protected keys, including IdentityFiles from the SSH config
+
+	// Agent adds the keys of the ssh-agent listening on SSH_AUTH_SOCK.
+	Agent bool
+
+	// KeyboardInteractive answers keyboard-interactive challenges, e.g. for a one-time
+	// code. When nil, every question is answered with the password, which is how IOS
+	// asks for it when keyboard-interactive is enabled.
+	KeyboardInteractive KeyboardInteractiveFunc
+}
+
+var authSettings struct {
+	sync.RWMutex
+	config  *AuthConfig
+	signers []ssh.Signer // Parsed from KeyFiles and Keys
+}
+
+// SetAuth makes every connection log in with config. The keys are read and decrypted
+// once, here; a key that cannot be is an error and nothing changes. Pass nil to go
+// back to password authentication only.
+func SetAuth(config *AuthConfig) error {
+	var signers []ssh.Signer
+	if config != nil {
+		for _, path := range config.KeyFiles {
+			signer, err := loadPrivateKeyFile(path, config.Passphrase)
+			if err != nil {
+				return fmt.Errorf("auth: %s: %w", path, err)
+			}
+			signers = append(signers, signer)
+		}
+		for i, key := range config.Keys {
+			signer, err := parsePrivateKey(key, config.Passphrase)
+			if err != nil {
+				return fmt.Errorf("auth: key %d: %w", i+1, err)
+			}
+			signers = append(signers, signer)
+		}
+	}
+
+	authSettings.Lock()
+	defer authSettings.Unlock()
+	authSettings.config = config
+	authSettings.signers = signers
+	return nil
+}
+
+func activeAuth() (*AuthConfig, []ssh.Signer) {
+	authSettings.RLock()
+	defer authSettings.RUnlock()
+	return authSettings.config, authSettings.signers
+}
+
+// authMethods returns the SSH login methods for target and the function that
+// releases what they hold, the ssh-agent connection, once the handshake is done.
+func authMethods(target sshTarget, password string) ([]ssh.AuthMethod, func()) {
+	config, signers := activeAuth()
+	if config == nil {
+		config = &AuthConfig{}
+	}
+	release := func() {}
+
+	signers = append(loadIdentityFiles(target.identityFiles, config.Passphrase), signers...)
+	if config.Agent {
+		if agentSigners, conn, err := agentKeys(); err != nil {
+			logf(slog.LevelWarn, "Skipping ssh-agent: %v", err)
+		} else {
+			signers = append(signers, agentSigners...)
+			release = func() { conn.Close() }
+		}
+	}
+
+	var auth []ssh.AuthMethod
+	if len(signers) > 0 {
+		auth = append(auth, ssh.PublicKeys(signers...))
+	}
+	if password != "" {
+		auth = append(auth, ssh.Password(password))
+	}
+	switch {
+	case config.KeyboardInteractive != nil:
+		auth = append(auth, ssh.KeyboardInteractive(ssh.KeyboardInteractiveChallenge(config.KeyboardInteractive)))
+	case password != "":
+		auth = append(auth, ssh.KeyboardInteractive(answerWithPassword(password)))
+	}
+	return auth, release
+}
+
+// answerWithPassword answers every keyboard-interactive question with the password.
+func answerWithPassword(password string) ssh.KeyboardInteractiveChallenge {
+	return func(name string, instruction string, questions []string, echos []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i := range questions {
+			answers[i] = password
+		}
+		return answers, nil
+	}
+}
+
+// agentKeys returns the keys of the running ssh-agent and the connection to it, which
+// signing needs until the handshake is done.
+func agentKeys() ([]ssh.Signer, net.Conn, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, errors.New("SSH_AUTH_SOCK is not set")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, err
+	}
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return signers, conn, nil
+}
+
+// loadIdentityFiles reads the private keys it can, decrypting them with passphrase if
+// they need one; keys that are missing or cannot be decrypted are logged and skipped.
+func loadIdentityFiles(paths []string, passphrase string) []ssh.Signer {
+	var signers []ssh.Signer
+	for _, path := range paths {
+		signer, err := loadPrivateKeyFile(path, passphrase)
+		if err != nil {
+			logf(slog.LevelWarn, "Skipping identity file %s: %v", path, err)
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
+func loadPrivateKeyFile(path string, passphrase string) (ssh.Signer, error) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parsePrivateKey(key, passphrase)
+}
+
+// parsePrivateKey parses a PEM or OpenSSH private key, decrypting it with passphrase
+// when it is protected.
+func parsePrivateKey(key []byte, passphrase string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	var missing *ssh.PassphraseMissingError
+	if !errors.As(err, &missing) {
+		return signer, err
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("key is protected by a passphrase and none is set")
+	}
+	return ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+}
@@ -0,0 +1,67 @@
+package cisco_test
+
+import (
+	"testing"
+
+	"github.com/xtokio/cisco"
+)
+
+// benchmarkParse runs parse over the fixture of command and reports throughput in
+// bytes of raw output per second.
+func benchmarkParse[T any](b *testing.B, command string, parse func(rawOutput string) (T, error)) {
+	rawOutput, ok := cisco.Fixtures[command]
+	if !ok {
+		b.Fatalf("no fixture for %q", command)
+	}
+	b.SetBytes(int64(len(rawOutput)))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := parse(rawOutput); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseInterfaces(b *testing.B) {
+	benchmarkParse(b, "show interface", cisco.ParseInterfaces)
+}
+
+func BenchmarkParseInterfaceConfig(b *testing.B) {
+	benchmarkParse(b, "show running-config", cisco.ParseInterfaceConfig)
+}
+
+func BenchmarkParseVersionInfo(b *testing.B) {
+	benchmarkParse(b, "show version", cisco.ParseVersionInfo)
+}
+
+func BenchmarkParseInterfaceStatus(b *testing.B) {
+	benchmarkParse(b, "show interface status", cisco.ParseInterfaceStatus)
+}
+
+func BenchmarkParseMacAddressTable(b *testing.B) {
+	benchmarkParse(b, "show mac address-table", cisco.ParseMacAddressTable)
+}
+
+func BenchmarkParseVlanInfo(b *testing.B) {
+	benchmarkParse(b, "show vlan", cisco.ParseVlanInfo)
+}
+
+// BenchmarkParsers runs every parser with a fixture, one sub-benchmark per command,
+// e.g. go test -bench 'Parsers/show_lldp'.
+func BenchmarkParsers(b *testing.B) {
+	for _, command := range cisco.ParsableCommands() {
+		rawOutput, ok := cisco.Fixtures[command]
+		if !ok {
+			continue
+		}
+		b.Run(command, func(b *testing.B) {
+			b.SetBytes(int64(len(rawOutput)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, stats := cisco.SafeParse(command, rawOutput); stats.Err != nil {
+					b.Fatal(stats.Err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette records real sessions or replays them.
+type CassetteMode int
+
+const (
+	// CassetteRecord runs commands on the real switch and saves every output.
+	CassetteRecord CassetteMode = iota + 1
+	// CassetteReplay serves saved outputs instead of connecting to the switch.
+	CassetteReplay
+)
+
+// ErrCassetteMiss is returned in replay mode when no recording exists for a command.
+var ErrCassetteMiss = errors.New("no recording in cassette")
+
+// Cassette stores command outputs as plain text files, one per switch and command
+// list, e.g. <Dir>/switch01/show_interface_status.txt. The files hold the raw shell
+// transcript exactly as the switch printed it, so they can be reviewed, committed and
+// edited by hand.
+type Cassette struct {
+	Dir string
+}
+
+// NewCassette returns a Cassette storing its recordings under dir.
+func NewCassette(dir string) *Cassette {
+	return &Cassette{Dir: dir}
+}
+
+var (
+	cassetteMu   sync.RWMutex
+	cassette     *Cassette
+	cassetteMode CassetteMode
+)
+
+// SetCassette routes every session of the package — the package-level functions as
+// well as Clients — through c. In CassetteRecord mode sessions run normally and their
+// output is saved; in CassetteReplay mode no connection is made and the saved output
+// is returned instead. Passing nil restores normal operation.
+func SetCassette(c *Cassette, mode CassetteMode) {
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	cassette = c
+	cassetteMode = mode
+	if c == nil {
+		cassetteMode = 0
+	}
+}
+
+// activeCassette returns the installed cassette, if it is in the given mode.
+func activeCassette(mode CassetteMode) *Cassette {
+	cassetteMu.RLock()
+	defer cassetteMu.RUnlock()
+	if cassetteMode != mode {
+		return nil
+	}
+	return cassette
+}
+
+// Record saves the output of a command list run on a switch.
+func (c *Cassette) Record(switch_hostname string, switch_commands []string, output string) error {
+	path := c.path(switch_hostname, switch_commands)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(output), 0o644); err != nil {
+		return fmt.Errorf("failed to record %s: %w", path, err)
+	}
+	return nil
+}
+
+// Replay returns the recorded output of a command list run on a switch.
+func (c *Cassette) Replay(switch_hostname string, switch_commands []string) (string, error) {
+	path := c.path(switch_hostname, switch_commands)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%s :: %s :: %w (%s)", switch_hostname, strings.Join(switch_commands, "; "), ErrCassetteMiss, path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to replay %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// reCassetteUnsafe matches runs of characters not kept in recording file names.
+var reCassetteUnsafe = regexp.MustCompile(`[^A-Za-z0-9.\-]+`)
+
+func (c *Cassette) path(switch_hostname string, switch_commands []string) string {
+	name := reCassetteUnsafe.ReplaceAllString(strings.Join(switch_commands, "__"), "_")
+	name = strings.Trim(name, "_")
+	host := reCassetteUnsafe.ReplaceAllString(switch_hostname, "_")
+	return filepath.Join(c.Dir, host, name+".txt")
+}
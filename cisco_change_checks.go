@@ -0,0 +1,193 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrPreCheckFailed is returned when a pre-check fails and the change was not applied.
+	ErrPreCheckFailed = errors.New("pre-check failed")
+	// ErrPostCheckFailed is returned when a post-check fails after the change was applied.
+	ErrPostCheckFailed = errors.New("post-check failed")
+)
+
+// ChangeCheck is a verification run before and after a change. Capture reads the
+// state the check looks at; an error fails the check. Verify compares the state after
+// the change with the state before it; when nil the state must be unchanged.
+type ChangeCheck struct {
+	Name    string
+	Capture func(switch_hostname string) (any, error)
+	Verify  func(before any, after any) error
+}
+
+// CheckResult is the outcome of one check in one phase.
+type CheckResult struct {
+	Name   string
+	Passed bool
+	Err    error
+}
+
+// CheckedChange is a configuration change wrapped in pre-checks and post-checks.
+// Rollback holds the configuration commands that undo it; when empty, failed
+// post-checks only flag the change.
+type CheckedChange struct {
+	Switch      string
+	Description string
+	Commands    []string
+	Rollback    []string
+	Checks      []ChangeCheck
+}
+
+// CheckedChangeReport describes what happened to a CheckedChange.
+type CheckedChangeReport struct {
+	Switch         string
+	PreChecks      []CheckResult
+	PostChecks     []CheckResult
+	Applied        bool
+	Output         string
+	RolledBack     bool
+	RollbackOutput string
+}
+
+// Failed returns the names of the checks that did not pass.
+func (r CheckedChangeReport) Failed() []string {
+	var failed []string
+	for _, result := range append(append([]CheckResult(nil), r.PreChecks...), r.PostChecks...) {
+		if !result.Passed {
+			failed = append(failed, result.Name)
+		}
+	}
+	return failed
+}
+
+// Apply_checked_change captures every check, applies the change only if all of them
+// passed, then captures and verifies them again. If a post-check fails the Rollback
+// commands are sent, and ErrPostCheckFailed is returned either way.
+func Apply_checked_change(change CheckedChange) (CheckedChangeReport, error) {
+	report := CheckedChangeReport{Switch: change.Switch}
+
+	before := make([]any, len(change.Checks))
+	preFailed := false
+	for i, check := range change.Checks {
+		state, err := check.Capture(change.Switch)
+		before[i] = state
+		report.PreChecks = append(report.PreChecks, CheckResult{Name: check.Name, Passed: err == nil, Err: err})
+		if err != nil {
+			preFailed = true
+		}
+	}
+	if preFailed {
+		return report, fmt.Errorf("%s: %w: %v", change.Switch, ErrPreCheckFailed, report.Failed())
+	}
+
+	output, err := pushConfig(change.Switch, change.Commands)
+	report.Output = output
+	if err != nil {
+		return report, err
+	}
+	report.Applied = true
+
+	postFailed := false
+	for i, check := range change.Checks {
+		after, err := check.Capture(change.Switch)
+		if err == nil {
+			if check.Verify != nil {
+				err = check.Verify(before[i], after)
+			} else if !reflect.DeepEqual(before[i], after) {
+				err = fmt.Errorf("state changed from %v to %v", before[i], after)
+			}
+		}
+		report.PostChecks = append(report.PostChecks, CheckResult{Name: check.Name, Passed: err == nil, Err: err})
+		if err != nil {
+			postFailed = true
+		}
+	}
+	if !postFailed {
+		return report, nil
+	}
+
+	if len(change.Rollback) > 0 {
+		report.RollbackOutput, err = pushConfig(change.Switch, change.Rollback)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w: %v; rollback failed: %v", change.Switch, ErrPostCheckFailed, report.Failed(), err)
+		}
+		report.RolledBack = true
+	}
+
+	return report, fmt.Errorf("%s: %w: %v", change.Switch, ErrPostCheckFailed, report.Failed())
+}
+
+// CdpNeighborCheck passes while neighbor is seen by CDP on switch_interface, before
+// and after the change.
+func CdpNeighborCheck(switch_interface string, neighbor string) ChangeCheck {
+	return ChangeCheck{
+		Name: fmt.Sprintf("CDP neighbor %s on %s", neighbor, switch_interface),
+		Capture: func(switch_hostname string) (any, error) {
+			neighbors, err := Show_cdp_neighbors(switch_hostname)
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range neighbors {
+				if shortInterfaceName(n.Interface) == shortInterfaceName(switch_interface) && n.Neighbor == neighbor {
+					return true, nil
+				}
+			}
+			return nil, fmt.Errorf("%s not seen on %s", neighbor, switch_interface)
+		},
+	}
+}
+
+// ConnectedPortsCheck passes when no fewer ports are connected after the change than before.
+func ConnectedPortsCheck() ChangeCheck {
+	return ChangeCheck{
+		Name: "connected port count",
+		Capture: func(switch_hostname string) (any, error) {
+			statuses, err := Show_interfaces_status(switch_hostname)
+			if err != nil {
+				return nil, err
+			}
+			connected := 0
+			for _, status := range statuses {
+				if status.Status == "connected" {
+					connected++
+				}
+			}
+			return connected, nil
+		},
+		Verify: func(before any, after any) error {
+			if after.(int) < before.(int) {
+				return fmt.Errorf("connected ports dropped from %d to %d", before, after)
+			}
+			return nil
+		},
+	}
+}
+
+// SpanningTreeRootCheck passes when the root bridge of every VLAN is unchanged.
+func SpanningTreeRootCheck() ChangeCheck {
+	return ChangeCheck{
+		Name: "spanning-tree root unchanged",
+		Capture: func(switch_hostname string) (any, error) {
+			roots, err := Show_spanning_tree_root(switch_hostname)
+			if err != nil {
+				return nil, err
+			}
+			rootByVlan := make(map[string]string)
+			for _, root := range roots {
+				rootByVlan[root.Vlan] = root.RootPriority + " " + root.RootMac
+			}
+			return rootByVlan, nil
+		},
+		Verify: func(before any, after any) error {
+			was, now := before.(map[string]string), after.(map[string]string)
+			for vlan, root := range was {
+				if now[vlan] != root {
+					return fmt.Errorf("root of %s changed from %q to %q", vlan, root, now[vlan])
+				}
+			}
+			return nil
+		},
+	}
+}
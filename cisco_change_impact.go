@@ -0,0 +1,158 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrChangeNotConfirmed is returned when an impact preview was declined and the change was not applied.
+var ErrChangeNotConfirmed = errors.New("change not confirmed")
+
+// AuthSession is an 802.1X/MAB session authenticated on an interface.
+type AuthSession struct {
+	MacAddress string
+	Method     string // e.g., dot1x, mab
+	Domain     string // e.g., DATA, VOICE
+	Status     string // e.g., Auth, Unauth
+	Vendor     string // From the OUI database set with SetOUIDatabase
+}
+
+// InterfaceImpact describes what is connected behind an interface, so operators can see
+// what a shutdown or VLAN change would disconnect.
+type InterfaceImpact struct {
+	Switch        string
+	Interface     string
+	MacEntries    []MacAddressEntry
+	CdpNeighbors  []CdpNeighbor
+	LldpNeighbors []LldpNeighbor
+	PoeDevice     *PowerInterfaceInfo // nil if the port is not powering a device
+	AuthSessions  []AuthSession
+	Warnings      []string
+}
+
+// Risky reports whether the interface looks like an uplink or neighbor switch link.
+func (i InterfaceImpact) Risky() bool {
+	return len(i.Warnings) > 0
+}
+
+// uplinkMacThreshold is the number of MAC addresses above which a port is assumed to
+// lead to another switch rather than to a single host.
+const uplinkMacThreshold = 10
+
+// reAuthSession matches a "show access-session interface" data row:
+// Gi1/0/2   0011.2233.4455 dot1x  DATA   Auth   000000000000000B1C2D3E4F
+var reAuthSession = regexp.MustCompile(`^(\S+)\s+([0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4})\s+(\S+)\s+(\S+)\s+(\S+)`)
+
+// Preview_interface_impact gathers what is behind an interface — MAC entries, CDP/LLDP
+// neighbors, the PoE device and authentication sessions — without changing anything.
+// Call it before Interface_shutdown or a VLAN change and check Risky before going ahead.
+func Preview_interface_impact(switch_hostname string, switch_interface string) (InterfaceImpact, error) {
+	return Preview_interface_impact_context(context.Background(), switch_hostname, switch_interface)
+}
+
+// Preview_interface_impact_context is Preview_interface_impact with a context that cancels the commands.
+func Preview_interface_impact_context(ctx context.Context, switch_hostname string, switch_interface string) (InterfaceImpact, error) {
+	impact := InterfaceImpact{Switch: switch_hostname, Interface: normalizeInterfaceName(switch_interface)}
+	if err := checkInterfaceParameter(switch_interface); err != nil {
+		return impact, err
+	}
+
+	client, err := ConnectContext(ctx, switch_hostname)
+	if err != nil {
+		return impact, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrentlyContext(ctx, []string{
+		"show mac address-table interface " + switch_interface,
+		"show cdp neighbors " + switch_interface,
+		"show lldp neighbors " + switch_interface,
+		"show power inline " + switch_interface,
+		"show access-session interface " + switch_interface,
+	})
+	// Commands a platform doesn't support, e.g. "show power inline" on a switch without
+	// PoE, are rejected with a CommandError and print an error the parsers skip, so a
+	// missing section only leaves that part of the impact empty.
+	if err != nil && !onlyCommandErrors(err) {
+		return impact, err
+	}
+
+	impact.MacEntries, _ = ParseMacAddressTable(outputs[0])
+	impact.CdpNeighbors, _ = ParseCdpNeighbors(outputs[1])
+	impact.LldpNeighbors, _ = ParseLldpNeighbors(outputs[2])
+	if _, powerInterfaces, _ := ParsePowerInline(outputs[3]); len(powerInterfaces) > 0 && powerInterfaces[0].Oper == "on" {
+		impact.PoeDevice = &powerInterfaces[0]
+	}
+	impact.AuthSessions = ParseAuthSessions(outputs[4])
+
+	impact.Warnings = impactWarnings(impact)
+	return impact, nil
+}
+
+// Interface_shutdown_confirmed previews the impact of shutting down an interface and
+// only applies the shutdown if confirm returns true. The impact is returned either way;
+// ErrChangeNotConfirmed is returned if confirm declined.
+func Interface_shutdown_confirmed(switch_hostname string, switch_interface string, confirm func(InterfaceImpact) bool) (string, InterfaceImpact, error) {
+	return Interface_shutdown_confirmed_context(context.Background(), switch_hostname, switch_interface, confirm)
+}
+
+// Interface_shutdown_confirmed_context is Interface_shutdown_confirmed with a context
+// that cancels the preview and the change.
+func Interface_shutdown_confirmed_context(ctx context.Context, switch_hostname string, switch_interface string, confirm func(InterfaceImpact) bool) (string, InterfaceImpact, error) {
+	impact, err := Preview_interface_impact_context(ctx, switch_hostname, switch_interface)
+	if err != nil {
+		return "", impact, err
+	}
+	if !confirm(impact) {
+		return "", impact, fmt.Errorf("shutdown of %s on %s: %w", switch_interface, switch_hostname, ErrChangeNotConfirmed)
+	}
+
+	outputString, err := Interface_shutdown_context(ctx, switch_hostname, switch_interface)
+	return outputString, impact, err
+}
+
+func impactWarnings(impact InterfaceImpact) []string {
+	var warnings []string
+
+	for _, neighbor := range impact.CdpNeighbors {
+		if strings.ContainsAny(neighbor.Capability, "SR") {
+			warnings = append(warnings, fmt.Sprintf("CDP neighbor %s (%s) is a switch or router", neighbor.Neighbor, neighbor.Platform))
+		}
+	}
+	for _, neighbor := range impact.LldpNeighbors {
+		if strings.Contains(neighbor.Capability, "R") {
+			warnings = append(warnings, fmt.Sprintf("LLDP neighbor %s is a router", neighbor.Neighbor))
+		}
+	}
+	if len(impact.MacEntries) > uplinkMacThreshold {
+		warnings = append(warnings, fmt.Sprintf("%d MAC addresses learned, port likely leads to another switch", len(impact.MacEntries)))
+	}
+	if strings.HasPrefix(shortInterfaceName(impact.Interface), "Po") {
+		warnings = append(warnings, "interface is a port-channel")
+	}
+
+	return warnings
+}
+
+// ParseAuthSessions extracts the sessions from "show access-session interface" output.
+func ParseAuthSessions(rawOutput string) []AuthSession {
+	var sessions []AuthSession
+	vendors := activeOUIDatabase()
+	for _, line := range strings.Split(rawOutput, "\n") {
+		match := reAuthSession.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		sessions = append(sessions, AuthSession{
+			MacAddress: strings.ToLower(match[2]),
+			Method:     match[3],
+			Domain:     match[4],
+			Status:     match[5],
+			Vendor:     vendors.Vendor(match[2]),
+		})
+	}
+	return sessions
+}
@@ -0,0 +1,83 @@
+package cisco_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xtokio/cisco"
+)
+
+// uplinkResponses make Gi1/0/1 of the simulator lead to core01, a switch.
+var uplinkResponses = map[string]string{
+	"show mac address-table interface Gi1/0/1": `          Mac Address Table
+-------------------------------------------
+
+Vlan    Mac Address       Type        Ports
+----    -----------       --------    -----
+  10    0011.2233.4401    DYNAMIC     Gi1/0/1
+  20    3c52.82aa.bb03    DYNAMIC     Gi1/0/1
+Total Mac Addresses for this criterion: 2
+`,
+	"show cdp neighbors Gi1/0/1": `Capability Codes: R - Router, T - Trans Bridge, B - Source Route Bridge
+                  S - Switch, H - Host, I - IGMP, r - Repeater, P - Phone,
+                  D - Remote, C - CVTA, M - Two-port Mac Relay
+
+Device ID        Local Intrfce     Holdtme    Capability  Platform  Port ID
+core01.example.com
+                 Gig 1/0/1         163             R S I  WS-C6509- Gig 3/12
+
+Total cdp entries displayed : 1
+`,
+}
+
+func TestPreviewInterfaceImpact(t *testing.T) {
+	server, host := newSimulator(t, "2960x")
+	for command, output := range uplinkResponses {
+		server.SetResponse(command, output)
+	}
+
+	// The switch rejects the LLDP, PoE and access-session commands; the impact is
+	// made of what it did answer.
+	impact, err := cisco.Preview_interface_impact(host, "Gi1/0/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(impact.MacEntries) != 2 {
+		t.Errorf("MacEntries = %v, want 2", impact.MacEntries)
+	}
+	if len(impact.CdpNeighbors) != 1 || !strings.HasPrefix(impact.CdpNeighbors[0].Neighbor, "core01") {
+		t.Errorf("CdpNeighbors = %v, want core01", impact.CdpNeighbors)
+	}
+	if impact.PoeDevice != nil || len(impact.LldpNeighbors) != 0 || len(impact.AuthSessions) != 0 {
+		t.Errorf("impact = %+v, want nothing from the rejected commands", impact)
+	}
+	if !impact.Risky() {
+		t.Error("a port leading to a switch is not Risky")
+	}
+}
+
+func TestPreviewInterfaceImpactTimeout(t *testing.T) {
+	server, host := newSimulator(t, "2960x")
+	for command, output := range uplinkResponses {
+		server.SetResponse(command, output)
+	}
+	server.SetDelay("show access-session interface Gi1/0/1", time.Second)
+
+	// The rejected commands must not hide the one that timed out.
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err := cisco.Preview_interface_impact_context(ctx, host, "Gi1/0/1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the deadline", err)
+	}
+}
+
+func TestPreviewInterfaceImpactRejectsBadInterface(t *testing.T) {
+	_, host := newSimulator(t, "2960x")
+	if _, err := cisco.Preview_interface_impact(host, "Gi1/0/1; reload"); err == nil {
+		t.Fatal("err = nil, want the interface rejected")
+	}
+}
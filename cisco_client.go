@@ -0,0 +1,121 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxSessions is the number of concurrent sessions a Client opens over a
+// single SSH connection when MaxSessions is not set. IOS allows a handful of
+// channels per connection; staying low avoids exhausting VTY resources.
+const DefaultMaxSessions = 4
+
+// Connect dials the switch using the CISCO_USERNAME and CISCO_PASSWORD environment
+// variables and returns a Client whose connection can be reused for several
+// commands. The caller must Close the Client when done.
+func Connect(switch_hostname string) (*Client, error) {
+	return connectToSwitch(switch_hostname)
+}
+
+// ConnectContext is like Connect but gives up dialing when ctx is done.
+func ConnectContext(ctx context.Context, switch_hostname string) (*Client, error) {
+	return connectToSwitchContext(ctx, switch_hostname)
+}
+
+// ConnectWithCredentials is like Connect but uses the given credentials.
+func ConnectWithCredentials(switch_hostname string, username string, password string) (*Client, error) {
+	return connectToSwitchWithCredentials(context.Background(), switch_hostname, username, password)
+}
+
+// ConnectWithCredentialsContext is like ConnectWithCredentials but gives up dialing
+// when ctx is done.
+func ConnectWithCredentialsContext(ctx context.Context, switch_hostname string, username string, password string) (*Client, error) {
+	return connectToSwitchWithCredentials(ctx, switch_hostname, username, password)
+}
+
+// RunCommand runs a single command in a new session over the client's existing
+// connection, without a new TCP/SSH handshake.
+func (c *Client) RunCommand(switch_command string) (string, error) {
+	return c.RunCommandContext(context.Background(), switch_command)
+}
+
+// RunCommandContext is like RunCommand but stops waiting for a session slot or for
+// the output, and ends the session, when ctx is done.
+func (c *Client) RunCommandContext(ctx context.Context, switch_command string) (string, error) {
+	return c.RunCommandWithOptionsContext(ctx, switch_command, CommandOptions{})
+}
+
+// RunCommands runs the commands in order in a single session over the client's
+// existing connection and returns the combined output.
+func (c *Client) RunCommands(switch_commands []string) (string, error) {
+	return c.RunCommandsContext(context.Background(), switch_commands)
+}
+
+// RunCommandsContext is like RunCommands but ends the session when ctx is done.
+func (c *Client) RunCommandsContext(ctx context.Context, switch_commands []string) (string, error) {
+	return c.RunCommandsWithOptionsContext(ctx, switch_commands, CommandOptions{})
+}
+
+// RunCommandsConcurrently runs every command in its own session over the client's
+// existing connection, with at most MaxSessions sessions in flight. Outputs are
+// returned in the same order as the commands. If any command fails, the errors of
+// all failing commands are joined and returned alongside the outputs that did
+// succeed; commands the switch rejected fail with their CommandError.
+func (c *Client) RunCommandsConcurrently(switch_commands []string) ([]string, error) {
+	return c.RunCommandsConcurrentlyContext(context.Background(), switch_commands)
+}
+
+// RunCommandsConcurrentlyContext is like RunCommandsConcurrently but ends every
+// session still running, and skips those not started yet, when ctx is done.
+func (c *Client) RunCommandsConcurrentlyContext(ctx context.Context, switch_commands []string) ([]string, error) {
+	outputs := make([]string, len(switch_commands))
+	errs := make([]error, len(switch_commands))
+
+	var wg sync.WaitGroup
+	for i, cmd := range switch_commands {
+		wg.Add(1)
+		go func(i int, cmd string) {
+			defer wg.Done()
+			outputs[i], errs[i] = c.RunCommandContext(ctx, cmd)
+		}(i, cmd)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		// A CommandError already names the switch and the command.
+		var cmdErr *CommandError
+		if err != nil && !errors.As(err, &cmdErr) {
+			errs[i] = fmt.Errorf("%s :: %s :: %w", c.SwitchHostname, switch_commands[i], err)
+		}
+	}
+
+	return outputs, errors.Join(errs...)
+}
+
+// acquireSession blocks until a session slot is free and returns the function
+// that releases it.
+func (c *Client) acquireSession() func() {
+	release, _ := c.acquireSessionContext(context.Background())
+	return release
+}
+
+// acquireSessionContext is acquireSession giving up with the error of ctx when ctx
+// is done before a slot is free.
+func (c *Client) acquireSessionContext(ctx context.Context) (func(), error) {
+	c.sessionsOnce.Do(func() {
+		max := c.MaxSessions
+		if max <= 0 {
+			max = DefaultMaxSessions
+		}
+		c.sessions = make(chan struct{}, max)
+	})
+
+	select {
+	case c.sessions <- struct{}{}:
+		return func() { <-c.sessions }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s :: waiting for a session :: %w", c.SwitchHostname, ctx.Err())
+	}
+}
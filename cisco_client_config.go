@@ -0,0 +1,240 @@
+package cisco
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// DefaultDialTimeout is how long opening the TCP connection to a switch may take
+// when neither Config.DialTimeout nor SetTimeouts sets it.
+const DefaultDialTimeout = 1 * time.Second
+
+// DefaultCiphers are the SSH ciphers offered when Config.Ciphers is not set: the
+// modern ones plus the CBC cipher older IOS releases are limited to.
+var DefaultCiphers = []string{
+	// Modern ciphers (the defaults your other 9 switches use)
+	"aes128-gcm@openssh.com",
+	"aes256-gcm@openssh.com",
+	"chacha20-poly1305@openssh.com",
+	"aes128-ctr",
+	"aes192-ctr",
+	"aes256-ctr",
+
+	// Add a legacy cipher that the old switch supports
+	// (from the "peer offered" list in your error)
+	"aes128-cbc",
+}
+
+// DefaultKexAlgorithms are the SSH key exchanges offered when Config.KexAlgorithms is
+// not set, including the SHA-1 groups older IOS releases are limited to.
+var DefaultKexAlgorithms = []string{
+	// Modern Kex (defaults from your error's "we offered" list)
+	"curve25519-sha256",
+	"ecdh-sha2-nistp256",
+	"ecdh-sha2-nistp384",
+	"ecdh-sha2-nistp521",
+	"diffie-hellman-group14-sha256",
+	// Legacy Kex (the one your switch needs)
+	"diffie-hellman-group1-sha1",
+	"diffie-hellman-group14-sha1",
+	"diffie-hellman-group1-sha256",
+	"diffie-hellman-group14-sha256",
+	"diffie-hellman-group1-sha512",
+	"diffie-hellman-group14-sha512",
+}
+
+// Config is everything needed to connect to a switch. Only Host is required.
+type Config struct {
+	// Host is the switch hostname or address. It may carry a port, e.g.
+	// "switch01:2222" or "[2001:db8::1]:2222", and is looked up in the SSH config
+	// set with SetSSHConfig.
+	Host string
+
+	// Port overrides the port of Host and of the SSH config. Zero means
+	// DefaultSSHPort unless one of those sets it.
+	Port int
+
+	Username string
+	Password string
+
+	// Auth, when set, are the authentication methods offered instead of Password and
+	// the keys of SetAuth and the SSH config, e.g. ssh.PublicKeys(signer) with a key
+	// from a secrets store. Jump hosts without a Password of their own use them too.
+	Auth []ssh.AuthMethod
+
+	// EnablePassword answers the enable prompt on platforms that need it. Empty
+	// means Password.
+	EnablePassword string
+
+	// AutoEnable makes the configuration helpers run Client.Enable when the login
+	// lands in user EXEC, instead of failing with ErrInsufficientPrivilege.
+	AutoEnable bool
+
+	// DialTimeout caps opening the TCP connection. Zero means the one set with
+	// SetTimeouts, or else DefaultDialTimeout.
+	DialTimeout time.Duration
+
+	// CommandTimeout becomes the client's Client.CommandTimeout.
+	CommandTimeout time.Duration
+
+	// Ciphers and KexAlgorithms are offered in the SSH handshake, in order of
+	// preference. Empty means DefaultCiphers and DefaultKexAlgorithms.
+	Ciphers       []string
+	KexAlgorithms []string
+
+	// HostKeyCallback verifies the host key of the switch, e.g. one from
+	// golang.org/x/crypto/ssh/knownhosts. Nil accepts any host key.
+	HostKeyCallback ssh.HostKeyCallback
+
+	// Platform selects the CLI dialect. Empty means the one set with SetPlatform,
+	// or IOS.
+	Platform Platform
+
+	// MaxSessions caps the concurrent sessions of the client, see Client.MaxSessions.
+	MaxSessions int
+
+	// KeepAlive sends an SSH keepalive that often, so long-lived clients are not
+	// dropped while idle and a dead connection shows in Client.Healthy. Zero sends
+	// none.
+	KeepAlive time.Duration
+
+	// Logger becomes the client's Client.Logger, and logs the connection attempts.
+	Logger *slog.Logger
+
+	// Retry retries connecting after transient failures. Nil means the policy set
+	// with SetRetry, if any.
+	Retry *RetryPolicy
+
+	// JumpHosts are connected through in order to reach the switch, e.g. a bastion in
+	// front of the management network. They replace the ProxyJump of the SSH config.
+	JumpHosts []JumpHost
+
+	// JumpClient is an established connection, e.g. to a bastion shared by many
+	// clients, that the first jump host, or the switch, is dialed through. It is left
+	// open when the client is closed.
+	JumpClient *ssh.Client
+}
+
+// JumpHost is a host the connection to a switch is tunneled through.
+type JumpHost struct {
+	// Host is the hostname or address, optionally with a port, looked up in the SSH
+	// config like switch hostnames.
+	Host string
+
+	// Username and Password log in to the jump host. Empty means those the switch
+	// is logged in to with.
+	Username string
+	Password string
+}
+
+// EnvConfig returns the Config for a switch with the credentials from the
+// CISCO_USERNAME, CISCO_PASSWORD and CISCO_ENABLE_PASSWORD environment variables,
+// which is what the functions taking only a hostname connect with. Setting
+// CISCO_ENABLE_PASSWORD also turns on AutoEnable.
+func EnvConfig(switch_hostname string) Config {
+	enablePassword := os.Getenv("CISCO_ENABLE_PASSWORD")
+	return Config{
+		Host:           switch_hostname,
+		Username:       os.Getenv("CISCO_USERNAME"),
+		Password:       os.Getenv("CISCO_PASSWORD"),
+		EnablePassword: enablePassword,
+		AutoEnable:     enablePassword != "",
+	}
+}
+
+// NewClient connects to the switch described by config and returns a Client whose
+// connection can be reused for several commands. The caller must Close the Client
+// when done.
+func NewClient(config Config) (*Client, error) {
+	return NewClientContext(context.Background(), config)
+}
+
+// NewClientContext is like NewClient but gives up dialing when ctx is done.
+func NewClientContext(ctx context.Context, config Config) (*Client, error) {
+	platform := config.Platform
+	if platform == "" {
+		platform = platformOf(config.Host)
+	}
+	enablePassword := config.EnablePassword
+	if enablePassword == "" {
+		enablePassword = config.Password
+	}
+
+	// Replayed sessions never touch the network.
+	if activeCassette(CassetteReplay) != nil {
+		return &Client{SwitchHostname: config.Host, Platform: platform, MaxSessions: config.MaxSessions, CommandTimeout: config.CommandTimeout, Logger: config.Logger, autoEnable: config.AutoEnable}, nil
+	}
+
+	target := resolveTarget(config.Host, config.Username)
+	if config.Port != 0 {
+		host, _, _ := net.SplitHostPort(target.address)
+		target.address = net.JoinHostPort(host, strconv.Itoa(config.Port))
+	}
+	sshClient, jumps, err := dialWithRetry(ctx, target, config)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Client:         sshClient,
+		SwitchHostname: config.Host,
+		MaxSessions:    config.MaxSessions,
+		CommandTimeout: config.CommandTimeout,
+		Logger:         config.Logger,
+		Platform:       platform,
+		enablePassword: enablePassword,
+		autoEnable:     config.AutoEnable,
+		jumps:          jumps,
+	}
+	if config.KeepAlive > 0 {
+		client.keepAlive(config.KeepAlive)
+	}
+	return client, nil
+}
+
+// sshClientConfig returns the SSH client settings for target, logging in with
+// Config.Auth or else the methods of authMethods, and the function to call once the
+// handshake is done.
+func sshClientConfig(target sshTarget, config Config) (*ssh.ClientConfig, func()) {
+	auth, release := config.Auth, func() {}
+	if len(auth) == 0 {
+		auth, release = authMethods(target, config.Password)
+	}
+
+	timeout := config.DialTimeout
+	if timeout <= 0 {
+		timeout = activeTimeouts().Dial
+	}
+	if timeout <= 0 {
+		timeout = DefaultDialTimeout
+	}
+	ciphers := config.Ciphers
+	if len(ciphers) == 0 {
+		ciphers = DefaultCiphers
+	}
+	kexAlgorithms := config.KexAlgorithms
+	if len(kexAlgorithms) == 0 {
+		kexAlgorithms = DefaultKexAlgorithms
+	}
+	hostKeyCallback := config.HostKeyCallback
+	if hostKeyCallback == nil {
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+
+	return &ssh.ClientConfig{
+		User:            target.user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+		Config: ssh.Config{
+			Ciphers:      ciphers,
+			KeyExchanges: kexAlgorithms,
+		},
+	}, release
+}
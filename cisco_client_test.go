@@ -0,0 +1,61 @@
+package cisco_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xtokio/cisco"
+)
+
+func TestRunCommandsDetailed(t *testing.T) {
+	_, host := newSimulator(t, "2960x")
+
+	results, err := cisco.RunCommandsDetailed(host, []string{"show version", "show bogus", "show vlan"})
+	var cmdErr *cisco.CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Command != "show bogus" {
+		t.Fatalf("err = %v, want the CommandError of show bogus", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	if !strings.Contains(results[0].Output, "WS-C2960X") || results[0].Error != nil {
+		t.Errorf("show version = %q, %v", results[0].Output, results[0].Error)
+	}
+	if !errors.As(results[1].Error, &cmdErr) {
+		t.Errorf("show bogus error = %v, want a CommandError", results[1].Error)
+	}
+	if !strings.Contains(results[2].Output, "VLAN") || results[2].Error != nil {
+		t.Errorf("show vlan = %q, %v", results[2].Output, results[2].Error)
+	}
+	for _, result := range results {
+		if strings.Contains(result.Output, "switch01#") {
+			t.Errorf("output of %s contains the prompt: %q", result.Command, result.Output)
+		}
+	}
+}
+
+func TestRunCommandsConcurrently(t *testing.T) {
+	server, _ := newSimulator(t, "2960x")
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrently([]string{"show version", "show bogus", "show other"})
+	if len(outputs) != 3 || !strings.Contains(outputs[0], "WS-C2960X") {
+		t.Fatalf("outputs = %q", outputs)
+	}
+
+	// Both rejected commands are reported, each named once.
+	var cmdErr *cisco.CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("err = %v, want CommandErrors", err)
+	}
+	for _, command := range []string{"show bogus", "show other"} {
+		if count := strings.Count(err.Error(), command); count != 1 {
+			t.Errorf("%q named %d times in %q", command, count, err)
+		}
+	}
+}
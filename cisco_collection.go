@@ -0,0 +1,48 @@
+package cisco
+
+import "time"
+
+// ParserVersion identifies the parser behaviour of this library. It changes whenever a
+// parser's output for the same input changes, so stored collections can tell whether
+// they are comparable.
+const ParserVersion = "1"
+
+// Collection wraps parsed output with where, when and how it was collected.
+type Collection[T any] struct {
+	Switch        string
+	SerialNumber  string // From "show version"; empty if it could not be read
+	Command       string
+	ParserVersion string
+	CollectedAt   time.Time
+	Duration      time.Duration // From connecting until the output was parsed
+	Data          T
+}
+
+// Collect runs command on a switch, parses the output with parse and returns it in a
+// Collection. "show version" runs alongside it on the same connection to record the
+// serial number, e.g. Collect(host, "show vlan", ParseVlanInfo).
+func Collect[T any](switch_hostname string, command string, parse func(rawOutput string) (T, error)) (Collection[T], error) {
+	collection := Collection[T]{Switch: switch_hostname, Command: command, ParserVersion: ParserVersion, CollectedAt: time.Now()}
+
+	client, err := connectToSwitch(switch_hostname)
+	if err != nil {
+		return collection, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrently([]string{command, "show version"})
+	if err != nil {
+		return collection, err
+	}
+
+	if version, err := ParseVersionStruct(outputs[1]); err == nil {
+		collection.SerialNumber = version.SerialNumber
+	}
+
+	parseStart := time.Now()
+	collection.Data, err = parse(outputs[0])
+	err = reportParse(switch_hostname, command, outputs[0], recordCount(collection.Data), parseStart, err)
+	collection.Duration = time.Since(collection.CollectedAt)
+
+	return collection, err
+}
@@ -0,0 +1,139 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultCommandErrorPatterns match the lines IOS, IOS-XE, IOS-XR, NX-OS and ASA print
+// instead of the output of a command they rejected.
+var DefaultCommandErrorPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`%\s*Invalid input detected`),      // IOS, IOS-XE, IOS-XR, ASA ("ERROR: % Invalid input detected")
+	regexp.MustCompile(`%\s*Invalid (command|parameter)`), // NX-OS
+	regexp.MustCompile(`%\s*Incomplete command`),
+	regexp.MustCompile(`%\s*Ambiguous command`),
+	regexp.MustCompile(`%\s*(Unrecognized|Unknown) command`),
+	regexp.MustCompile(`%\s*Authorization failed`),
+}
+
+var commandErrorSettings struct {
+	sync.RWMutex
+	patterns []*regexp.Regexp
+}
+
+// SetCommandErrorPatterns replaces the patterns that tell a rejected command from
+// its output. A line matching one of them at the top of the output of a command
+// makes the command fail with a CommandError. Pass nil to go back to
+// DefaultCommandErrorPatterns, or an empty slice to turn the detection off.
+func SetCommandErrorPatterns(patterns []*regexp.Regexp) {
+	commandErrorSettings.Lock()
+	defer commandErrorSettings.Unlock()
+	commandErrorSettings.patterns = patterns
+}
+
+func activeCommandErrorPatterns() []*regexp.Regexp {
+	commandErrorSettings.RLock()
+	defer commandErrorSettings.RUnlock()
+	if commandErrorSettings.patterns == nil {
+		return DefaultCommandErrorPatterns
+	}
+	return commandErrorSettings.patterns
+}
+
+// CommandError is returned when the switch rejected a command, e.g. with
+// "% Invalid input detected at '^' marker.". The output of the session is returned
+// alongside it.
+type CommandError struct {
+	Switch   string
+	Command  string
+	Message  string // The error line as printed, e.g. "% Incomplete command."
+	Position int    // Offset in Command of the '^' marker, -1 when the switch printed none
+}
+
+func (e *CommandError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("%s :: %s :: %s", e.Switch, e.Command, e.Message)
+	}
+	return fmt.Sprintf("%s :: %s :: %s (marker at position %d)", e.Switch, e.Command, e.Message, e.Position)
+}
+
+// onlyCommandErrors reports whether err, possibly joined from the errors of several
+// commands as by RunCommandsConcurrently, is made of CommandErrors only, i.e. the
+// switch answered every command and rejected some.
+func onlyCommandErrors(err error) bool {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			if !onlyCommandErrors(err) {
+				return false
+			}
+		}
+		return true
+	}
+	var cmdErr *CommandError
+	return errors.As(err, &cmdErr)
+}
+
+// checkCommands returns the CommandError of the first of the commands the switch
+// rejected in the output of their session, or nil.
+func (c *Client) checkCommands(switch_commands []string, outputString string) error {
+	splitter := newCommandSplitter(switch_commands)
+	io.WriteString(splitter, outputString)
+	return commandErrors(c.SwitchHostname, splitter.results(nil), splitter.columns)
+}
+
+// commandErrors sets the Error of every result whose output is a rejection and
+// returns the first CommandError, or nil when every command was accepted. columns
+// holds where each command starts on its echo line, which the '^' marker is aligned
+// with.
+func commandErrors(switch_hostname string, results []CommandResult, columns []int) error {
+	patterns := activeCommandErrorPatterns()
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var first error
+	for i := range results {
+		if results[i].Error != nil || i >= len(columns) {
+			continue
+		}
+		if cmdErr := findCommandError(switch_hostname, results[i].Command, results[i].Output, columns[i], patterns); cmdErr != nil {
+			results[i].Error = cmdErr
+			if first == nil {
+				first = cmdErr
+			}
+		}
+	}
+	return first
+}
+
+// findCommandError looks for an error line at the top of the output of a command,
+// below the '^' marker if there is one. Error lines further down are left alone, as
+// they can be part of the output, e.g. of "show logging".
+func findCommandError(switch_hostname string, command string, output string, column int, patterns []*regexp.Regexp) *CommandError {
+	position := -1
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case trimmed == "^":
+			if marker := strings.Index(line, "^") - column; marker >= 0 {
+				position = marker
+			}
+			continue
+		}
+
+		for _, pattern := range patterns {
+			if pattern.MatchString(trimmed) {
+				return &CommandError{Switch: switch_hostname, Command: command, Message: trimmed, Position: position}
+			}
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package cisco
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// reVolatileConfigLine matches running-config lines that change without anyone
+// changing the configuration: timestamps, the NTP clock period the switch keeps
+// tuning, and the size banner.
+var reVolatileConfigLine = regexp.MustCompile(`^(?:` +
+	`Building configuration\.\.\.` +
+	`|Current configuration ?: .*` +
+	`|! (?:Last configuration change|NVRAM config last updated|No configuration change since last restart) .*` +
+	`|!(?:Time|Running configuration last done at): .*` + // NX-OS
+	`|ntp clock-period \d+` +
+	`|: Written by .*|: Saved` + // ASA
+	`|Cryptochecksum:\w+` + // ASA
+	`)$`)
+
+// reSecretConfigLines match running-config lines carrying a password, key or SNMP
+// community; every capture group is a secret. Encrypted and hashed values are
+// masked too, as type 7 passwords are trivially reversed and hashes can be cracked.
+var reSecretConfigLines = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:secret|password|passwd|key-string|pre-shared-key)(?: [0-9])? (\S+)`),
+	regexp.MustCompile(`^snmp-server community (\S+)`),
+	regexp.MustCompile(`^snmp-server host \S+ (?:(?:traps|informs) )?version (?:1|2c) (\S+)`),
+	regexp.MustCompile(`^snmp-server user \S+ .*\bauth (?:md5|sha\S*) (\S+)(?:.* priv (?:(?:des|3des|aes(?: \d+)?|aes-\d+) )?(\S+))?`),
+	regexp.MustCompile(`^(?:tacacs|radius)-server (?:host \S+ (?:.* )?)?key(?: [0-9])? (\S+)`),
+	regexp.MustCompile(`^ntp authentication-key \d+ md5 (\S+)`),
+	regexp.MustCompile(`^crypto isakmp key(?: [0-9])? (\S+)`),
+}
+
+// reSecretKeyBlock matches the header of a "tacacs server", "radius server" or ASA
+// "aaa-server" block, whose key line reSecretConfigKey matches. In other blocks, e.g.
+// a key chain, "key 1" is no secret.
+var (
+	reSecretKeyBlock  = regexp.MustCompile(`^(?:tacacs server|radius server|aaa-server) `)
+	reSecretConfigKey = regexp.MustCompile(`^\s+key(?: [0-9])? (\S+)`)
+)
+
+// BackupResult is the outcome of backing up one switch.
+type BackupResult struct {
+	Switch  string
+	Path    string // Backup file of the switch
+	Changed bool   // The configuration differs from the previous backup, or is the first one
+	Added   int    // Lines added since the previous backup
+	Removed int    // Lines removed since the previous backup
+	Err     error  // The previous backup, if any, is kept when the switch failed
+}
+
+// ConfigBackup pulls the running configuration of every switch of a registry into
+// a directory only its owner can read, one <switch>.cfg file per switch, and keeps the history of each in
+// git when Git is set. Configurations are normalized with NormalizeConfig first, so
+// a file only changes when the configuration does.
+type ConfigBackup struct {
+	Dir string
+
+	// Devices returns the switches to back up. It is called at every run, so
+	// switches added to the registry are picked up without a restart.
+	Devices func() ([]string, error)
+
+	// Git commits every changed file to a git repository in Dir, initialized on the
+	// first run, with one commit per switch. Commits use the git identity configured
+	// for the user or the repository.
+	Git bool
+
+	// MaskSecrets replaces passwords, keys and SNMP communities with "<removed>" in
+	// the backup files, see MaskConfigSecrets. Without it the files, and the git
+	// history, hold the credentials of the switches; they are only readable by
+	// their owner either way.
+	MaskSecrets bool
+
+	Concurrency int                // Switches backed up at once; DefaultFleetConcurrency when zero
+	OnResult    func(BackupResult) // Called after each switch of a run; may be nil
+}
+
+// NewConfigBackup returns a ConfigBackup writing to dir for the switches devices returns.
+func NewConfigBackup(dir string, devices func() ([]string, error)) *ConfigBackup {
+	return &ConfigBackup{Dir: dir, Devices: devices}
+}
+
+// NormalizeConfig strips the command echo, the prompt and the lines of a running
+// configuration that change on their own, so two backups of an unchanged
+// configuration are identical. Trailing spaces are removed from every line.
+func NormalizeConfig(rawOutput string) string {
+	var lines []string
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if reCommandEcho.MatchString(line) || reVolatileConfigLine.MatchString(line) {
+			continue
+		}
+		if len(lines) == 0 && line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// MaskConfigSecrets replaces the passwords, keys and SNMP communities of a running
+// configuration with "<removed>", so a backup can be shared or kept in a repository
+// that is not itself secret. A changed secret no longer shows in a diff.
+func MaskConfigSecrets(config string) string {
+	lines := strings.Split(config, "\n")
+	secretBlock := false
+	for i, line := range lines {
+		if !strings.HasPrefix(line, " ") {
+			secretBlock = reSecretKeyBlock.MatchString(line)
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "description ") {
+			continue
+		}
+		for _, re := range reSecretConfigLines {
+			line = maskSubmatches(re, line)
+		}
+		if secretBlock {
+			line = maskSubmatches(reSecretConfigKey, line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// maskSubmatches replaces every capture group re matches in line with "<removed>".
+func maskSubmatches(re *regexp.Regexp, line string) string {
+	indexes := re.FindStringSubmatchIndex(line)
+	if indexes == nil {
+		return line
+	}
+	// Replace from the end so the earlier indexes stay valid.
+	for group := len(indexes)/2 - 1; group > 0; group-- {
+		start, end := indexes[2*group], indexes[2*group+1]
+		if start >= 0 {
+			line = line[:start] + "<removed>" + line[end:]
+		}
+	}
+	return line
+}
+
+// Run backs up every switch once and then every interval until ctx is done. Errors
+// of a single run, e.g. a failing registry, are logged and the schedule goes on.
+func (b *ConfigBackup) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := b.RunOnce(ctx); err != nil {
+			logf(slog.LevelError, "Config Backup :: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce backs up every switch of the registry and returns a result per switch. A
+// failing switch does not stop the others; the error is in its result. The returned
+// error is for the run as a whole: the registry, the directory or git.
+func (b *ConfigBackup) RunOnce(ctx context.Context) ([]BackupResult, error) {
+	switch_hostnames, err := b.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("config backup: reading devices: %w", err)
+	}
+	if err := os.MkdirAll(b.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("config backup: %w", err)
+	}
+	if b.Git {
+		if _, err := os.Stat(filepath.Join(b.Dir, ".git")); os.IsNotExist(err) {
+			if _, err := b.git("init"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	fleet := NewFleet(b.Concurrency, nil)
+	fleetResults := fleet.RunContext(ctx, switch_hostnames, func(client *Client) (any, error) {
+		return client.RunCommand("show running-config")
+	})
+
+	results := make([]BackupResult, len(fleetResults))
+	var gitErr error
+	for i, fleetResult := range fleetResults {
+		result := BackupResult{Switch: fleetResult.Switch, Path: filepath.Join(b.Dir, backupFileName(fleetResult.Switch))}
+		if fleetResult.Err != nil {
+			result.Err = fleetResult.Err
+		} else {
+			result.Err = b.save(&result, fleetResult.Value.(string))
+		}
+		// A file written but not committed by an earlier run, e.g. because git failed,
+		// is committed now although it did not change this time.
+		if result.Err == nil && b.Git && gitErr == nil {
+			gitErr = b.commit(result)
+		}
+		if result.Err != nil {
+			logf(slog.LevelError, "%s :: Config Backup :: %v", result.Switch, result.Err)
+		}
+
+		results[i] = result
+		if b.OnResult != nil {
+			b.OnResult(result)
+		}
+	}
+
+	return results, gitErr
+}
+
+// save normalizes a running configuration and writes it to the backup file of the
+// switch if it differs from the previous backup.
+func (b *ConfigBackup) save(result *BackupResult, rawOutput string) error {
+	if strings.Contains(rawOutput, "% Invalid input") || strings.Contains(rawOutput, "% Authorization failed") {
+		return fmt.Errorf("show running-config was rejected; the previous backup is kept")
+	}
+	config := NormalizeConfig(rawOutput)
+	if config == "" {
+		return fmt.Errorf("show running-config returned nothing; the previous backup is kept")
+	}
+	if b.MaskSecrets {
+		config = MaskConfigSecrets(config)
+	}
+
+	previous, err := os.ReadFile(result.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if string(previous) == config {
+		return nil
+	}
+
+	result.Changed = true
+	result.Added, result.Removed = countLineChanges(string(previous), config)
+
+	temp := result.Path + ".tmp"
+	// The configuration holds the credentials of the switch unless MaskSecrets is set.
+	if err := os.WriteFile(temp, []byte(config), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(temp, result.Path)
+}
+
+// commit records the backup file of one switch in git if it has uncommitted changes.
+// The commit message counts the lines git sees added and removed.
+func (b *ConfigBackup) commit(result BackupResult) error {
+	name := filepath.Base(result.Path)
+	if _, err := b.git("add", "--", name); err != nil {
+		return err
+	}
+	numstat, err := b.git("diff", "--cached", "--numstat", "--", name)
+	if err != nil || numstat == "" {
+		return err
+	}
+	var added, removed int
+	fmt.Sscan(numstat, &added, &removed)
+
+	args := []string{"commit", "--quiet", "-m", fmt.Sprintf("%s: %d lines added, %d removed", result.Switch, added, removed), "--", name}
+	if _, err := b.git("config", "user.email"); err != nil {
+		// No identity configured, as on a fresh backup server; git refuses to commit without one.
+		args = append([]string{"-c", "user.name=Config Backup", "-c", "user.email=config-backup@localhost"}, args...)
+	}
+	_, err = b.git(args...)
+	return err
+}
+
+// git runs a git command in the backup directory.
+func (b *ConfigBackup) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = b.Dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("config backup: git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+// backupFileName returns the backup file name of a switch, safe for any file system.
+func backupFileName(switch_hostname string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(switch_hostname) + ".cfg"
+}
+
+// countLineChanges counts the lines of after missing from before and the other way
+// around, counting repeated lines as often as they occur.
+func countLineChanges(before string, after string) (added int, removed int) {
+	counts := make(map[string]int)
+	if before != "" {
+		for _, line := range strings.Split(strings.TrimSuffix(before, "\n"), "\n") {
+			counts[line]++
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(after, "\n"), "\n") {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			added++
+		}
+	}
+	for _, count := range counts {
+		removed += count
+	}
+	return added, removed
+}
@@ -0,0 +1,42 @@
+package cisco_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xtokio/cisco"
+)
+
+func TestMaskConfigSecrets(t *testing.T) {
+	config := `enable secret 9 $9$abc
+service password-encryption
+username admin privilege 15 secret 5 $1$xyz
+snmp-server community public RO
+snmp-server user mon grp v3 auth sha authpass priv aes 128 privpass
+tacacs-server host 10.1.1.1 key 7 0123ABC
+tacacs server ISE
+ address ipv4 10.1.1.1
+ key 7 1234ABCD
+key chain OSPF
+ key 1
+  key-string 7 0011
+interface Gi1/0/1
+ description password reset kiosk
+line vty 0 4
+ password 7 045802150C2E
+`
+	masked := cisco.MaskConfigSecrets(config)
+	for _, secret := range []string{"$9$abc", "$1$xyz", "public", "authpass", "privpass", "0123ABC", "1234ABCD", "0011", "045802150C2E"} {
+		if strings.Contains(masked, secret) {
+			t.Errorf("%q left in\n%s", secret, masked)
+		}
+	}
+	for _, kept := range []string{"service password-encryption", " key 1\n", "description password reset kiosk", "address ipv4 10.1.1.1"} {
+		if !strings.Contains(masked, kept) {
+			t.Errorf("%q masked in\n%s", kept, masked)
+		}
+	}
+	if strings.Count(masked, "\n") != strings.Count(config, "\n") {
+		t.Errorf("lines added or removed:\n%s", masked)
+	}
+}
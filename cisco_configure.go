@@ -0,0 +1,58 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrCommitFailed is returned when IOS-XR rejects a configuration commit. The staged
+// changes are discarded.
+var ErrCommitFailed = errors.New("configuration commit failed")
+
+// pushConfig sends configuration commands to a switch in configuration mode.
+func pushConfig(switch_hostname string, config_commands []string) (string, error) {
+	client, err := connectForConfig(switch_hostname)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	return client.configure("configure", config_commands, client.commandTimeout(DefaultCommandTimeout))
+}
+
+// configure sends configuration commands between "configure terminal" and "end",
+// after checking that none would be split into several CLI lines or trigger help. On
+// IOS-XR, where changes are only staged until committed, it ends with "commit" and
+// "abort" instead: abort leaves configuration mode and drops anything the commit
+// rejected, so a failed commit never stays pending in the session.
+func (c *Client) configure(label string, config_commands []string, commandTimeout time.Duration) (string, error) {
+	return c.configureContext(context.Background(), label, config_commands, commandTimeout)
+}
+
+// configureContext is configure with a context that ends the session early.
+func (c *Client) configureContext(ctx context.Context, label string, config_commands []string, commandTimeout time.Duration) (string, error) {
+	for _, cmd := range config_commands {
+		if err := checkTextParameter("configuration line", cmd); err != nil {
+			return "", fmt.Errorf("%s :: %s :: %w", c.SwitchHostname, label, err)
+		}
+	}
+
+	commands := append([]string{"configure terminal"}, config_commands...)
+	if c.platform() == PlatformIOSXR {
+		commands = append(commands, "commit", "abort")
+	} else {
+		commands = append(commands, "end")
+	}
+
+	outputString, err := c.runShell(ctx, label, commands, commandTimeout)
+	if err != nil {
+		return outputString, err
+	}
+	if c.platform() == PlatformIOSXR && strings.Contains(outputString, "Failed to commit") {
+		return outputString, fmt.Errorf("%s :: %s :: %w", c.SwitchHostname, label, ErrCommitFailed)
+	}
+	return outputString, nil
+}
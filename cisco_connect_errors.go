@@ -0,0 +1,100 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ConnectFailure classifies why connecting to a switch failed, so reports can tell a
+// switch that is down from one rejecting the credentials.
+type ConnectFailure string
+
+const (
+	ConnectDNS            ConnectFailure = "dns"            // The hostname did not resolve
+	ConnectRefused        ConnectFailure = "refused"        // The switch answered but nothing listens on the SSH port
+	ConnectTimeout        ConnectFailure = "timeout"        // No answer: switch down, or filtered on the way
+	ConnectUnreachable    ConnectFailure = "unreachable"    // No route to the switch
+	ConnectClosed         ConnectFailure = "closed"         // The switch hung up during the handshake, e.g. access-class or no free VTY line
+	ConnectKeyExchange    ConnectFailure = "key-exchange"   // No algorithm in common, see ConnectError.PeerAlgorithms
+	ConnectAuthentication ConnectFailure = "authentication" // The credentials were rejected
+	ConnectOther          ConnectFailure = "other"
+)
+
+// ConnectError is returned when dialing a switch fails.
+type ConnectError struct {
+	Switch  string
+	Failure ConnectFailure
+
+	// For ConnectKeyExchange: what could not be agreed on (e.g., "server to client
+	// cipher") and the algorithms the switch offered for it.
+	Negotiating    string
+	PeerAlgorithms []string
+
+	Err error
+}
+
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("failed to dial SSH to %s (%s): %v", e.Switch, e.Failure, e.Err)
+}
+
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes the error match ErrAuth, ErrUnreachable or ErrTimeout by its Failure.
+func (e *ConnectError) Is(target error) bool {
+	switch target {
+	case ErrAuth:
+		return e.Failure == ConnectAuthentication
+	case ErrUnreachable:
+		return e.Failure == ConnectDNS || e.Failure == ConnectRefused || e.Failure == ConnectTimeout || e.Failure == ConnectUnreachable
+	case ErrTimeout:
+		return e.Failure == ConnectTimeout
+	}
+	return false
+}
+
+// ConnectFailureOf returns the classification of a connection error anywhere in err's
+// chain, or "" when err did not come from connecting.
+func ConnectFailureOf(err error) ConnectFailure {
+	var connectErr *ConnectError
+	if errors.As(err, &connectErr) {
+		return connectErr.Failure
+	}
+	return ""
+}
+
+// newConnectError classifies an error returned by ssh.Dial.
+func newConnectError(switch_hostname string, err error) *ConnectError {
+	connectErr := &ConnectError{Switch: switch_hostname, Failure: ConnectOther, Err: err}
+
+	var dnsErr *net.DNSError
+	var negotiationErr *ssh.AlgorithmNegotiationError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		connectErr.Failure = ConnectDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		connectErr.Failure = ConnectRefused
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		connectErr.Failure = ConnectUnreachable
+	case errors.As(err, &netErr) && netErr.Timeout():
+		connectErr.Failure = ConnectTimeout
+	case errors.As(err, &negotiationErr):
+		connectErr.Failure = ConnectKeyExchange
+		connectErr.Negotiating = negotiationErr.What
+		connectErr.PeerAlgorithms = negotiationErr.RequestedAlgorithms
+	case strings.Contains(err.Error(), "unable to authenticate"):
+		// The ssh package reports authentication failures as plain errors.
+		connectErr.Failure = ConnectAuthentication
+	case errors.Is(err, io.EOF), errors.Is(err, syscall.ECONNRESET):
+		connectErr.Failure = ConnectClosed
+	}
+	return connectErr
+}
@@ -2,11 +2,14 @@ package cisco
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
+	"log/slog"
+	"net"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/ssh"
@@ -16,306 +19,221 @@ import (
 type Client struct {
 	*ssh.Client
 	SwitchHostname string
-}
-
-// ConnectToSwitchWithCredentials creates and returns a new Client with an active SSH session
-func connectToSwitchWithCredentials(switch_hostname string, username string, password string) (*Client, error) {
-	sshConfig := &ssh.ClientConfig{
-		User: username,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(password),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // Use a proper HostKeyCallback in production!
-		Timeout:         1 * time.Second,
-		// Manually define all supported ciphers
-		Config: ssh.Config{
-			Ciphers: []string{
-				// Modern ciphers (the defaults your other 9 switches use)
-				"aes128-gcm@openssh.com",
-				"aes256-gcm@openssh.com",
-				"chacha20-poly1305@openssh.com",
-				"aes128-ctr",
-				"aes192-ctr",
-				"aes256-ctr",
-
-				// Add a legacy cipher that the old switch supports
-				// (from the "peer offered" list in your error)
-				"aes128-cbc",
-			},
-			// KeyExchanges for HANDSHAKE
-			KeyExchanges: []string{
-				// Modern Kex (defaults from your error's "we offered" list)
-				"curve25519-sha256",
-				"ecdh-sha2-nistp256",
-				"ecdh-sha2-nistp384",
-				"ecdh-sha2-nistp521",
-				"diffie-hellman-group14-sha256",
-				// Legacy Kex (the one your switch needs)
-				"diffie-hellman-group1-sha1",
-				"diffie-hellman-group14-sha1",
-				"diffie-hellman-group1-sha256",
-				"diffie-hellman-group14-sha256",
-				"diffie-hellman-group1-sha512",
-				"diffie-hellman-group14-sha512",
-			},
-		},
-	}
-
-	sshClient, err := ssh.Dial("tcp", switch_hostname+":22", sshConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial SSH to %s: %w", switch_hostname, err)
-	}
 
-	return &Client{
-		Client:         sshClient,
-		SwitchHostname: switch_hostname,
-	}, nil
+	// MaxSessions caps how many sessions may run concurrently over this
+	// connection. Zero means DefaultMaxSessions.
+	MaxSessions int
+
+	// CommandTimeout caps how long a session may take to print its output, for
+	// every operation of the client but Collect_tech_support. Zero means the one set
+	// with SetTimeouts, or else DefaultCommandTimeout, and a few seconds for changes
+	// to a single interface.
+	CommandTimeout time.Duration
+
+	// Logger receives the log messages of the client. Nil means the one set with
+	// SetLogger.
+	Logger *slog.Logger
+
+	// PrivilegeLevel is the effective privilege level of the login, set by
+	// DetectPrivilege. Zero means not detected yet. It is written under privilegeMu;
+	// read it only while no other goroutine uses the client.
+	PrivilegeLevel int
+
+	// Platform selects the CLI dialect, e.g. commit-based configuration on IOS-XR.
+	// It is taken from SetPlatform when connecting; empty means IOS. DetectPlatform
+	// writes it under platformMu; read it only while no other goroutine uses the client.
+	Platform   Platform
+	platformMu sync.RWMutex
+
+	// privilegeMu serializes detecting and raising the privilege level, so a client
+	// shared by several goroutines detects it once.
+	privilegeMu sync.Mutex
+
+	// enablePassword answers the enable prompt on platforms that need it, see
+	// sessionSetup. Enable only changes it before setting escalate.
+	enablePassword string
+
+	// escalate makes every session enter privileged EXEC first, see Enable, and
+	// autoEnable lets requirePrivilege call Enable.
+	escalate   atomic.Bool
+	autoEnable bool
+
+	// jumps are the jump host connections the client was reached through, closed
+	// with it.
+	jumps []*ssh.Client
+
+	sessionsOnce sync.Once
+	sessions     chan struct{}
+
+	// active counts the shell sessions running, and uncleanTeardowns those that had
+	// to be torn down before the switch ended them, see Close.
+	active           sync.WaitGroup
+	uncleanTeardowns atomic.Int32
+
+	// unhealthy is set by keepAlive, see Healthy.
+	unhealthy atomic.Bool
 }
 
-func RunCommandWithCredentials(switch_hostname string, username string, password string, switch_command string) (string, error) {
-	client, err := connectToSwitchWithCredentials(switch_hostname, username, password)
-	if err != nil {
-		// Just return the connection error
-		return "", err
-	}
-	// 3. Defer closing the *client*
-	defer client.Close()
+// connectToSwitchWithCredentials creates and returns a new Client with an active SSH session
+func connectToSwitchWithCredentials(ctx context.Context, switch_hostname string, username string, password string) (*Client, error) {
+	return NewClientContext(ctx, Config{Host: switch_hostname, Username: username, Password: password})
+}
 
-	session, err := client.NewSession()
-	if err != nil {
-		log.Printf("%s :: %s :: Failed to create session :: %v", switch_hostname, switch_command, err)
-		return "", fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, switch_command, err)
-	}
-	defer session.Close()
+// DefaultSSHPort is the port dialed when neither the switch hostname nor the SSH
+// config gives one.
+const DefaultSSHPort = "22"
 
-	modes := ssh.TerminalModes{
-		ssh.ECHO:          0,
-		ssh.TTY_OP_ISPEED: 14400,
-		ssh.TTY_OP_OSPEED: 14400,
-	}
+// sshTarget is where, and as whom, to connect for a switch hostname.
+type sshTarget struct {
+	address       string // host:port to dial
+	user          string
+	identityFiles []string
+	proxyJump     []string // See SSHConfigHost.ProxyJump
+}
 
-	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
-		log.Printf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
+// resolveTarget works out the address and login for a switch hostname, which may
+// carry its own port for devices behind NAT, e.g. "switch01:2222" or
+// "[2001:db8::1]:2222", and applies the settings from SetSSHConfig.
+func resolveTarget(switch_hostname string, username string) sshTarget {
+	host, port := strings.Trim(switch_hostname, "[]"), ""
+	if h, p, err := net.SplitHostPort(switch_hostname); err == nil {
+		host, port = h, p
 	}
 
-	stdin, err := session.StdinPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdin for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
+	target := sshTarget{user: username}
+	if config := activeSSHConfig(); config != nil {
+		entry := config.Lookup(host)
+		if entry.HostName != "" {
+			host = entry.HostName
+		}
+		if port == "" {
+			port = entry.Port
+		}
+		if target.user == "" {
+			target.user = entry.User
+		}
+		target.identityFiles = entry.IdentityFiles
+		target.proxyJump = entry.ProxyJump
 	}
-	stdout, err := session.StdoutPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdout for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
+	if port == "" {
+		port = DefaultSSHPort
 	}
 
-	if err := session.Shell(); err != nil {
-		log.Printf("failed to start shell on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
+	target.address = net.JoinHostPort(host, port)
+	return target
+}
+
+// dialTarget connects to target, through its jump hosts if it has any, and returns
+// the jump host connections too so they can be closed with the client. Jump hosts
+// come from Config.JumpHosts, or else the ProxyJump of the SSH config, and get the
+// connection settings of config; those from the SSH config its password as well.
+func dialTarget(ctx context.Context, target sshTarget, config Config) (*ssh.Client, []*ssh.Client, error) {
+	hops := make([]sshTarget, 0, len(target.proxyJump)+len(config.JumpHosts)+1)
+	hopConfigs := make([]Config, 0, cap(hops))
+	addHop := func(host string, user string, hopConfig Config) {
+		if user == "" {
+			user = target.user
+		}
+		hop := resolveTarget(host, user)
+		hop.proxyJump = nil
+		hops = append(hops, hop)
+		hopConfigs = append(hopConfigs, hopConfig)
+	}
+	if len(config.JumpHosts) > 0 {
+		for _, jump := range config.JumpHosts {
+			hopConfig := config
+			if jump.Password != "" {
+				hopConfig.Password, hopConfig.Auth = jump.Password, nil
+			}
+			addHop(jump.Host, jump.Username, hopConfig)
+		}
+	} else {
+		for _, jump := range target.proxyJump {
+			user, host := "", jump
+			if u, h, ok := strings.Cut(jump, "@"); ok {
+				user, host = u, h
+			}
+			addHop(host, user, config)
+		}
 	}
+	hops = append(hops, target)
+	hopConfigs = append(hopConfigs, config)
 
-	commands := []string{
-		"terminal length 0",
-		switch_command,
-		"exit",
+	var jumps []*ssh.Client
+	var sshClient *ssh.Client
+	var err error
+	if config.JumpClient != nil {
+		sshClient, err = dialThrough(ctx, config.JumpClient, hops[0], hopConfigs[0])
+	} else {
+		sshClient, err = dialSSH(ctx, hops[0], hopConfigs[0])
 	}
-	for _, cmd := range commands {
-		_, err = fmt.Fprintf(stdin, "%s\n", cmd)
+	for i, hop := range hops[1:] {
 		if err != nil {
-			log.Printf("Failed to write to stdin on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
+			break
 		}
+		jumps = append(jumps, sshClient)
+		sshClient, err = dialThrough(ctx, sshClient, hop, hopConfigs[i+1])
 	}
-
-	var buf bytes.Buffer
-	// Channel to signal that session.Wait() has finished
-	done := make(chan error, 1)
-
-	// Goroutine to read stdout and wait for the session to close (after 'exit' command)
-	go func() {
-		// Reads from stdout until the session closes (EOF)
-		// This must happen *before* session.Wait() for session.Wait() to be useful.
-		buf.ReadFrom(stdout)
-		done <- session.Wait() // Wait for the remote command/shell to exit
-	}()
-
-	// --- TIMEOUT MECHANISM ---
-	// Give this command a generous 3 seconds to complete since 'show interface' can be long.
-	const commandTimeout = 30 * time.Second
-
-	select {
-	case err := <-done:
-		// Command execution finished successfully or with an error
-		if err != nil && err != io.EOF {
-			// io.EOF is often returned by session.Wait() on clean exit, which is fine
-			log.Printf("Session wait failed on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
+	if err != nil {
+		for i := len(jumps) - 1; i >= 0; i-- {
+			jumps[i].Close()
 		}
-	case <-time.After(commandTimeout):
-		// Timeout hit. Close the client connection to forcefully terminate the session.
-		client.Close()
-		log.Printf("Show Interfaces timed out after %s on %s", commandTimeout, switch_hostname)
-		return "", fmt.Errorf("%s command timed out after %s", switch_command, commandTimeout)
+		return nil, nil, err
 	}
-
-	outputString := buf.String()
-
-	return outputString, nil
+	return sshClient, jumps, nil
 }
 
-// ConnectToSwitch creates and returns a new Client with an active SSH session
-func connectToSwitch(switch_hostname string) (*Client, error) {
-	// Retrieve credentials from environment variables
-	var username = os.Getenv("CISCO_USERNAME")
-	var password = os.Getenv("CISCO_PASSWORD")
-
-	sshConfig := &ssh.ClientConfig{
-		User: username,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(password),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // Use a proper HostKeyCallback in production!
-		Timeout:         1 * time.Second,
-		// Manually define all supported ciphers
-		Config: ssh.Config{
-			Ciphers: []string{
-				// Modern ciphers (the defaults your other 9 switches use)
-				"aes128-gcm@openssh.com",
-				"aes256-gcm@openssh.com",
-				"chacha20-poly1305@openssh.com",
-				"aes128-ctr",
-				"aes192-ctr",
-				"aes256-ctr",
-
-				// Add a legacy cipher that the old switch supports
-				// (from the "peer offered" list in your error)
-				"aes128-cbc",
-			},
-			// KeyExchanges for HANDSHAKE
-			KeyExchanges: []string{
-				// Modern Kex (defaults from your error's "we offered" list)
-				"curve25519-sha256",
-				"ecdh-sha2-nistp256",
-				"ecdh-sha2-nistp384",
-				"ecdh-sha2-nistp521",
-				"diffie-hellman-group14-sha256",
-				// Legacy Kex (the one your switch needs)
-				"diffie-hellman-group1-sha1",
-				"diffie-hellman-group14-sha1",
-				"diffie-hellman-group1-sha256",
-				"diffie-hellman-group14-sha256",
-				"diffie-hellman-group1-sha512",
-				"diffie-hellman-group14-sha512",
-			},
-		},
-	}
-
-	sshClient, err := ssh.Dial("tcp", switch_hostname+":22", sshConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial SSH to %s: %w", switch_hostname, err)
-	}
+// dialSSH opens an SSH connection to target over a TCP connection from the Dialer set
+// with SetDialer.
+func dialSSH(ctx context.Context, target sshTarget, config Config) (*ssh.Client, error) {
+	clientConfig, release := sshClientConfig(target, config)
+	defer release()
+	dialCtx, cancel := context.WithTimeout(ctx, clientConfig.Timeout)
+	defer cancel()
 
-	return &Client{
-		Client:         sshClient,
-		SwitchHostname: switch_hostname,
-	}, nil
-}
-
-func RunCommand(switch_hostname string, switch_command string) (string, error) {
-	client, err := connectToSwitch(switch_hostname)
+	conn, err := activeDialer().DialContext(dialCtx, "tcp", target.address)
 	if err != nil {
-		// Just return the connection error
-		return "", err
+		return nil, err
 	}
-	// 3. Defer closing the *client*
-	defer client.Close()
+	return newSSHClient(ctx, conn, target, clientConfig)
+}
 
-	session, err := client.NewSession()
+// dialThrough opens an SSH connection to hop over a tunnel through an established one.
+func dialThrough(ctx context.Context, via *ssh.Client, hop sshTarget, config Config) (*ssh.Client, error) {
+	conn, err := via.DialContext(ctx, "tcp", hop.address)
 	if err != nil {
-		log.Printf("%s :: %s :: Failed to create session :: %v", switch_hostname, switch_command, err)
-		return "", fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, switch_command, err)
-	}
-	defer session.Close()
-
-	modes := ssh.TerminalModes{
-		ssh.ECHO:          0,
-		ssh.TTY_OP_ISPEED: 14400,
-		ssh.TTY_OP_OSPEED: 14400,
+		return nil, err
 	}
+	clientConfig, release := sshClientConfig(hop, config)
+	defer release()
+	return newSSHClient(ctx, conn, hop, clientConfig)
+}
 
-	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
-		log.Printf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-	}
+// newSSHClient runs the SSH handshake over conn, closing it on failure or when ctx
+// is done first.
+func newSSHClient(ctx context.Context, conn net.Conn, target sshTarget, config *ssh.ClientConfig) (*ssh.Client, error) {
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
 
-	stdin, err := session.StdinPipe()
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, target.address, config)
 	if err != nil {
-		log.Printf("Unable to setup stdin for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
-	}
-	stdout, err := session.StdoutPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdout for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
-	}
-
-	if err := session.Shell(); err != nil {
-		log.Printf("failed to start shell on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
-	}
-
-	commands := []string{
-		"terminal length 0",
-		switch_command,
-		"exit",
-	}
-	for _, cmd := range commands {
-		_, err = fmt.Fprintf(stdin, "%s\n", cmd)
-		if err != nil {
-			log.Printf("Failed to write to stdin on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
 		}
+		return nil, err
 	}
+	return ssh.NewClient(sshConn, channels, requests), nil
+}
 
-	var buf bytes.Buffer
-	// Channel to signal that session.Wait() has finished
-	done := make(chan error, 1)
-
-	// Goroutine to read stdout and wait for the session to close (after 'exit' command)
-	go func() {
-		// Reads from stdout until the session closes (EOF)
-		// This must happen *before* session.Wait() for session.Wait() to be useful.
-		buf.ReadFrom(stdout)
-		done <- session.Wait() // Wait for the remote command/shell to exit
-	}()
-
-	// --- TIMEOUT MECHANISM ---
-	// Give this command a generous 3 seconds to complete since 'show interface' can be long.
-	const commandTimeout = 30 * time.Second
-
-	select {
-	case err := <-done:
-		// Command execution finished successfully or with an error
-		if err != nil && err != io.EOF {
-			// io.EOF is often returned by session.Wait() on clean exit, which is fine
-			log.Printf("Session wait failed on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
-		}
-	case <-time.After(commandTimeout):
-		// Timeout hit. Close the client connection to forcefully terminate the session.
-		client.Close()
-		log.Printf("Show Interfaces timed out after %s on %s", commandTimeout, switch_hostname)
-		return "", fmt.Errorf("%s command timed out after %s", switch_command, commandTimeout)
-	}
-
-	outputString := buf.String()
-
-	return outputString, nil
+func RunCommandWithCredentials(switch_hostname string, username string, password string, switch_command string) (string, error) {
+	return RunCommandWithCredentialsContext(context.Background(), switch_hostname, username, password, switch_command)
 }
 
-func RunCommands(switch_hostname string, switch_commands []string) (string, error) {
-	client, err := connectToSwitch(switch_hostname)
+// RunCommandWithCredentialsContext is RunCommandWithCredentials with a context that
+// cancels connecting and the command.
+func RunCommandWithCredentialsContext(ctx context.Context, switch_hostname string, username string, password string, switch_command string) (string, error) {
+	client, err := connectToSwitchWithCredentials(ctx, switch_hostname, username, password)
 	if err != nil {
 		// Just return the connection error
 		return "", err
@@ -323,90 +241,48 @@ func RunCommands(switch_hostname string, switch_commands []string) (string, erro
 	// 3. Defer closing the *client*
 	defer client.Close()
 
-	session, err := client.NewSession()
-	if err != nil {
-		log.Printf("%s :: %s :: Failed to create session :: %v", switch_hostname, switch_commands, err)
-		return "", fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, switch_commands, err)
-	}
-	defer session.Close()
-
-	modes := ssh.TerminalModes{
-		ssh.ECHO:          0,
-		ssh.TTY_OP_ISPEED: 14400,
-		ssh.TTY_OP_OSPEED: 14400,
-	}
-
-	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
-		log.Printf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-	}
-
-	stdin, err := session.StdinPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdin for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
-	}
-	stdout, err := session.StdoutPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdout for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
-	}
-
-	if err := session.Shell(); err != nil {
-		log.Printf("failed to start shell on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
-	}
-
-	commands := []string{"terminal length 0"}
-	commands = append(commands, switch_commands...)
-	commands = append(commands, "exit")
-
-	for _, cmd := range commands {
-		_, err = fmt.Fprintf(stdin, "%s\n", cmd)
-		if err != nil {
-			log.Printf("Failed to write to stdin on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
-		}
-	}
+	return client.runShell(ctx, switch_command, []string{switch_command}, client.commandTimeout(DefaultCommandTimeout))
+}
 
-	var buf bytes.Buffer
-	// Channel to signal that session.Wait() has finished
-	done := make(chan error, 1)
+// ConnectToSwitch creates and returns a new Client with an active SSH session
+func connectToSwitch(switch_hostname string) (*Client, error) {
+	return connectToSwitchContext(context.Background(), switch_hostname)
+}
 
-	// Goroutine to read stdout and wait for the session to close (after 'exit' command)
-	go func() {
-		// Reads from stdout until the session closes (EOF)
-		// This must happen *before* session.Wait() for session.Wait() to be useful.
-		buf.ReadFrom(stdout)
-		done <- session.Wait() // Wait for the remote command/shell to exit
-	}()
+// connectToSwitchContext is connectToSwitch with a context that cancels dialing and
+// the SSH handshake.
+func connectToSwitchContext(ctx context.Context, switch_hostname string) (*Client, error) {
+	// Credentials come from environment variables
+	return NewClientContext(ctx, EnvConfig(switch_hostname))
+}
 
-	// --- TIMEOUT MECHANISM ---
-	// Give this command a generous 3 seconds to complete since 'show interface' can be long.
-	const commandTimeout = 30 * time.Second
+func RunCommand(switch_hostname string, switch_command string) (string, error) {
+	return RunCommandContext(context.Background(), switch_hostname, switch_command)
+}
 
-	select {
-	case err := <-done:
-		// Command execution finished successfully or with an error
-		if err != nil && err != io.EOF {
-			// io.EOF is often returned by session.Wait() on clean exit, which is fine
-			log.Printf("Session wait failed on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
-		}
-	case <-time.After(commandTimeout):
-		// Timeout hit. Close the client connection to forcefully terminate the session.
-		client.Close()
-		log.Printf("Show Interfaces timed out after %s on %s", commandTimeout, switch_hostname)
-		return "", fmt.Errorf("%s command timed out after %s", switch_commands, commandTimeout)
+// RunCommandContext is RunCommand with a context: canceling it, or its deadline
+// passing, stops connecting or ends the command, whichever is in progress. The
+// command still times out after DefaultCommandTimeout, or the one set with
+// SetTimeouts, when the context has no earlier deadline.
+func RunCommandContext(ctx context.Context, switch_hostname string, switch_command string) (string, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		// Just return the connection error
+		return "", err
 	}
+	// 3. Defer closing the *client*
+	defer client.Close()
 
-	outputString := buf.String()
+	return client.runShell(ctx, switch_command, []string{switch_command}, client.commandTimeout(DefaultCommandTimeout))
+}
 
-	return outputString, nil
+func RunCommands(switch_hostname string, switch_commands []string) (string, error) {
+	return RunCommandsContext(context.Background(), switch_hostname, switch_commands)
 }
 
-func Interface_shutdown(switch_hostname string, switch_interface string) (string, error) {
-	client, err := connectToSwitch(switch_hostname)
+// RunCommandsContext is RunCommands with a context, see RunCommandContext.
+func RunCommandsContext(ctx context.Context, switch_hostname string, switch_commands []string) (string, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
 	if err != nil {
 		// Just return the connection error
 		return "", err
@@ -414,97 +290,55 @@ func Interface_shutdown(switch_hostname string, switch_interface string) (string
 	// 3. Defer closing the *client*
 	defer client.Close()
 
-	session, err := client.NewSession()
-	if err != nil {
-		log.Printf("%s :: %s :: Failed to create session :: %v", switch_hostname, "shutdown", err)
-		return "", fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, "shutdown", err)
-	}
-	defer session.Close()
+	return client.runShell(ctx, fmt.Sprint(switch_commands), switch_commands, client.commandTimeout(DefaultCommandTimeout))
+}
 
-	modes := ssh.TerminalModes{
-		ssh.ECHO:          0,
-		ssh.TTY_OP_ISPEED: 14400,
-		ssh.TTY_OP_OSPEED: 14400,
-	}
+func Interface_shutdown(switch_hostname string, switch_interface string) (string, error) {
+	return Interface_shutdown_context(context.Background(), switch_hostname, switch_interface)
+}
 
-	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
-		log.Printf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
+// Interface_shutdown_context is Interface_shutdown with a context that cancels connecting
+// and the change.
+func Interface_shutdown_context(ctx context.Context, switch_hostname string, switch_interface string) (string, error) {
+	if err := checkInterfaceParameter(switch_interface); err != nil {
+		return "", err
 	}
 
-	stdin, err := session.StdinPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdin for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
-	}
-	stdout, err := session.StdoutPipe()
+	client, err := connectForConfigContext(ctx, switch_hostname)
 	if err != nil {
-		log.Printf("Unable to setup stdout for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
-	}
-
-	if err := session.Shell(); err != nil {
-		log.Printf("failed to start shell on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
+		// Just return the connection error
+		return "", err
 	}
+	// 3. Defer closing the *client*
+	defer client.Close()
 
 	commands := []string{
-		"terminal length 0", // Prevents paging '--More--' prompts
-		"configure terminal",
 		fmt.Sprintf("interface %s", switch_interface),
 		"shutdown",
-		"end",
-		"exit",
 	}
 
-	for _, cmd := range commands {
-		_, err = fmt.Fprintf(stdin, "%s\n", cmd)
-		if err != nil {
-			log.Printf("Failed to write to stdin on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
-		}
-	}
-
-	var buf bytes.Buffer
-	// Channel to signal that session.Wait() has finished
-	done := make(chan error, 1)
-
-	// Goroutine to read stdout and wait for the session to close (after 'exit' command)
-	go func() {
-		// Reads from stdout until the session closes (EOF)
-		// This must happen *before* session.Wait() for session.Wait() to be useful.
-		buf.ReadFrom(stdout)
-		done <- session.Wait() // Wait for the remote command/shell to exit
-	}()
-
-	// --- TIMEOUT MECHANISM ---
-	// Give this command a generous 3 seconds to complete since 'show interface' can be long.
-	const commandTimeout = 3 * time.Second
-
-	select {
-	case err := <-done:
-		// Command execution finished successfully or with an error
-		if err != nil && err != io.EOF {
-			// io.EOF is often returned by session.Wait() on clean exit, which is fine
-			log.Printf("Session wait failed on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
-		}
-	case <-time.After(commandTimeout):
-		// Timeout hit. Close the client connection to forcefully terminate the session.
-		client.Close()
-		log.Printf("Show Interfaces timed out after %s on %s", commandTimeout, switch_hostname)
-		return "", fmt.Errorf("%s command timed out after %s", "shutdown", commandTimeout)
+	outputString, err := client.configureContext(ctx, "shutdown", commands, client.commandTimeout(3*time.Second))
+	if err != nil {
+		return "", err
 	}
 
-	outputString := buf.String()
-
-	log.Printf("Successfully applied '%s' to interface %s on %s.", "shutdown", switch_interface, switch_hostname)
+	client.logf(slog.LevelInfo, "Successfully applied '%s' to interface %s on %s.", "shutdown", switch_interface, switch_hostname)
 
 	return outputString, nil
 }
 
 func Interface_no_shutdown(switch_hostname string, switch_interface string) (string, error) {
-	client, err := connectToSwitch(switch_hostname)
+	return Interface_no_shutdown_context(context.Background(), switch_hostname, switch_interface)
+}
+
+// Interface_no_shutdown_context is Interface_no_shutdown with a context that cancels connecting
+// and the change.
+func Interface_no_shutdown_context(ctx context.Context, switch_hostname string, switch_interface string) (string, error) {
+	if err := checkInterfaceParameter(switch_interface); err != nil {
+		return "", err
+	}
+
+	client, err := connectForConfigContext(ctx, switch_hostname)
 	if err != nil {
 		// Just return the connection error
 		return "", err
@@ -512,108 +346,119 @@ func Interface_no_shutdown(switch_hostname string, switch_interface string) (str
 	// 3. Defer closing the *client*
 	defer client.Close()
 
-	session, err := client.NewSession()
-	if err != nil {
-		log.Printf("%s :: %s :: Failed to create session :: %v", switch_hostname, "shutdown", err)
-		return "", fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, "shutdown", err)
+	commands := []string{
+		fmt.Sprintf("interface %s", switch_interface),
+		"no shutdown",
 	}
-	defer session.Close()
 
-	modes := ssh.TerminalModes{
-		ssh.ECHO:          0,
-		ssh.TTY_OP_ISPEED: 14400,
-		ssh.TTY_OP_OSPEED: 14400,
+	outputString, err := client.configureContext(ctx, "no shutdown", commands, client.commandTimeout(3*time.Second))
+	if err != nil {
+		return "", err
 	}
 
-	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
-		log.Printf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-	}
+	client.logf(slog.LevelInfo, "Successfully applied '%s' to interface %s on %s.", "no shutdown", switch_interface, switch_hostname)
 
-	stdin, err := session.StdinPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdin for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
+	return outputString, nil
+}
+
+func Interface_change_description(switch_hostname string, switch_interface string, interface_description string) (string, error) {
+	return Interface_change_description_context(context.Background(), switch_hostname, switch_interface, interface_description)
+}
+
+// Interface_change_description_context is Interface_change_description with a context that cancels connecting
+// and the change.
+func Interface_change_description_context(ctx context.Context, switch_hostname string, switch_interface string, interface_description string) (string, error) {
+	if err := checkInterfaceParameter(switch_interface); err != nil {
+		return "", err
 	}
-	stdout, err := session.StdoutPipe()
-	if err != nil {
-		log.Printf("Unable to setup stdout for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
+	if err := checkTextParameter("description", interface_description); err != nil {
+		return "", err
 	}
 
-	if err := session.Shell(); err != nil {
-		log.Printf("failed to start shell on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
+	client, err := connectForConfigContext(ctx, switch_hostname)
+	if err != nil {
+		// Just return the connection error
+		return "", err
 	}
+	// 3. Defer closing the *client*
+	defer client.Close()
 
 	commands := []string{
-		"terminal length 0", // Prevents paging '--More--' prompts
-		"configure terminal",
 		fmt.Sprintf("interface %s", switch_interface),
-		"no shutdown",
-		"end",
-		"exit",
+		fmt.Sprintf("description %s", interface_description),
 	}
 
-	for _, cmd := range commands {
-		_, err = fmt.Fprintf(stdin, "%s\n", cmd)
-		if err != nil {
-			log.Printf("Failed to write to stdin on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
-		}
+	outputString, err := client.configureContext(ctx, "description", commands, client.commandTimeout(3*time.Second))
+	if err != nil {
+		return "", err
 	}
 
-	var buf bytes.Buffer
-	// Channel to signal that session.Wait() has finished
-	done := make(chan error, 1)
+	client.logf(slog.LevelInfo, "Successfully changed description '%s' to interface %s on %s.", interface_description, switch_interface, switch_hostname)
 
-	// Goroutine to read stdout and wait for the session to close (after 'exit' command)
-	go func() {
-		// Reads from stdout until the session closes (EOF)
-		// This must happen *before* session.Wait() for session.Wait() to be useful.
-		buf.ReadFrom(stdout)
-		done <- session.Wait() // Wait for the remote command/shell to exit
-	}()
+	return outputString, nil
+}
 
-	// --- TIMEOUT MECHANISM ---
-	// Give this command a generous 3 seconds to complete since 'show interface' can be long.
-	const commandTimeout = 3 * time.Second
+// runShell opens a new session on the client's connection, starts an interactive
+// shell, disables paging, sends the commands followed by "exit" and returns
+// everything the switch printed. label identifies the operation in logs and errors.
+// A command the switch rejected is a CommandError, returned with the output.
+func (c *Client) runShell(ctx context.Context, label string, switch_commands []string, commandTimeout time.Duration) (string, error) {
+	switch_hostname := c.SwitchHostname
 
-	select {
-	case err := <-done:
-		// Command execution finished successfully or with an error
-		if err != nil && err != io.EOF {
-			// io.EOF is often returned by session.Wait() on clean exit, which is fine
-			log.Printf("Session wait failed on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
+	if replay := activeCassette(CassetteReplay); replay != nil {
+		outputString, err := replay.Replay(switch_hostname, switch_commands)
+		if err != nil {
+			return "", err
 		}
-	case <-time.After(commandTimeout):
-		// Timeout hit. Close the client connection to forcefully terminate the session.
-		client.Close()
-		log.Printf("Show Interfaces timed out after %s on %s", commandTimeout, switch_hostname)
-		return "", fmt.Errorf("%s command timed out after %s", "shutdown", commandTimeout)
+		return outputString, c.checkCommands(switch_commands, outputString)
 	}
 
+	var buf bytes.Buffer
+	if _, err := c.streamShell(ctx, label, switch_commands, commandTimeout, &buf); err != nil {
+		return "", err
+	}
 	outputString := buf.String()
+	c.record(label, switch_commands, outputString)
 
-	log.Printf("Successfully applied '%s' to interface %s on %s.", "shutdown", switch_interface, switch_hostname)
+	return outputString, c.checkCommands(switch_commands, outputString)
+}
 
-	return outputString, nil
+// record saves the output of a session to the cassette being recorded, if any.
+func (c *Client) record(label string, switch_commands []string, outputString string) {
+	if recorder := activeCassette(CassetteRecord); recorder != nil {
+		if err := recorder.Record(c.SwitchHostname, switch_commands, outputString); err != nil {
+			c.logf(slog.LevelError, "%s :: %s :: %v", c.SwitchHostname, label, err)
+		}
+	}
 }
 
-func Interface_change_description(switch_hostname string, switch_interface string, interface_description string) (string, error) {
-	client, err := connectToSwitch(switch_hostname)
-	if err != nil {
-		// Just return the connection error
-		return "", err
+// streamShell is runShell writing the output to w as it arrives instead of holding
+// it in memory, and returns the number of bytes written. A commandTimeout of zero
+// waits as long as the switch takes. Streamed sessions are not recorded to a cassette.
+// When ctx is done first, the session is logged out as on a timeout, which takes up
+// to LogoutTimeout.
+func (c *Client) streamShell(ctx context.Context, label string, switch_commands []string, commandTimeout time.Duration, w io.Writer) (int64, error) {
+	switch_hostname := c.SwitchHostname
+
+	if replay := activeCassette(CassetteReplay); replay != nil {
+		outputString, err := replay.Replay(switch_hostname, switch_commands)
+		if err != nil {
+			return 0, err
+		}
+		n, err := io.WriteString(w, outputString)
+		return int64(n), err
 	}
-	// 3. Defer closing the *client*
-	defer client.Close()
 
-	session, err := client.NewSession()
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("%s command canceled: %w", label, err)
+	}
+
+	defer c.trackSession()()
+
+	session, err := c.NewSession()
 	if err != nil {
-		log.Printf("%s :: %s :: Failed to create session :: %v", switch_hostname, "shutdown", err)
-		return "", fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, "shutdown", err)
+		c.logf(slog.LevelError, "%s :: %s :: Failed to create session :: %v", switch_hostname, label, err)
+		return 0, fmt.Errorf("%s :: %s :: Failed to create session :: %v", switch_hostname, label, err)
 	}
 	defer session.Close()
 
@@ -624,44 +469,40 @@ func Interface_change_description(switch_hostname string, switch_interface strin
 	}
 
 	if err := session.RequestPty("vt100", 80, 200, modes); err != nil {
-		log.Printf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "request for pseudo-terminal failed for %s: %v", switch_hostname, err)
+		return 0, fmt.Errorf("request for pseudo-terminal failed for %s: %v", switch_hostname, err)
 	}
 
 	stdin, err := session.StdinPipe()
 	if err != nil {
-		log.Printf("Unable to setup stdin for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "Unable to setup stdin for session on %s: %v", switch_hostname, err)
+		return 0, fmt.Errorf("unable to setup stdin for session on %s: %v", switch_hostname, err)
 	}
 	stdout, err := session.StdoutPipe()
 	if err != nil {
-		log.Printf("Unable to setup stdout for session on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "Unable to setup stdout for session on %s: %v", switch_hostname, err)
+		return 0, fmt.Errorf("unable to setup stdout for session on %s: %v", switch_hostname, err)
 	}
 
 	if err := session.Shell(); err != nil {
-		log.Printf("failed to start shell on %s: %v", switch_hostname, err)
-		return "", fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "failed to start shell on %s: %v", switch_hostname, err)
+		return 0, fmt.Errorf("failed to start shell on %s: %v", switch_hostname, err)
 	}
 
-	commands := []string{
-		"terminal length 0", // Prevents paging '--More--' prompts
-		"configure terminal",
-		fmt.Sprintf("interface %s", switch_interface),
-		fmt.Sprintf("description %s", interface_description),
-		"end",
-		"exit",
-	}
+	commands := c.sessionSetup() // Prevents paging '--More--' prompts and line wrapping
+	commands = append(commands, switch_commands...)
+	commands = append(commands, "exit")
 
 	for _, cmd := range commands {
 		_, err = fmt.Fprintf(stdin, "%s\n", cmd)
 		if err != nil {
-			log.Printf("Failed to write to stdin on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
+			c.logf(slog.LevelError, "Failed to write to stdin on %s: %v", switch_hostname, err)
+			return 0, fmt.Errorf("failed to write to stdin on %s: %v", switch_hostname, err)
 		}
 	}
 
-	var buf bytes.Buffer
+	var written int64
+	var copyErr error
 	// Channel to signal that session.Wait() has finished
 	done := make(chan error, 1)
 
@@ -669,63 +510,83 @@ func Interface_change_description(switch_hostname string, switch_interface strin
 	go func() {
 		// Reads from stdout until the session closes (EOF)
 		// This must happen *before* session.Wait() for session.Wait() to be useful.
-		buf.ReadFrom(stdout)
+		written, copyErr = io.Copy(w, stdout)
+		if copyErr != nil {
+			// w failed, e.g. a full disk; stop the switch from sending more.
+			session.Close()
+		}
 		done <- session.Wait() // Wait for the remote command/shell to exit
 	}()
 
-	// --- TIMEOUT MECHANISM ---
-	// Give this command a generous 3 seconds to complete since 'show interface' can be long.
-	const commandTimeout = 3 * time.Second
+	var timeout <-chan time.Time // nil, never fires, when there is no timeout
+	if commandTimeout > 0 {
+		timer := time.NewTimer(commandTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
 
+	// --- TIMEOUT MECHANISM ---
 	select {
 	case err := <-done:
+		if copyErr != nil {
+			c.logf(slog.LevelError, "%s :: %s :: Failed to write output: %v", switch_hostname, label, copyErr)
+			return written, fmt.Errorf("%s :: %s :: writing output: %w", switch_hostname, label, copyErr)
+		}
 		// Command execution finished successfully or with an error
 		if err != nil && err != io.EOF {
 			// io.EOF is often returned by session.Wait() on clean exit, which is fine
-			log.Printf("Session wait failed on %s: %v", switch_hostname, err)
-			return "", fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
+			c.logf(slog.LevelError, "Session wait failed on %s: %v", switch_hostname, err)
+			return written, fmt.Errorf("session wait failed on %s: %w", switch_hostname, err)
 		}
-	case <-time.After(commandTimeout):
-		// Timeout hit. Close the client connection to forcefully terminate the session.
-		client.Close()
-		log.Printf("Show Interfaces timed out after %s on %s", commandTimeout, switch_hostname)
-		return "", fmt.Errorf("%s command timed out after %s", "shutdown", commandTimeout)
-	}
-
-	outputString := buf.String()
-
-	log.Printf("Successfully changed description '%s' to interface %s on %s.", interface_description, switch_interface, switch_hostname)
-
-	return outputString, nil
+	case <-timeout:
+		// Timeout hit. Log out of the session so the switch frees its VTY line; the
+		// connection itself may still be shared with other sessions.
+		c.logout(session, stdin, done)
+		c.logf(slog.LevelError, "%s timed out after %s on %s", label, commandTimeout, switch_hostname)
+		return 0, fmt.Errorf("%s command %w after %s", label, ErrTimeout, commandTimeout)
+	case <-ctx.Done():
+		c.logout(session, stdin, done)
+		c.logf(slog.LevelWarn, "%s canceled on %s: %v", label, switch_hostname, ctx.Err())
+		return 0, fmt.Errorf("%s command canceled: %w", label, ctx.Err())
+	}
+
+	return written, nil
 }
 
-// Close closes the underlying SSH connection
-func (c *Client) Close() {
-	c.Client.Close()
-}
+// interfaceNameReplacer maps long interface type names to their short form.
+// Using strings.NewReplacer is the most efficient way to do multiple replacements,
+// and building it once avoids re-compiling it for every interface name.
+var interfaceNameReplacer = strings.NewReplacer(
+	"AppGigabitEthernet", "Ap",
+	"FastEthernet", "Fa",
+	"GigabitEthernet", "Gi",
+	"FiveGigabitEthernet", "Fi",
+	"FiveGi", "Fi",
+	"Fiv", "Fi",
+	"TenGigabitEthernet", "Te",
+	"TenGigE", "Te", // IOS-XR
+	"TenGi", "Te",
+	"Ten", "Te",
+	"TwentyGigabitEthernet", "Twe",
+	"TwentyFiveGigE", "Twe",
+	"TwentyFigE", "Twe",
+	"FortyGigabitEthernet", "Fo",
+	"FortyGigE", "Fo", // IOS-XR
+	"FortyGi", "Fo",
+	"HundredGigE", "Hu",
+	"Bundle-Ether", "BE", // IOS-XR
+	"Gig", "Gi", // In case "Gig" is used instead of "GigabitEthernet"
+)
 
-// normalizeInterfaceName shortens interface names to a standard format.
-func normalizeInterfaceName(name string) string {
+// shortInterfaceName shortens interface names to a standard format. Internal
+// comparisons use it regardless of the configured InterfaceNameStyle.
+func shortInterfaceName(name string) string {
 	name = strings.ReplaceAll(name, " ", "")
-
-	// Using strings.NewReplacer is the most efficient way to do multiple replacements.
-	replacer := strings.NewReplacer(
-		"AppGigabitEthernet", "Ap",
-		"FastEthernet", "Fa",
-		"GigabitEthernet", "Gi",
-		"FiveGigabitEthernet", "Fi",
-		"FiveGi", "Fi",
-		"Fiv", "Fi",
-		"TenGigabitEthernet", "Te",
-		"TenGi", "Te",
-		"Ten", "Te",
-		"TwentyGigabitEthernet", "Twe",
-		"TwentyFiveGigE", "Twe",
-		"TwentyFigE", "Twe",
-		"FortyGigabitEthernet", "Fo",
-		"FortyGi", "Fo",
-		"HundredGigE", "Hu",
-		"Gig", "Gi", // In case "Gig" is used instead of "GigabitEthernet"
-	)
-	return replacer.Replace(name)
+	// Small Business switches print lowercase types, e.g. gi1/0/1.
+	if digits := strings.IndexAny(name, "0123456789"); digits > 0 && name[0] >= 'a' && name[0] <= 'z' {
+		if title := strings.ToUpper(name[:1]) + name[1:digits]; interfaceTypeNames[title] != "" {
+			name = title + name[digits:]
+		}
+	}
+	return interfaceNameReplacer.Replace(name)
 }
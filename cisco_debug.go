@@ -0,0 +1,74 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ParseError is returned by the Show_* functions when a command ran but its output
+// could not be parsed. It carries the raw output, so the failure can be diagnosed or
+// attached to a bug report:
+//
+//	var parseErr *cisco.ParseError
+//	if errors.As(err, &parseErr) {
+//		os.WriteFile("failed.txt", []byte(parseErr.RawOutput), 0o644)
+//	}
+type ParseError struct {
+	Switch    string
+	Command   string
+	RawOutput string
+	Err       error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %q output: %v", e.Command, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every ParseError match ErrParse.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// DebugResult is the raw output of a command together with what the parser made of it.
+type DebugResult struct {
+	Switch    string
+	Command   string
+	RawOutput string
+	Parsed    any // The registered parser's result, e.g. []InterfaceStatus
+	Stats     ParseStats
+}
+
+// Show_debug runs any command with a registered parser and returns both the raw output
+// and the parsed result, so the two can be compared side by side. A parse failure is
+// returned as a *ParseError alongside the result; for commands without a parser only
+// the raw output is filled in.
+func Show_debug(switch_hostname string, switch_command string) (DebugResult, error) {
+	return Show_debug_context(context.Background(), switch_hostname, switch_command)
+}
+
+// Show_debug_context is Show_debug with a context that cancels the command.
+func Show_debug_context(ctx context.Context, switch_hostname string, switch_command string) (DebugResult, error) {
+	result := DebugResult{Switch: switch_hostname, Command: switch_command}
+
+	outputString, err := RunCommandContext(ctx, switch_hostname, switch_command)
+	if err != nil {
+		return result, err
+	}
+	result.RawOutput = outputString
+
+	if _, ok := fixtureParsers[switch_command]; !ok {
+		return result, nil
+	}
+
+	parseStart := time.Now()
+	result.Parsed, result.Stats = SafeParse(switch_command, outputString)
+	result.Stats.Switch = switch_hostname
+	err = reportParse(switch_hostname, switch_command, outputString, result.Stats.Records, parseStart, result.Stats.Err)
+
+	return result, err
+}
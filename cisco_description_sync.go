@@ -0,0 +1,212 @@
+package cisco
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// DescriptionChange is an interface description that Sync_interface_descriptions set.
+type DescriptionChange struct {
+	Interface string
+	Before    string
+	After     string
+}
+
+// DescriptionSyncReport describes what Sync_interface_descriptions did.
+type DescriptionSyncReport struct {
+	Switch    string
+	Changed   []DescriptionChange
+	Unchanged int      // Interfaces that already had the wanted description
+	Unknown   []string // Interfaces in the input that the switch does not have; left alone
+	Output    string   // Switch output of the configuration, empty when nothing changed
+}
+
+// LoadDescriptionsCSV reads interface descriptions from a CSV file with one
+// "interface,description" row per interface, e.g. exported from the patch records.
+// A first row with "interface" or "port" in its first column is taken as a header.
+// An empty description removes the description of the interface.
+func LoadDescriptionsCSV(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading descriptions: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	descriptions := make(map[string]string)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading descriptions: %w", err)
+		}
+		iface := strings.TrimSpace(record[0])
+		if row == 1 && (strings.EqualFold(iface, "interface") || strings.EqualFold(iface, "port")) {
+			continue
+		}
+		if iface == "" {
+			continue
+		}
+		descriptions[iface] = strings.TrimSpace(record[1])
+	}
+
+	return descriptions, nil
+}
+
+// NeighborDescriptions derives interface descriptions from CDP and LLDP neighbors in
+// the form "to core01:Gi3/12". Domain names and serial number suffixes are dropped
+// from the neighbor name. CDP wins when both protocols see a neighbor on a port.
+func NeighborDescriptions(cdp []CdpNeighbor, lldp []LldpNeighbor) map[string]string {
+	descriptions := make(map[string]string)
+	add := func(iface string, neighbor string, port string) {
+		iface = shortInterfaceName(iface)
+		if _, ok := descriptions[iface]; ok || iface == "" || neighbor == "" {
+			return
+		}
+		description := "to " + shortNeighborName(neighbor)
+		if port != "" {
+			description += ":" + shortInterfaceName(port)
+		}
+		descriptions[iface] = description
+	}
+
+	for _, neighbor := range cdp {
+		add(neighbor.Interface, neighbor.Neighbor, neighbor.NeighborInterface)
+	}
+	for _, neighbor := range lldp {
+		add(neighbor.Interface, neighbor.Neighbor, neighbor.NeighborInterface)
+	}
+
+	return descriptions
+}
+
+// Sync_interface_descriptions sets the description of every interface in
+// descriptions, keyed by interface name in any spelling. Interfaces that already
+// have the wanted description are not touched, so running it twice changes nothing
+// the second time; all changes go out in one configuration session.
+func Sync_interface_descriptions(switch_hostname string, descriptions map[string]string) (DescriptionSyncReport, error) {
+	return Sync_interface_descriptions_context(context.Background(), switch_hostname, descriptions)
+}
+
+// Sync_interface_descriptions_context is Sync_interface_descriptions with a context that cancels connecting and the change.
+func Sync_interface_descriptions_context(ctx context.Context, switch_hostname string, descriptions map[string]string) (DescriptionSyncReport, error) {
+	for iface, description := range descriptions {
+		if err := checkInterfaceParameter(iface); err != nil {
+			return DescriptionSyncReport{Switch: switch_hostname}, err
+		}
+		if err := checkTextParameter("description", description); err != nil {
+			return DescriptionSyncReport{Switch: switch_hostname}, err
+		}
+	}
+
+	client, err := connectForConfigContext(ctx, switch_hostname)
+	if err != nil {
+		return DescriptionSyncReport{Switch: switch_hostname}, err
+	}
+	defer client.Close()
+
+	return client.syncDescriptions(ctx, descriptions)
+}
+
+// Sync_neighbor_descriptions describes every port with a CDP or LLDP neighbor after
+// that neighbor, as built by NeighborDescriptions, and applies the descriptions with
+// the same rules as Sync_interface_descriptions. Ports without neighbors keep theirs.
+func Sync_neighbor_descriptions(switch_hostname string) (DescriptionSyncReport, error) {
+	return Sync_neighbor_descriptions_context(context.Background(), switch_hostname)
+}
+
+// Sync_neighbor_descriptions_context is Sync_neighbor_descriptions with a context that cancels connecting and the change.
+func Sync_neighbor_descriptions_context(ctx context.Context, switch_hostname string) (DescriptionSyncReport, error) {
+	client, err := connectForConfigContext(ctx, switch_hostname)
+	if err != nil {
+		return DescriptionSyncReport{Switch: switch_hostname}, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrentlyContext(ctx, []string{"show cdp neighbors", "show lldp neighbors"})
+	if err != nil {
+		return DescriptionSyncReport{Switch: switch_hostname}, err
+	}
+	// Either protocol may be disabled; its neighbors are simply missing.
+	cdp, _ := ParseCdpNeighbors(outputs[0])
+	lldp, _ := ParseLldpNeighbors(outputs[1])
+
+	descriptions := NeighborDescriptions(cdp, lldp)
+	for _, description := range descriptions {
+		if err := checkTextParameter("description", description); err != nil {
+			return DescriptionSyncReport{Switch: switch_hostname}, err
+		}
+	}
+
+	return client.syncDescriptions(ctx, descriptions)
+}
+
+// syncDescriptions compares descriptions with "show interfaces description" and
+// configures the interfaces that differ.
+func (c *Client) syncDescriptions(ctx context.Context, descriptions map[string]string) (DescriptionSyncReport, error) {
+	report := DescriptionSyncReport{Switch: c.SwitchHostname}
+
+	outputString, err := c.RunCommandContext(ctx, "show interfaces description")
+	if err != nil {
+		return report, err
+	}
+	current, err := ParseInterfaceDescriptions(outputString)
+	if err != nil {
+		return report, fmt.Errorf("%s :: show interfaces description :: %w", c.SwitchHostname, err)
+	}
+	existing := make(map[string]InterfaceDescription, len(current))
+	for _, iface := range current {
+		existing[shortInterfaceName(iface.Interface)] = iface
+	}
+
+	for iface, description := range descriptions {
+		found, ok := existing[shortInterfaceName(iface)]
+		switch {
+		case !ok:
+			report.Unknown = append(report.Unknown, normalizeInterfaceName(iface))
+		case found.Description == strings.TrimSpace(description):
+			report.Unchanged++
+		default:
+			report.Changed = append(report.Changed, DescriptionChange{Interface: found.Interface, Before: found.Description, After: strings.TrimSpace(description)})
+		}
+	}
+	SortByInterface(report.Changed, func(change DescriptionChange) string { return change.Interface })
+	SortByInterface(report.Unknown, func(iface string) string { return iface })
+
+	if len(report.Changed) == 0 {
+		return report, nil
+	}
+
+	var commands []string
+	for _, change := range report.Changed {
+		commands = append(commands, "interface "+change.Interface)
+		if change.After == "" {
+			commands = append(commands, "no description")
+		} else {
+			commands = append(commands, "description "+change.After)
+		}
+	}
+	report.Output, err = c.configureContext(ctx, "description sync", commands, c.commandTimeout(10*time.Second))
+	if err != nil {
+		return report, err
+	}
+
+	for i := range report.Changed {
+		report.Changed[i].Interface = normalizeInterfaceName(report.Changed[i].Interface)
+	}
+	c.logf(slog.LevelInfo, "Successfully changed %d interface descriptions on %s.", len(report.Changed), c.SwitchHostname)
+
+	return report, nil
+}
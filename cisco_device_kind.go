@@ -0,0 +1,102 @@
+package cisco
+
+import (
+	"slices"
+	"strings"
+)
+
+// DeviceKind is what kind of device a CDP or LLDP neighbor is, as far as its
+// advertisement tells.
+type DeviceKind string
+
+const (
+	DeviceUnknown     DeviceKind = ""
+	DevicePhone       DeviceKind = "phone"
+	DeviceAccessPoint DeviceKind = "access-point"
+	DeviceCamera      DeviceKind = "camera"
+	DeviceSwitch      DeviceKind = "switch"
+	DeviceRouter      DeviceKind = "router"
+	DeviceHost        DeviceKind = "host"
+)
+
+// accessPointPlatforms are platform prefixes of Cisco access points in CDP; APs also
+// advertise themselves as trans bridges, which is the fallback.
+var accessPointPlatforms = []string{"AIR-", "C91", "CW91"}
+
+// cameraKeywords identify video surveillance cameras in CDP platforms and LLDP system
+// descriptions, matched case-insensitively.
+var cameraKeywords = []string{"camera", "axis", "hikvision", "civs-ipc", "ipc-", "video surveillance"}
+
+// ClassifyCdpNeighbor tells what a CDP neighbor is from its capability codes and
+// platform: a phone (P, or an "IP Phone" platform), a camera, an access point, a
+// switch (S), a router (R) or a host (H).
+func ClassifyCdpNeighbor(neighbor CdpNeighbor) DeviceKind {
+	codes := strings.Fields(neighbor.Capability)
+	has := func(code string) bool { return slices.Contains(codes, code) }
+
+	switch {
+	case has("P") || strings.Contains(neighbor.Platform, "Phone"):
+		return DevicePhone
+	case containsAnyFold(neighbor.Platform, cameraKeywords):
+		return DeviceCamera
+	case hasAnyPrefix(neighbor.Platform, accessPointPlatforms), has("T") && !has("S") && !has("R"):
+		return DeviceAccessPoint
+	case has("S"):
+		return DeviceSwitch
+	case has("R"):
+		return DeviceRouter
+	case has("H"):
+		return DeviceHost
+	}
+	return DeviceUnknown
+}
+
+// ClassifyLldpNeighbor tells what an LLDP neighbor is from its enabled capabilities
+// (T telephone, W WLAN access point, B bridge, R router, S station) and, when parsed
+// from "show lldp neighbors detail", its LLDP-MED device type and system description.
+// The codes are separated by commas on IOS, e.g. "B,R", and run together on NX-OS, e.g.
+// "BR".
+func ClassifyLldpNeighbor(neighbor LldpNeighbor) DeviceKind {
+	capability := strings.ReplaceAll(neighbor.Capability, " ", "")
+	codes := strings.Split(capability, ",")
+	if !strings.Contains(capability, ",") {
+		codes = strings.Split(capability, "")
+	}
+	has := func(code string) bool { return slices.Contains(codes, code) }
+
+	switch {
+	// Class III endpoints are communication devices, i.e. IP phones.
+	case has("T") || strings.Contains(neighbor.MedDeviceType, "Class III"):
+		return DevicePhone
+	case containsAnyFold(neighbor.SystemDescription, cameraKeywords):
+		return DeviceCamera
+	case has("W"):
+		return DeviceAccessPoint
+	case has("B") || strings.Contains(neighbor.MedDeviceType, "Network Connectivity"):
+		return DeviceSwitch
+	case has("R"):
+		return DeviceRouter
+	case has("S") || strings.Contains(neighbor.MedDeviceType, "Endpoint"):
+		return DeviceHost
+	}
+	return DeviceUnknown
+}
+
+func containsAnyFold(s string, keywords []string) bool {
+	s = strings.ToLower(s)
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
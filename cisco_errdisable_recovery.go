@@ -0,0 +1,156 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotErrDisabled is returned by Recover_errdisabled when the interface is not
+	// err-disabled; nothing is changed.
+	ErrNotErrDisabled = errors.New("interface is not err-disabled")
+	// ErrNotRecovered is returned by Recover_errdisabled when the interface was bounced
+	// but did not come back connected in time.
+	ErrNotRecovered = errors.New("interface did not come back connected")
+)
+
+// DefaultRecoveryTimeout is how long Recover_errdisabled waits for the interface to
+// come back connected when RecoveryOptions.Timeout is not set. Spanning tree alone
+// takes 30 seconds on ports without portfast.
+const DefaultRecoveryTimeout = 45 * time.Second
+
+// recoveryPollInterval is how often Recover_errdisabled checks the interface status.
+const recoveryPollInterval = 3 * time.Second
+
+// RecoveryOptions tune Recover_errdisabled.
+type RecoveryOptions struct {
+	// ClearPortSecurity clears the sticky and dynamic secure MAC addresses of the
+	// interface before the bounce when it was err-disabled by a port-security
+	// violation, so the device now on the port can be learned.
+	ClearPortSecurity bool
+
+	// Timeout is how long to wait for the interface to come back connected;
+	// DefaultRecoveryTimeout when zero.
+	Timeout time.Duration
+}
+
+// RecoveryReport describes what Recover_errdisabled did.
+type RecoveryReport struct {
+	Switch              string
+	Interface           string
+	Cause               string // Why the interface was err-disabled, e.g., psecure-violation
+	ClearedPortSecurity bool
+	Output              string // Switch output of the clear and bounce commands
+	Status              string // Status after the bounce, e.g., connected, notconnect, err-disabled
+	Recovered           bool
+}
+
+// Recover_errdisabled runs the manual err-disable remediation on one interface: it
+// reads the err-disable cause, optionally clears port security, bounces the interface
+// with shutdown and no shutdown, and waits for it to come back connected. An
+// interface that is not err-disabled is left alone with ErrNotErrDisabled; one that
+// does not recover, e.g. because the violation recurs, yields ErrNotRecovered.
+func Recover_errdisabled(switch_hostname string, switch_interface string, opts RecoveryOptions) (RecoveryReport, error) {
+	return Recover_errdisabled_context(context.Background(), switch_hostname, switch_interface, opts)
+}
+
+// Recover_errdisabled_context is Recover_errdisabled with a context that cancels
+// connecting, the change and the wait for the interface to come back.
+func Recover_errdisabled_context(ctx context.Context, switch_hostname string, switch_interface string, opts RecoveryOptions) (RecoveryReport, error) {
+	report := RecoveryReport{Switch: switch_hostname, Interface: normalizeInterfaceName(switch_interface)}
+	if err := checkInterfaceParameter(switch_interface); err != nil {
+		return report, err
+	}
+
+	client, err := connectForConfigContext(ctx, switch_hostname)
+	if err != nil {
+		return report, err
+	}
+	defer client.Close()
+
+	outputString, err := client.RunCommandContext(ctx, "show interfaces status err-disabled")
+	if err != nil {
+		return report, err
+	}
+	port, found := findErrDisabledPort(ParseErrDisabledStatus(outputString), switch_interface)
+	if !found {
+		return report, fmt.Errorf("%s :: %s :: %w", switch_hostname, switch_interface, ErrNotErrDisabled)
+	}
+	report.Cause = port.Cause
+
+	var output strings.Builder
+	if opts.ClearPortSecurity && port.Cause == "psecure-violation" {
+		clearOutput, err := client.RunCommandsContext(ctx, []string{
+			"clear port-security sticky interface " + switch_interface,
+			"clear port-security dynamic interface " + switch_interface,
+		})
+		output.WriteString(clearOutput)
+		if err != nil {
+			report.Output = output.String()
+			return report, err
+		}
+		if strings.Contains(clearOutput, "% Invalid input") {
+			report.Output = output.String()
+			return report, fmt.Errorf("%s :: %s :: clearing port security was rejected", switch_hostname, switch_interface)
+		}
+		report.ClearedPortSecurity = true
+	}
+
+	bounceOutput, err := client.configureContext(ctx, "errdisable recovery", []string{"interface " + switch_interface, "shutdown", "no shutdown"}, client.commandTimeout(10*time.Second))
+	output.WriteString(bounceOutput)
+	report.Output = output.String()
+	if err != nil {
+		return report, err
+	}
+	client.logf(slog.LevelInfo, "%s :: %s :: Bounced err-disabled (%s) interface", switch_hostname, switch_interface, port.Cause)
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRecoveryTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		statusOutput, err := client.RunCommandContext(ctx, fmt.Sprintf("show interfaces %s status", switch_interface))
+		if err != nil {
+			return report, err
+		}
+		statuses, _ := ParseInterfaceStatus(statusOutput)
+		if status, ok := findInterfaceStatus(statuses, switch_interface); ok {
+			report.Status = status.Status
+		}
+		if report.Status == "connected" {
+			report.Recovered = true
+			client.logf(slog.LevelInfo, "Successfully recovered err-disabled interface %s on %s.", switch_interface, switch_hostname)
+			return report, nil
+		}
+		if time.Now().Add(recoveryPollInterval).After(deadline) {
+			break
+		}
+		select {
+		case <-time.After(recoveryPollInterval):
+		case <-ctx.Done():
+			return report, fmt.Errorf("%s :: %s :: waiting for recovery: %w", switch_hostname, switch_interface, ctx.Err())
+		}
+	}
+
+	status := report.Status
+	if status == "" {
+		status = "unknown"
+	}
+	return report, fmt.Errorf("%s :: %s :: %w after %s (status %s)", switch_hostname, switch_interface, ErrNotRecovered, timeout, status)
+}
+
+// findErrDisabledPort returns the err-disabled entry of an interface in any spelling.
+func findErrDisabledPort(ports []ErrDisabledPort, name string) (ErrDisabledPort, bool) {
+	name = shortInterfaceName(name)
+	for _, port := range ports {
+		if shortInterfaceName(port.Interface) == name {
+			return port, true
+		}
+	}
+	return ErrDisabledPort{}, false
+}
@@ -0,0 +1,22 @@
+package cisco
+
+import "errors"
+
+// Error classes matched with errors.Is, whatever operation failed, so callers can
+// decide what to do without looking at error text: retry on ErrTimeout or
+// ErrUnreachable, alert on ErrAuth, skip on ErrParse.
+var (
+	// ErrAuth matches connection failures where the switch rejected the credentials.
+	ErrAuth = errors.New("authentication failed")
+
+	// ErrTimeout matches commands that timed out and connections the switch did not
+	// answer in time.
+	ErrTimeout = errors.New("timed out")
+
+	// ErrUnreachable matches connection failures before any login: unknown hostname,
+	// no route, nothing listening or no answer.
+	ErrUnreachable = errors.New("switch unreachable")
+
+	// ErrParse matches ParseErrors, output that could not be parsed.
+	ErrParse = errors.New("output could not be parsed")
+)
@@ -0,0 +1,169 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// FactSelector names a group of facts GatherFacts can collect.
+type FactSelector string
+
+const (
+	FactsVersion          FactSelector = "version"
+	FactsInterfaces       FactSelector = "interfaces"
+	FactsInterfacesStatus FactSelector = "interfaces-status"
+	FactsVlans            FactSelector = "vlans"
+	FactsNeighbors        FactSelector = "neighbors" // CDP and LLDP
+	FactsMacAddressTable  FactSelector = "mac-address-table"
+	FactsPoe              FactSelector = "poe"
+)
+
+// AllFacts lists every FactSelector, in collection order.
+var AllFacts = []FactSelector{FactsVersion, FactsInterfaces, FactsInterfacesStatus, FactsVlans, FactsNeighbors, FactsMacAddressTable, FactsPoe}
+
+// factCommands are the show commands each selector runs.
+var factCommands = map[FactSelector][]string{
+	FactsVersion:          {"show version"},
+	FactsInterfaces:       {"show interface"},
+	FactsInterfacesStatus: {"show interface status"},
+	FactsVlans:            {"show vlan"},
+	FactsNeighbors:        {"show cdp neighbors", "show lldp neighbors"},
+	FactsMacAddressTable:  {"show mac address-table"},
+	FactsPoe:              {"show power inline"},
+}
+
+// DeviceFacts is everything GatherFacts collected from one switch. Facts that were not
+// selected, or whose command failed, are left empty.
+type DeviceFacts struct {
+	SwitchHostname string
+	CollectedAt    time.Time
+	Duration       time.Duration
+	ParserVersion  string
+	Selected       []FactSelector
+
+	Version          VersionInfo
+	Interfaces       []InterfaceDetails
+	InterfacesStatus []InterfaceStatus
+	Vlans            []VlanInfo
+	CdpNeighbors     []CdpNeighbor
+	LldpNeighbors    []LldpNeighbor
+	MacAddressTable  []MacAddressEntry
+	PowerModules     []PowerModuleInfo
+	PowerInterfaces  []PowerInterfaceInfo
+}
+
+// Snapshot converts the facts to a DeviceSnapshot for the analysis functions and
+// EncodeSnapshot.
+func (f DeviceFacts) Snapshot() DeviceSnapshot {
+	snapshot := DeviceSnapshot{
+		SwitchHostname:   f.SwitchHostname,
+		CollectedAt:      f.CollectedAt,
+		ParserVersion:    f.ParserVersion,
+		Interfaces:       f.Interfaces,
+		InterfacesStatus: f.InterfacesStatus,
+		MacAddressTable:  f.MacAddressTable,
+		Vlans:            f.Vlans,
+		PowerModules:     f.PowerModules,
+		PowerInterfaces:  f.PowerInterfaces,
+		CdpNeighbors:     f.CdpNeighbors,
+		LldpNeighbors:    f.LldpNeighbors,
+	}
+	if slices.Contains(f.Selected, FactsVersion) {
+		snapshot.Version = f.Version.Map()
+	}
+	return snapshot
+}
+
+// GatherFacts collects the selected facts from a switch over one connection, running
+// the commands concurrently. Without selectors every fact is collected. A failing
+// parser doesn't stop the others: the facts gathered are returned together with the
+// joined errors.
+func GatherFacts(switch_hostname string, selectors ...FactSelector) (DeviceFacts, error) {
+	return GatherFactsContext(context.Background(), switch_hostname, selectors...)
+}
+
+// GatherFactsContext is GatherFacts with a context that cancels the commands.
+func GatherFactsContext(ctx context.Context, switch_hostname string, selectors ...FactSelector) (DeviceFacts, error) {
+	if len(selectors) == 0 {
+		selectors = AllFacts
+	}
+	facts := DeviceFacts{SwitchHostname: switch_hostname, CollectedAt: time.Now(), ParserVersion: ParserVersion, Selected: selectors}
+
+	var commands []string
+	for _, selector := range selectors {
+		selectorCommands, ok := factCommands[selector]
+		if !ok {
+			return facts, fmt.Errorf("unknown fact selector %q", selector)
+		}
+		commands = append(commands, selectorCommands...)
+	}
+
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return facts, err
+	}
+	defer client.Close()
+
+	// A command the switch does not know fails to parse below, with the others kept.
+	outputs, err := client.RunCommandsConcurrentlyContext(ctx, commands)
+	if err != nil && !onlyCommandErrors(err) {
+		return facts, err
+	}
+
+	var errs []error
+	report := func(command string, rawOutput string, records int, err error, start time.Time) {
+		if err = reportParse(switch_hostname, command, rawOutput, records, start, err); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	i := 0
+	for _, selector := range selectors {
+		start := time.Now()
+		switch selector {
+		case FactsVersion:
+			facts.Version, err = ParseVersionStruct(outputs[i])
+			report("show version", outputs[i], 1, err, start)
+		case FactsInterfaces:
+			facts.Interfaces, err = ParseInterfaces(outputs[i])
+			for j := range facts.Interfaces {
+				facts.Interfaces[j].Interface = normalizeInterfaceName(facts.Interfaces[j].Interface)
+			}
+			report("show interface", outputs[i], len(facts.Interfaces), err, start)
+		case FactsInterfacesStatus:
+			facts.InterfacesStatus, err = ParseInterfaceStatus(outputs[i])
+			report("show interface status", outputs[i], len(facts.InterfacesStatus), err, start)
+		case FactsVlans:
+			facts.Vlans, err = ParseVlanInfo(outputs[i])
+			report("show vlan", outputs[i], len(facts.Vlans), err, start)
+		case FactsNeighbors:
+			facts.CdpNeighbors, err = ParseCdpNeighbors(outputs[i])
+			for j := range facts.CdpNeighbors {
+				facts.CdpNeighbors[j].Interface = normalizeInterfaceName(facts.CdpNeighbors[j].Interface)
+				facts.CdpNeighbors[j].NeighborInterface = normalizeInterfaceName(facts.CdpNeighbors[j].NeighborInterface)
+			}
+			report("show cdp neighbors", outputs[i], len(facts.CdpNeighbors), err, start)
+
+			start = time.Now()
+			facts.LldpNeighbors, err = ParseLldpNeighbors(outputs[i+1])
+			for j := range facts.LldpNeighbors {
+				facts.LldpNeighbors[j].Interface = normalizeInterfaceName(facts.LldpNeighbors[j].Interface)
+				facts.LldpNeighbors[j].NeighborInterface = normalizeInterfaceName(facts.LldpNeighbors[j].NeighborInterface)
+			}
+			report("show lldp neighbors", outputs[i+1], len(facts.LldpNeighbors), err, start)
+		case FactsMacAddressTable:
+			facts.MacAddressTable, err = ParseMacAddressTable(outputs[i])
+			report("show mac address-table", outputs[i], len(facts.MacAddressTable), err, start)
+		case FactsPoe:
+			facts.PowerModules, facts.PowerInterfaces, err = ParsePowerInline(outputs[i])
+			report("show power inline", outputs[i], len(facts.PowerModules)+len(facts.PowerInterfaces), err, start)
+		}
+		i += len(factCommands[selector])
+	}
+
+	facts.Duration = time.Since(facts.CollectedAt)
+	return facts, errors.Join(errs...)
+}
@@ -0,0 +1,861 @@
+package cisco
+
+// Fixtures holds representative raw outputs for every supported show command,
+// keyed by the command the Show_* functions send. They are exported so parser
+// benchmarks (see BenchmarkParser) and applications can exercise the parsers
+// without a switch.
+var Fixtures = map[string]string{
+	"show running-config": `switch01#show running-config
+Building configuration...
+
+Current configuration : 4123 bytes
+!
+version 15.2
+hostname switch01
+!
+interface GigabitEthernet1/0/1
+ description Uplink to core01
+ switchport mode trunk
+ switchport trunk allowed vlan 10,20,30
+!
+interface GigabitEthernet1/0/2
+ description Workstation 2-101
+ switchport access vlan 10
+ switchport mode access
+ spanning-tree portfast
+!
+interface GigabitEthernet1/0/3
+ switchport access vlan 20
+ switchport voice vlan 30
+ switchport mode access
+ shutdown
+!
+interface Vlan10
+ ip address 10.0.10.2 255.255.255.0
+!
+end
+
+switch01#exit
+`,
+
+	"show version": `switch01#show version
+Cisco IOS Software, C2960X Software (C2960X-UNIVERSALK9-M), Version 15.2(7)E8, RELEASE SOFTWARE (fc3)
+Technical Support: http://www.cisco.com/techsupport
+Copyright (c) 1986-2023 by Cisco Systems, Inc.
+
+ROM: Bootstrap program is C2960X boot loader
+BOOTLDR: C2960X Boot Loader (C2960X-HBOOT-M) Version 15.2(7r)E, RELEASE SOFTWARE (fc1)
+
+switch01 uptime is 1 year, 12 weeks, 3 days, 4 hours, 10 minutes
+System returned to ROM by power-on
+System restarted at 08:15:22 EST Mon Mar 6 2023
+System image file is "flash:c2960x-universalk9-mz.152-7.E8.bin"
+Last reload reason: power-on
+
+cisco WS-C2960X-48FPD-L (APM86XXX) processor (revision V02) with 524288K bytes of memory.
+Processor board ID FOC1234X5YZ
+Last reset from power-on
+1 Virtual Ethernet interface
+52 Gigabit Ethernet interfaces
+
+System serial number            : FOC1234X5YZ
+Model number                    : WS-C2960X-48FPD-L
+
+switch01#exit
+`,
+
+	"show interface": `switch01#show interface
+GigabitEthernet1/0/1 is up, line protocol is up (connected)
+  Hardware is Gigabit Ethernet, address is 00a1.b2c3.d401 (bia 00a1.b2c3.d401)
+  Description: Uplink to core01
+  MTU 1500 bytes, BW 1000000 Kbit/sec, DLY 10 usec,
+     reliability 255/255, txload 3/255, rxload 1/255
+  Encapsulation ARPA, loopback not set
+  Keepalive set (10 sec)
+  Full-duplex, 1000Mb/s, media type is 10/100/1000BaseTX
+  input flow-control is off, output flow-control is unsupported
+  ARP type: ARPA, ARP Timeout 04:00:00
+  Last input 00:00:01, output 00:00:00, output hang never
+  Last clearing of "show interface" counters never
+  Input queue: 0/75/0/0 (size/max/drops/flushes); Total output drops: 12
+  Queueing strategy: fifo
+  Output queue: 0/40 (size/max)
+  5 minute input rate 4582000 bits/sec, 812 packets/sec
+  5 minute output rate 12430000 bits/sec, 1504 packets/sec
+     912345678 packets input, 734567890123 bytes, 0 no buffer
+     Received 1234567 broadcasts (987654 multicasts)
+     0 runts, 0 giants, 0 throttles
+     3 input errors, 2 CRC, 1 frame, 0 overrun, 0 ignored
+     0 watchdog, 987654 multicast, 0 pause input
+     0 input packets with dribble condition detected
+     1234567890 packets output, 1098765432109 bytes, 0 underruns
+     0 output errors, 0 collisions, 1 interface resets
+     0 unknown protocol drops
+     0 babbles, 0 late collision, 0 deferred
+     0 lost carrier, 0 no carrier, 0 pause output
+     0 output buffer failures, 0 output buffers swapped out
+GigabitEthernet1/0/2 is down, line protocol is down (notconnect)
+  Hardware is Gigabit Ethernet, address is 00a1.b2c3.d402 (bia 00a1.b2c3.d402)
+  Description: Workstation 2-101
+  MTU 1500 bytes, BW 10000 Kbit/sec, DLY 1000 usec,
+     reliability 255/255, txload 1/255, rxload 1/255
+  Encapsulation ARPA, loopback not set
+  Keepalive set (10 sec)
+  Auto-duplex, Auto-speed, media type is 10/100/1000BaseTX
+  input flow-control is off, output flow-control is unsupported
+  ARP type: ARPA, ARP Timeout 04:00:00
+  Last input never, output 3w2d, output hang never
+  Last clearing of "show interface" counters never
+  Input queue: 0/75/0/0 (size/max/drops/flushes); Total output drops: 0
+  Queueing strategy: fifo
+  Output queue: 0/40 (size/max)
+  5 minute input rate 0 bits/sec, 0 packets/sec
+  5 minute output rate 0 bits/sec, 0 packets/sec
+     0 packets input, 0 bytes, 0 no buffer
+     Received 0 broadcasts (0 multicasts)
+     0 runts, 0 giants, 0 throttles
+     0 input errors, 0 CRC, 0 frame, 0 overrun, 0 ignored
+     0 watchdog, 0 multicast, 0 pause input
+     0 input packets with dribble condition detected
+     48211 packets output, 4123456 bytes, 0 underruns
+     0 output errors, 0 collisions, 2 interface resets
+     0 unknown protocol drops
+     0 babbles, 0 late collision, 0 deferred
+     0 lost carrier, 0 no carrier, 0 pause output
+     0 output buffer failures, 0 output buffers swapped out
+Vlan10 is up, line protocol is up
+  Hardware is EtherSVI, address is 00a1.b2c3.d440 (bia 00a1.b2c3.d440)
+  Internet address is 10.0.10.2/24
+  MTU 1500 bytes, BW 1000000 Kbit/sec, DLY 10 usec,
+     reliability 255/255, txload 1/255, rxload 1/255
+  Encapsulation ARPA, loopback not set
+  Last input 00:00:00, output 00:00:00, output hang never
+  Queueing strategy: fifo
+  5 minute input rate 2000 bits/sec, 3 packets/sec
+  5 minute output rate 1000 bits/sec, 1 packets/sec
+     4567890 packets input, 345678901 bytes, 0 no buffer
+     0 input errors, 0 CRC, 0 frame, 0 overrun, 0 ignored
+     1234567 packets output, 98765432 bytes, 0 underruns
+     0 output errors, 0 interface resets
+switch01#exit
+`,
+
+	"show interface status": `switch01#show interface status
+
+Port      Name               Status       Vlan       Duplex  Speed Type
+Gi1/0/1   Uplink to core01   connected    trunk        a-full a-1000 10/100/1000BaseTX
+Gi1/0/2   Workstation 2-101  notconnect   10             auto   auto 10/100/1000BaseTX
+Gi1/0/3                      disabled     20             auto   auto 10/100/1000BaseTX
+Gi1/0/4   Printer 2-110      err-disabled 10             auto   auto 10/100/1000BaseTX
+Gi1/0/5   AP 2-East          connected    30           a-full a-1000 10/100/1000BaseTX
+Te1/0/1                      notconnect   1              full    10G Not Present
+switch01#exit
+`,
+
+	"show mac address-table": `switch01#show mac address-table
+          Mac Address Table
+-------------------------------------------
+
+Vlan    Mac Address       Type        Ports
+----    -----------       --------    -----
+ All    0100.0ccc.cccc    STATIC      CPU
+ All    0100.0ccc.cccd    STATIC      CPU
+  10    0011.2233.4401    DYNAMIC     Gi1/0/1
+  10    0050.56a1.0b02    DYNAMIC     Gi1/0/2
+  20    3c52.82aa.bb03    DYNAMIC     Gi1/0/1
+  30    00a2.ee11.2204    DYNAMIC     Gi1/0/5
+  30    0004.f2ab.cd05    STATIC      Gi1/0/3
+Total Mac Addresses for this criterion: 7
+switch01#exit
+`,
+
+	"show vlan": `switch01#show vlan
+
+VLAN Name                             Status    Ports
+---- -------------------------------- --------- -------------------------------
+1    default                          active    Te1/0/1, Te1/0/2
+10   DATA                             active    Gi1/0/2, Gi1/0/4, Gi1/0/6
+                                                Gi1/0/7, Gi1/0/8
+20   PRINTERS                         active    Gi1/0/3
+30   VOICE                            active    Gi1/0/5
+99   MGMT                             act/lshut
+1002 fddi-default                     act/unsup
+1003 token-ring-default               act/unsup
+
+VLAN Type  SAID       MTU   Parent RingNo BridgeNo Stp  BrdgMode Trans1 Trans2
+---- ----- ---------- ----- ------ ------ -------- ---- -------- ------ ------
+1    enet  100001     1500  -      -      -        -    -        0      0
+10   enet  100010     1500  -      -      -        -    -        0      0
+20   enet  100020     1500  -      -      -        -    -        0      0
+30   enet  100030     1500  -      -      -        -    -        0      0
+99   enet  100099     1500  -      -      -        -    -        0      0
+1002 fddi  101002     1500  -      -      -        -    -        0      0
+1003 tr    101003     1500  -      -      -        -    -        0      0
+
+Remote SPAN VLANs
+------------------------------------------------------------------------------
+
+
+Primary Secondary Type              Ports
+------- --------- ----------------- ------------------------------------------
+switch01#exit
+`,
+
+	"show power inline": `switch01#show power inline
+
+Module   Available     Used     Remaining
+          (Watts)     (Watts)    (Watts)
+------   ---------   --------   ---------
+1           740.0       45.3       694.7
+
+Interface Admin  Oper       Power   Device              Class Max
+                            (Watts)
+--------- ------ ---------- ------- ------------------- ----- ----
+Gi1/0/1   auto   off        0.0     n/a                 n/a   30.0
+Gi1/0/2   auto   off        0.0     n/a                 n/a   30.0
+Gi1/0/3   auto   on         6.3     IP Phone 8845       2     30.0
+Gi1/0/4   auto   off        0.0     n/a                 n/a   30.0
+Gi1/0/5   auto   on         15.4    AIR-AP2802I-B-K9    4     30.0
+Gi1/0/6   auto   on         23.6    Ieee PD             4     30.0
+--------- ------ ---------- ------- ------------------- ----- ----
+Totals:          3   on     45.3
+switch01#exit
+`,
+
+	"show cdp neighbors": `switch01#show cdp neighbors
+Capability Codes: R - Router, T - Trans Bridge, B - Source Route Bridge
+                  S - Switch, H - Host, I - IGMP, r - Repeater, P - Phone,
+                  D - Remote, C - CVTA, M - Two-port Mac Relay
+
+Device ID        Local Intrfce     Holdtme    Capability  Platform  Port ID
+core01.example.com
+                 Gig 1/0/1         163             R S I  WS-C6509- Gig 3/12
+SEP00A1B2C3D4E5  Gig 1/0/3         128              H P M IP Phone  Port 1
+AP2-East.example.com
+                 Gig 1/0/5         142              T B I AIR-AP280 Gig 0
+
+Total cdp entries displayed : 3
+switch01#exit
+`,
+
+	"show lldp neighbors": `switch01#show lldp neighbors
+Capability codes:
+    (R) Router, (B) Bridge, (T) Telephone, (C) DOCSIS Cable Device
+    (W) WLAN Access Point, (P) Repeater, (S) Station, (O) Other
+
+Device ID           Local Intf     Hold-time  Capability      Port ID
+core01.example.com  Gi1/0/1        120        B,R             Gi3/12
+SEP00A1B2C3D4E5     Gi1/0/3        180        B,T             00a1.b2c3.d4e5:P1
+AP2-East            Gi1/0/5        120        B,W             Gi0
+
+Total entries displayed: 3
+switch01#exit
+`,
+
+	"show interfaces status err-disabled": `switch01#show interfaces status err-disabled
+
+Port      Name               Status       Reason               Err-disabled Vlans
+Gi1/0/4   Printer 2-110      err-disabled psecure-violation
+Gi1/0/9                      err-disabled bpduguard
+switch01#exit
+`,
+
+	"show spanning-tree blockedports": `switch01#show spanning-tree blockedports
+
+Name                 Blocked Interfaces List
+-------------------- ------------------------------------
+VLAN0010             Gi1/0/24, Po1
+VLAN0020             Gi1/0/24
+
+Number of blocked ports (segments) in the system : 3
+
+switch01#exit
+`,
+
+	"show spanning-tree inconsistentports": `switch01#show spanning-tree inconsistentports
+
+Name                 Interface                Inconsistency
+-------------------- ------------------------ ------------------
+VLAN0010             GigabitEthernet1/0/24    Root Inconsistent
+VLAN0020             GigabitEthernet1/0/23    Loop Inconsistent
+
+Number of inconsistent ports (segments) in the system : 2
+
+switch01#exit
+`,
+
+	"show ip dhcp binding": `switch01#show ip dhcp binding
+Bindings from all pools not associated with VRF:
+IP address          Client-ID/              Lease expiration        Type       State      Interface
+                    Hardware address/
+                    User name
+10.0.10.11          0100.5056.a10b.02       Oct 17 2026 10:15 AM    Automatic  Active     Vlan10
+10.0.10.12          0100.1122.3344.55       Infinite                Manual     Active     Vlan10
+10.0.20.40          0063.6973.636f.2d30.    Oct 18 2026 08:01 AM    Automatic  Active     Vlan20
+switch01#exit
+`,
+
+	"show tacacs": `switch01#show tacacs
+
+Tacacs+ Server -  public  :
+          Server name: ISE-1
+       Server address: 10.1.1.10
+          Server port: 49
+         Socket opens:       1234
+        Socket closes:       1234
+        Socket aborts:          0
+        Socket errors:          0
+      Socket Timeouts:          0
+Failed Connect Attempts:          0
+   Total Packets Sent:       3702
+   Total Packets Recv:       3702
+        Server Status: Alive
+
+Tacacs+ Server -  public  :
+          Server name: ISE-2
+       Server address: 10.1.2.10
+          Server port: 49
+         Socket opens:         12
+        Socket closes:         10
+        Socket aborts:          2
+        Socket errors:          1
+      Socket Timeouts:          7
+Failed Connect Attempts:          7
+   Total Packets Sent:         36
+   Total Packets Recv:         29
+        Server Status: Dead
+switch01#exit
+`,
+
+	"show crypto pki certificates": `switch01#show crypto pki certificates
+Certificate
+  Status: Available
+  Certificate Serial Number (hex): 3F00000012AB
+  Certificate Usage: General Purpose
+  Issuer:
+    cn=Corp Issuing CA
+    dc=corp
+  Subject:
+    Name: switch01.example.com
+    cn=switch01.example.com
+  Validity Date:
+    start date: 09:00:00 UTC Jan 1 2025
+    end   date: 09:00:00 UTC Jan 1 2027
+  Associated Trustpoints: TP-ISE
+
+CA Certificate
+  Status: Available
+  Certificate Serial Number (hex): 01
+  Certificate Usage: Signature
+  Issuer:
+    cn=Corp Root CA
+  Subject:
+    cn=Corp Root CA
+  Validity Date:
+    start date: 00:00:00 UTC Jan 1 2020
+    end   date: 00:00:00 UTC Jan 1 2040
+  Associated Trustpoints: TP-ISE
+
+Router Self-Signed Certificate
+  Status: Available
+  Certificate Serial Number (hex): 01
+  Certificate Usage: General Purpose
+  Issuer:
+    cn=IOS-Self-Signed-Certificate-1234567890
+  Subject:
+    Name: IOS-Self-Signed-Certificate-1234567890
+    cn=IOS-Self-Signed-Certificate-1234567890
+  Validity Date:
+    start date: 12:00:00 UTC Mar 6 2023
+    end   date: 00:00:00 UTC Jan 1 2030
+  Associated Trustpoints: TP-self-signed-1234567890
+switch01#exit
+`,
+
+	"show ip ssh": `switch01#show ip ssh
+SSH Enabled - version 1.99
+Authentication methods:publickey,keyboard-interactive,password
+Authentication Publickey Algorithms:x509v3-ssh-rsa,ssh-rsa
+Hostkey Algorithms:x509v3-ssh-rsa,ssh-rsa
+Encryption Algorithms:aes128-ctr,aes192-ctr,aes256-ctr,aes128-cbc
+MAC Algorithms:hmac-sha2-256,hmac-sha2-512,hmac-sha1,hmac-sha1-96
+KEX Algorithms:diffie-hellman-group-exchange-sha1,diffie-hellman-group14-sha1,diffie-hellman-group1-sha1
+Authentication timeout: 120 secs; Authentication retries: 3
+Minimum expected Diffie Hellman key size : 1024 bits
+IOS Keys in SECSH format(ssh-rsa, base64 encoded): TP-self-signed-1234567890
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQDcxnDuTGxf7PILPnVLtfiVOD2WKRfMhHZ6BzP0nniDOcEfhIJBW44KdfQ5YofOnvTRuDBVplK3jkAKDnrwgSjeSoBqz5aZThUYZk0HHkjoIYHK5MEh3Z2Tf5w3+/JLgryDT3bZUfFD7H42ncrNT2nWnvSrZ52ogPkwrrbVuWgxsQ==
+switch01#exit
+`,
+
+	"show line": `switch01#show line
+   Tty Line Typ     Tx/Rx    A Modem  Roty AccO AccI  Uses  Noise Overruns  Int
+*    0    0 CTY              -    -      -    -    -     0      0    0/0      -
+     1    1 AUX   9600/9600  -    -      -    -    -     0      0    0/0      -
+*    2    2 VTY              -    -      -    -  MGMT    12      0    0/0      -
+     3    3 VTY              -    -      -    -  MGMT     3      0    0/0      -
+     4    4 VTY              -    -      -    -  MGMT     0      0    0/0      -
+     5    5 VTY              -    -      -    -  MGMT     0      0    0/0      -
+     6    6 VTY              -    -      -    -  MGMT     0      0    0/0      -
+     7    7 VTY              -    -      -    -    -     0      0    0/0      -
+
+Line(s) not in async mode -or- with no hardware support:
+8-17
+
+switch01#exit
+`,
+
+	"show running-config | section ^line": `switch01#show running-config | section ^line
+line con 0
+ exec-timeout 15 0
+ logging synchronous
+line aux 0
+line vty 0 4
+ access-class MGMT in
+ exec-timeout 5 0
+ transport input ssh
+line vty 5
+ transport input telnet ssh
+switch01#exit
+`,
+
+	"show clock detail": `switch01#show clock detail
+10:15:32.123 EDT Fri Oct 16 2026
+Time source is NTP
+switch01#exit
+`,
+
+	"show spanning-tree root": `switch01#show spanning-tree root
+
+                                        Root    Hello Max Fwd
+Vlan                   Root ID          Cost    Time  Age Dly  Root Port
+---------------- -------------------- --------- ----- --- ---  ------------
+VLAN0001         32769 0011.2233.4455         4    2   20  15  Gi1/0/1
+VLAN0010         24586 0011.2233.4455         4    2   20  15  Gi1/0/1
+VLAN0020         24596 00a1.b2c3.d400         0    2   20  15
+VLAN0030         24606 0011.2233.4455         4    2   20  15  Gi1/0/1
+switch01#exit
+`,
+
+	"show ap summary": `switch01#show ap summary
+Number of APs: 3
+
+CC = Country Code
+RD = Regulatory Domain
+
+AP Name                          Slots AP Model             Ethernet MAC   Radio MAC      CC   RD   IP Address                                State        Location
+-------------------------------------------------------------------------------------------------------------------------------------------------------------------------------
+AP-Floor1-East                   2     C9120AXI-B           a4b4.39c1.0a10 a4b4.39d2.5e00 US   -B   10.20.30.11                               Registered   Floor 1 East Wing
+AP-Floor1-West                   2     C9120AXI-B           a4b4.39c1.0b20 a4b4.39d2.6f00 US   -B   10.20.30.12                               Registered   default location
+AP-Lobby                         3     C9130AXI-B           0c75.bd11.2230 0c75.bd4a.8c20 US   -B   10.20.30.13                               Downloading  Lobby
+switch01#exit
+`,
+
+	"show wlan summary": `switch01#show wlan summary
+Number of WLANs: 3
+
+ID   Profile Name                     SSID                             Status Security
+-------------------------------------------------------------------------------------------------------------------------------------
+1    CORP-WLAN                        CORP                             UP     [WPA2][802.1x][AES]
+2    GUEST-WLAN                       Guest                            UP     [open],MAC Filtering,[Web Auth]
+17   IOT-PSK                          IoT Devices                      DOWN   [WPA2][PSK][AES]
+switch01#exit
+`,
+
+	"show wireless client summary": `switch01#show wireless client summary
+Number of Clients: 3
+
+MAC Address    AP Name                                        Type ID   State             Protocol Method     Role
+-------------------------------------------------------------------------------------------------------------------------
+0c4d.e9a1.1122 AP-Floor1-East                                 WLAN 1    Run               11ax(5)  Dot1x      Local
+3c22.fb33.4455 AP-Lobby                                       WLAN 2    Webauth Pending   11n(2.4) None       Local
+f0d4.e2aa.bbcc AP-Floor1-West                                 WLAN 17   Run               11ac     PSK        Local
+
+Number of Excluded Clients: 0
+switch01#exit
+`,
+
+	"show failover": `switch01#show failover
+Failover On
+Failover unit Primary
+Failover LAN Interface: folink GigabitEthernet0/3 (up)
+Reconnect timeout 0:00:00
+Unit Poll frequency 1 seconds, holdtime 15 seconds
+Interface Poll frequency 5 seconds, holdtime 25 seconds
+Interface Policy 1
+Monitored Interfaces 3 of 1049 maximum
+MAC Address Move Notification Interval not set
+failover replication http
+Version: Ours 9.16(4), Mate 9.16(4)
+Serial Number: Ours JAD2235ABCD, Mate JAD2235WXYZ
+Last Failover at: 14:22:08 UTC Mar 3 2026
+        This host: Primary - Active
+                Active time: 19823451 (sec)
+                slot 0: ASA5516 hw/sw rev (3.0/9.16(4)) status (Up Sys)
+                  Interface outside (203.0.113.2): Normal (Monitored)
+                  Interface inside (10.1.0.1): Normal (Monitored)
+                  Interface management (192.168.1.1): Normal (Waiting)
+        Other host: Secondary - Standby Ready
+                Active time: 0 (sec)
+                slot 0: ASA5516 hw/sw rev (3.0/9.16(4)) status (Up Sys)
+                  Interface outside (203.0.113.3): Normal (Monitored)
+                  Interface inside (10.1.0.2): Normal (Monitored)
+                  Interface management (192.168.1.2): Normal (Waiting)
+
+Stateful Failover Logical Update Statistics
+        Link : folink GigabitEthernet0/3 (up)
+        Stateful Obj    xmit       xerr       rcv        rerr
+        General         2718734    0          104523     0
+switch01#exit
+`,
+
+	"show conn count": `switch01#show conn count
+54 in use, 2310 most used
+switch01#exit
+`,
+
+	"show lldp neighbors detail": `switch01#show lldp neighbors detail
+------------------------------------------------
+Local Intf: Gi1/0/1
+Chassis id: 0022.90ab.cd00
+Port id: Gi3/12
+Port Description: GigabitEthernet3/12
+System Name: core01.example.com
+
+System Description: 
+Cisco IOS Software, s72033_rp Software (s72033_rp-ADVENTERPRISEK9-M), Version 15.1(2)SY11, RELEASE SOFTWARE (fc2)
+Technical Support: http://www.cisco.com/techsupport
+Copyright (c) 1986-2018 by Cisco Systems, Inc.
+
+Time remaining: 113 seconds
+System Capabilities: B,R
+Enabled Capabilities: B,R
+Management Addresses:
+    IP: 10.1.0.1
+Auto Negotiation - not supported
+Physical media capabilities - not advertised
+Media Attachment Unit type - not advertised
+Vlan ID: - not advertised
+
+------------------------------------------------
+Local Intf: Gi1/0/3
+Chassis id: 10.1.30.25
+Port id: 00A1B2C3D4E5:P1
+Port Description: SW PORT
+System Name: SEP00A1B2C3D4E5
+
+System Description: 
+Cisco IP Phone 8845, V1, sip88xx.14-1-1-0001-125
+
+Time remaining: 163 seconds
+System Capabilities: B,T
+Enabled Capabilities: B,T
+Management Addresses:
+    IP: 10.1.30.25
+Auto Negotiation - supported, enabled
+Physical media capabilities:
+    1000baseT(FD)
+    100base-TX(FD)
+Media Attachment Unit type: 30
+Vlan ID: - not advertised
+
+MED Information:
+
+    MED Codes:
+          (NP) Network Policy, (LI) Location Identification
+          (PS) Power Source Entity, (PD) Power Device
+          (IN) Inventory
+
+    H/W revision: 12
+    F/W revision: sboot88xx.BE-01-020.sbn
+    S/W revision: sip88xx.14-1-1-0001-125
+    Serial number: FCH1234ABCD
+    Manufacturer: Cisco Systems, Inc.
+    Model: CP-8845
+    Capabilities: NP, PD, IN
+    Device type: Endpoint Class III
+    Network Policy(Voice): VLAN 30, tagged, Layer-2 priority: 5, DSCP: 46
+    Network Policy(Voice Signal): VLAN 30, tagged, Layer-2 priority: 4, DSCP: 32
+    PD device, Power source: Unknown, Power Priority: High, Wattage: 6.3
+    Location - not advertised
+
+------------------------------------------------
+Local Intf: Gi1/0/7
+Chassis id: accc.8e12.3456
+Port id: accc.8e12.3456
+Port Description: eth0
+System Name - not advertised
+
+System Description: 
+AXIS P3245-V Network Camera 10.12.153
+
+Time remaining: 101 seconds
+System Capabilities: S
+Enabled Capabilities: S
+Management Addresses:
+    IP: 10.1.40.17
+Auto Negotiation - supported, enabled
+Physical media capabilities:
+    100base-TX(FD)
+Media Attachment Unit type: 16
+Vlan ID: - not advertised
+
+MED Information:
+
+    MED Codes:
+          (NP) Network Policy, (LI) Location Identification
+          (PS) Power Source Entity, (PD) Power Device
+          (IN) Inventory
+
+    Capabilities: NP, PD, IN
+    Device type: Endpoint Class I
+    PD device, Power source: Unknown, Power Priority: Unknown, Wattage: 6.5
+    Location - not advertised
+
+
+Total entries displayed: 3
+switch01#exit
+`,
+
+	"show ip dhcp snooping statistics detail": `switch01#show ip dhcp snooping statistics detail
+ Packets Processed by DHCP Snooping                    = 18422
+ Packets Dropped Because
+   IDB not known                                       = 0
+   Queue full                                          = 0
+   Interface is in errdisabled                         = 0
+   Rate limit exceeded                                 = 0
+   Received on untrusted ports                         = 14
+   Nonzero giaddr                                      = 0
+   Source mac not equal to chaddr                      = 2
+   No binding entry                                    = 5
+   Insertion of opt82 fail                             = 0
+   Unknown packet                                      = 0
+   Interface Down                                      = 0
+   Unknown output interface                            = 0
+   Misdirected Packets                                 = 0
+   Packets with Invalid Size                           = 0
+   Packets with Invalid Option                         = 0
+switch01#exit
+`,
+
+	"show ip arp inspection statistics": `switch01#show ip arp inspection statistics
+
+ Vlan      Forwarded        Dropped     DHCP Drops      ACL Drops
+ ----      ---------        -------     ----------      ---------
+   10           9321             37             37              0
+   20           4410              0              0              0
+   30           1288              0              0              0
+
+ Vlan   DHCP Permits    ACL Permits  Probe Permits   Source MAC Failures
+ ----   ------------    -----------  -------------   -------------------
+   10           9102              0            219                     0
+   20           4388              0             22                     0
+   30           1270              0             18                     0
+
+ Vlan   Dest MAC Failures   IP Validation Failures   Invalid Protocol Data
+ ----   -----------------   ----------------------   ---------------------
+   10                   0                        0                       0
+   20                   0                        0                       0
+   30                   0                        0                       0
+switch01#exit
+`,
+
+	"show ip arp inspection interfaces": `switch01#show ip arp inspection interfaces
+
+ Interface        Trust State     Rate (pps)    Burst Interval
+ ---------------  -----------     ----------    --------------
+ Gi1/0/1          Untrusted               15                 1
+ Gi1/0/2          Untrusted               15                 1
+ Gi1/0/3          Trusted               None               N/A
+ Gi1/0/4          Untrusted               15                 1
+ Gi1/0/5          Untrusted               15                 1
+switch01#exit
+`,
+
+	"show interfaces description": `switch01#show interfaces description
+Interface                      Status         Protocol Description
+Vl1                            admin down     down
+Vl10                           up             up       Users
+Gi0/0                          admin down     down
+Gi1/0/1                        up             up       Uplink to core01 Po1 member
+Gi1/0/2                        down           down     Workstation 2-101
+Gi1/0/3                        admin down     down
+Gi1/0/4                        down           down     Printer 2-110
+Gi1/0/5                        up             up       AP 2-East
+Te1/0/1                        down           down
+switch01#exit
+`,
+
+	"show ip interface brief": `switch01#show ip interface brief
+Interface              IP-Address      OK? Method Status                Protocol
+Vlan1                  unassigned      YES NVRAM  administratively down down
+Vlan10                 10.0.10.2       YES NVRAM  up                    up
+GigabitEthernet0/0     unassigned      YES unset  administratively down down
+GigabitEthernet1/0/1   unassigned      YES unset  up                    up
+GigabitEthernet1/0/2   unassigned      YES unset  down                  down
+GigabitEthernet1/0/3   unassigned      YES unset  administratively down down
+GigabitEthernet1/0/4   unassigned      YES unset  down                  down
+GigabitEthernet1/0/5   unassigned      YES unset  up                    up
+TenGigabitEthernet1/0/1 unassigned     YES unset  down                  down
+switch01#exit
+`,
+
+	"show ip route": `switch01#show ip route
+Codes: L - local, C - connected, S - static, R - RIP, M - mobile, B - BGP
+       D - EIGRP, EX - EIGRP external, O - OSPF, IA - OSPF inter area
+       N1 - OSPF NSSA external type 1, N2 - OSPF NSSA external type 2
+       E1 - OSPF external type 1, E2 - OSPF external type 2
+       i - IS-IS, su - IS-IS summary, L1 - IS-IS level-1, L2 - IS-IS level-2
+       ia - IS-IS inter area, * - candidate default, U - per-user static route
+       o - ODR, P - periodic downloaded static route, H - NHRP, l - LISP
+       a - application route
+       + - replicated route, % - next hop override, p - overrides from PfR
+
+Gateway of last resort is 10.0.10.1 to network 0.0.0.0
+
+S*    0.0.0.0/0 [1/0] via 10.0.10.1
+      10.0.0.0/8 is variably subnetted, 2 subnets, 2 masks
+C        10.0.10.0/24 is directly connected, Vlan10
+L        10.0.10.2/32 is directly connected, Vlan10
+switch01#exit
+`,
+
+	"show ip arp": `switch01#show ip arp
+Protocol  Address          Age (min)  Hardware Addr   Type   Interface
+Internet  10.0.10.1               3   00a1.b2c3.e010  ARPA   Vlan10
+Internet  10.0.10.2               -   00a1.b2c3.d440  ARPA   Vlan10
+Internet  10.0.10.25             12   0011.2233.4401  ARPA   Vlan10
+Internet  10.0.10.31              0   0050.56a1.0b02  ARPA   Vlan10
+Internet  10.0.10.99              0   Incomplete      ARPA
+switch01#exit
+`,
+
+	"show inventory": `switch01#show inventory
+NAME: "1", DESCR: "WS-C2960X-48FPD-L"
+PID: WS-C2960X-48FPD-L , VID: V05  , SN: FOC1234X5YZ
+
+NAME: "Switch 1 - Power Supply 0", DESCR: "FRU Power Supply"
+PID: PWR-C2-1025WAC    , VID: V02  , SN: DCB2231H0QP
+
+NAME: "Switch 1 - FlexStackPlus Module", DESCR: "Stacking Module"
+PID: C2960X-STACK      , VID: V02  , SN: FOC21337N2K
+
+NAME: "GigabitEthernet1/0/49", DESCR: "1000BaseSX SFP"
+PID: GLC-SX-MMD          , VID: V01  , SN: FNS21450ABC
+
+switch01#exit
+`,
+
+	"show env all": `switch01#show env all
+Switch 1 FAN 1 is OK
+Switch 1 FAN 2 is OK
+FAN PS-1 is OK
+FAN PS-2 is NOT PRESENT
+Switch 1: SYSTEM TEMPERATURE is OK
+Inlet Temperature Value: 31 Degree Celsius
+Temperature State: GREEN
+Yellow Threshold : 46 Degree Celsius
+Red Threshold    : 56 Degree Celsius
+
+Hotspot Temperature Value: 44 Degree Celsius
+Temperature State: GREEN
+Yellow Threshold : 105 Degree Celsius
+Red Threshold    : 125 Degree Celsius
+SW  PID                 Serial#     Status           Sys Pwr  PoE Pwr  Watts
+--  ------------------  ----------  ---------------  -------  -------  -----
+1A  PWR-C2-1025WAC      DCB2231H0QP  OK              Good     Good     1025
+1B  Not Present
+switch01#exit
+`,
+
+	"show interfaces trunk": `switch01#show interfaces trunk
+
+Port        Mode             Encapsulation  Status        Native vlan
+Gi1/0/1     on               802.1q         trunking      1
+
+Port        Vlans allowed on trunk
+Gi1/0/1     10,20,30
+
+Port        Vlans allowed and active in management domain
+Gi1/0/1     10,20,30
+
+Port        Vlans in spanning tree forwarding state and not pruned
+Gi1/0/1     10,20,30
+switch01#exit
+`,
+
+	"show logging": `switch01#show logging
+Syslog logging: enabled (0 messages dropped, 2 messages rate-limited, 0 flushes, 0 overruns, xml disabled, filtering disabled)
+
+No Active Message Discriminator.
+
+No Inactive Message Discriminator.
+
+    Console logging: level debugging, 41 messages logged, xml disabled,
+                     filtering disabled
+    Monitor logging: level debugging, 0 messages logged, xml disabled,
+                     filtering disabled
+    Buffer logging:  level debugging, 41 messages logged, xml disabled,
+                    filtering disabled
+    Exception Logging: size (4096 bytes)
+    Count and timestamp logging messages: disabled
+    File logging: disabled
+    Persistent logging: disabled
+
+No active filter modules.
+
+    Trap logging: level informational, 45 message lines logged
+        Logging to 10.0.0.5  (udp port 514, audit disabled,
+              link up),
+              45 message lines logged,
+              0 message lines rate-limited,
+              0 message lines dropped-by-MD,
+              xml disabled, sequence number disabled
+              filtering disabled
+        Logging Source-Interface:       VRF Name:
+
+Log Buffer (4096 bytes):
+
+000117: Oct 16 09:58:41.207 EST: %LINK-3-UPDOWN: Interface GigabitEthernet1/0/5, changed state to up
+000118: Oct 16 09:58:42.211 EST: %LINEPROTO-5-UPDOWN: Line protocol on Interface GigabitEthernet1/0/5, changed state to up
+000119: Oct 16 10:01:17.554 EST: %ILPOWER-7-DETECT: Interface Gi1/0/5: Power Device detected: IEEE PD
+000120: Oct 16 10:02:03.123 EST: %PM-4-ERR_DISABLE: psecure-violation error detected on Gi1/0/4, putting Gi1/0/4 in err-disable state
+000121: Oct 16 10:02:03.130 EST: %PORT_SECURITY-2-PSECURE_VIOLATION: Security violation occurred, caused by MAC address 0011.2233.4499 on port GigabitEthernet1/0/4.
+000122: Oct 16 10:14:52.887 EST: %SYS-5-CONFIG_I: Configured from console by netops on vty0 (10.0.0.5)
+switch01#exit
+`,
+
+	"show ip ospf neighbor": `switch01#show ip ospf neighbor
+switch01#exit
+`,
+
+	"show ip bgp summary": `switch01#show ip bgp summary
+% BGP not active
+
+switch01#exit
+`,
+
+	"show vlan brief": `switch01#show vlan brief
+
+VLAN Name                             Status    Ports
+---- -------------------------------- --------- -------------------------------
+1    default                          active    Te1/0/1, Te1/0/2
+10   DATA                             active    Gi1/0/2, Gi1/0/4, Gi1/0/6
+                                                Gi1/0/7, Gi1/0/8
+20   PRINTERS                         active    Gi1/0/3
+30   VOICE                            active    Gi1/0/5
+99   MGMT                             act/lshut
+1002 fddi-default                     act/unsup
+1003 token-ring-default               act/unsup
+switch01#exit
+`,
+
+	"show switch": `switch01#show switch
+Switch/Stack Mac Address : 00a1.b2c3.d400
+                                           H/W   Current
+Switch#  Role   Mac Address     Priority Version  State
+----------------------------------------------------------
+*1       Master 00a1.b2c3.d400     1      4       Ready
+
+switch01#exit
+`,
+}
@@ -0,0 +1,267 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultFleetConcurrency is the number of switches a Fleet works on at once when
+// Concurrency is not set.
+const DefaultFleetConcurrency = 10
+
+// FleetEventType is a step in the progress of one switch through a fleet run.
+type FleetEventType string
+
+const (
+	FleetQueued     FleetEventType = "queued"
+	FleetConnecting FleetEventType = "connecting"
+	FleetRunning    FleetEventType = "running"
+	FleetParsed     FleetEventType = "parsed"
+	FleetFailed     FleetEventType = "failed"
+	FleetCanceled   FleetEventType = "canceled"
+)
+
+// FleetEvent reports the progress of one switch.
+type FleetEvent struct {
+	Switch string
+	Type   FleetEventType
+	Time   time.Time
+	Err    error // Set for FleetFailed and FleetCanceled
+}
+
+// FleetResult is the outcome of a fleet task on one switch.
+type FleetResult struct {
+	Switch   string
+	Value    any // Whatever the task returned
+	Err      error
+	Canceled bool // The run was canceled before this switch finished
+	Skipped  bool // RunJob found the switch already done by a previous run
+	Duration time.Duration
+}
+
+// FleetTask is the work done on each switch over an established connection.
+type FleetTask func(client *Client) (any, error)
+
+// Fleet runs a task across many switches with bounded concurrency and reports every
+// switch's progress, so long runs can show live progress in a UI or CLI.
+type Fleet struct {
+	// Concurrency is the number of switches worked on at once. Zero means
+	// DefaultFleetConcurrency.
+	Concurrency int
+
+	// OnEvent, if set, receives every progress event. Calls are serialized, so the
+	// callback needs no locking of its own; it should return quickly, e.g. by
+	// forwarding the event to a channel.
+	OnEvent func(FleetEvent)
+
+	// Timeout, if set, caps the time spent on each switch, connecting included. A
+	// switch that takes longer fails with ErrTimeout and its connection is closed,
+	// which takes up to LogoutTimeout more.
+	Timeout time.Duration
+
+	// Pool, if set, provides the connections instead of dialing a new one per switch
+	// and run, and gets them back afterwards for the next run.
+	Pool *Pool
+
+	eventMu sync.Mutex
+
+	cancelMu sync.Mutex
+	cancels  map[*context.CancelFunc]struct{}
+}
+
+// NewFleet returns a Fleet working on concurrency switches at once and sending
+// progress events to onEvent, which may be nil.
+func NewFleet(concurrency int, onEvent func(FleetEvent)) *Fleet {
+	return &Fleet{Concurrency: concurrency, OnEvent: onEvent}
+}
+
+// Run connects to every switch, runs task on it and returns the results in the same
+// order as switch_hostnames. A failing switch does not stop the others.
+func (f *Fleet) Run(switch_hostnames []string, task FleetTask) []FleetResult {
+	return f.RunContext(context.Background(), switch_hostnames, task)
+}
+
+// RunContext is like Run but stops when ctx is canceled or Cancel is called: switches
+// not started yet are skipped, and the connections of those in flight are closed so
+// their sessions end at once instead of waiting for their timeouts. The partial
+// results are returned, with Canceled set on every switch that did not finish.
+func (f *Fleet) RunContext(ctx context.Context, switch_hostnames []string, task FleetTask) []FleetResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	f.trackCancel(&cancel, true)
+	defer f.trackCancel(&cancel, false)
+
+	results := make([]FleetResult, len(switch_hostnames))
+	for _, switch_hostname := range switch_hostnames {
+		f.emit(switch_hostname, FleetQueued, nil)
+	}
+
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFleetConcurrency
+	}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, switch_hostname := range switch_hostnames {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			results[i] = FleetResult{Switch: switch_hostname, Err: ctx.Err(), Canceled: true}
+			f.emit(switch_hostname, FleetCanceled, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, switch_hostname string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = f.runOne(ctx, switch_hostname, task)
+		}(i, switch_hostname)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Cancel stops every run in progress on this Fleet, as if their contexts were canceled.
+func (f *Fleet) Cancel() {
+	f.cancelMu.Lock()
+	defer f.cancelMu.Unlock()
+	for cancel := range f.cancels {
+		(*cancel)()
+	}
+}
+
+func (f *Fleet) trackCancel(cancel *context.CancelFunc, active bool) {
+	f.cancelMu.Lock()
+	defer f.cancelMu.Unlock()
+	if f.cancels == nil {
+		f.cancels = make(map[*context.CancelFunc]struct{})
+	}
+	if active {
+		f.cancels[cancel] = struct{}{}
+	} else {
+		delete(f.cancels, cancel)
+	}
+}
+
+func (f *Fleet) runOne(ctx context.Context, switch_hostname string, task FleetTask) FleetResult {
+	start := time.Now()
+	result := FleetResult{Switch: switch_hostname}
+
+	canceled := func() FleetResult {
+		result.Err, result.Canceled, result.Duration = ctx.Err(), true, time.Since(start)
+		f.emit(switch_hostname, FleetCanceled, ctx.Err())
+		return result
+	}
+
+	// switchCtx ends at the Timeout of this switch, ctx when the whole run stops.
+	switchCtx := ctx
+	if f.Timeout > 0 {
+		var cancel context.CancelFunc
+		switchCtx, cancel = context.WithTimeout(ctx, f.Timeout)
+		defer cancel()
+	}
+	timedOut := func(err error) error {
+		if switchCtx.Err() == nil {
+			return err
+		}
+		return fmt.Errorf("%s :: %w after %s: %v", switch_hostname, ErrTimeout, f.Timeout, err)
+	}
+
+	f.emit(switch_hostname, FleetConnecting, nil)
+	client, err := f.connect(switchCtx, switch_hostname)
+	if ctx.Err() != nil {
+		if client != nil {
+			f.release(client, false)
+		}
+		return canceled()
+	}
+	if err != nil {
+		result.Err, result.Duration = timedOut(err), time.Since(start)
+		f.emit(switch_hostname, FleetFailed, result.Err)
+		return result
+	}
+
+	// Closing the connection ends its sessions, after LogoutTimeout at most.
+	stop := context.AfterFunc(switchCtx, func() { client.Close() })
+
+	f.emit(switch_hostname, FleetRunning, nil)
+	result.Value, result.Err = task(client)
+	result.Duration = time.Since(start)
+	// A connection closed by the cancellation or timeout is not handed back to the pool.
+	f.release(client, stop() && (result.Err == nil || client.alive(keepaliveTimeout)))
+	if ctx.Err() != nil {
+		return canceled()
+	}
+	if result.Err != nil {
+		result.Err = timedOut(result.Err)
+		f.emit(switch_hostname, FleetFailed, result.Err)
+		return result
+	}
+
+	f.emit(switch_hostname, FleetParsed, nil)
+	return result
+}
+
+// connect dials the switch, or takes a connection from the pool if the fleet has one.
+func (f *Fleet) connect(ctx context.Context, switch_hostname string) (*Client, error) {
+	if f.Pool != nil {
+		return f.Pool.Get(ctx, switch_hostname)
+	}
+	return connectToSwitchContext(ctx, switch_hostname)
+}
+
+// release closes a connection from connect, or hands it back to the pool when it is
+// reusable.
+func (f *Fleet) release(client *Client, reusable bool) {
+	switch {
+	case f.Pool == nil:
+		client.Close()
+	case reusable:
+		f.Pool.Put(client)
+	default:
+		f.Pool.Discard(client)
+	}
+}
+
+func (f *Fleet) emit(switch_hostname string, eventType FleetEventType, err error) {
+	if f.OnEvent == nil {
+		return
+	}
+	f.eventMu.Lock()
+	defer f.eventMu.Unlock()
+	f.OnEvent(FleetEvent{Switch: switch_hostname, Type: eventType, Time: time.Now(), Err: err})
+}
+
+// ParseTask returns a FleetTask that runs command and parses it with the parser
+// registered for it, e.g. ParseTask("show interface status") yields []InterfaceStatus
+// per switch.
+func ParseTask(command string) FleetTask {
+	return func(client *Client) (any, error) {
+		outputString, err := client.RunCommand(command)
+		if err != nil {
+			return nil, err
+		}
+		parseStart := time.Now()
+		data, stats := SafeParse(command, outputString)
+		return data, reportParse(client.SwitchHostname, command, outputString, stats.Records, parseStart, stats.Err)
+	}
+}
+
+// ConfigTask returns a FleetTask that sends configuration commands in configuration
+// mode and returns the switch output. It fails with ErrInsufficientPrivilege on
+// switches where the login may not configure.
+func ConfigTask(config_commands ...string) FleetTask {
+	return func(client *Client) (any, error) {
+		if err := client.requirePrivilege(context.Background(), ConfigPrivilegeLevel); err != nil {
+			return nil, err
+		}
+		return client.configure("configure", config_commands, client.commandTimeout(DefaultCommandTimeout))
+	}
+}
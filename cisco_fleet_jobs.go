@@ -0,0 +1,175 @@
+package cisco
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// JobStatus is the state of one switch within a persistent fleet job. Switches
+// without a record are still pending.
+type JobStatus string
+
+const (
+	JobDone   JobStatus = "done"
+	JobFailed JobStatus = "failed"
+)
+
+// FleetSkipped is reported by RunJob for switches a previous run already completed.
+const FleetSkipped FleetEventType = "skipped"
+
+// JobRecord is the persisted status of one switch within a job.
+type JobRecord struct {
+	Job       string
+	Switch    string
+	Status    JobStatus
+	Error     string `json:",omitempty"`
+	Attempts  int
+	UpdatedAt time.Time
+}
+
+// JobStore persists per-switch job status, so a restarted process can resume a long
+// fleet operation. FileJobStore is the built-in implementation.
+type JobStore interface {
+	// Load returns the latest record of every switch of the job, keyed by switch.
+	Load(job string) (map[string]JobRecord, error)
+	// Save stores a record, replacing the previous one for the same job and switch.
+	Save(record JobRecord) error
+}
+
+// FileJobStore is a JobStore keeping an append-only log of JSON records in one file.
+// Every record is synced to disk before Save returns, so at most the switch in flight
+// is lost when the process crashes.
+type FileJobStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileJobStore returns a FileJobStore writing to path, created on first Save.
+func NewFileJobStore(path string) *FileJobStore {
+	return &FileJobStore{Path: path}
+}
+
+// Load returns the latest record of every switch of the job.
+func (s *FileJobStore) Load(job string) (map[string]JobRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make(map[string]JobRecord)
+
+	file, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job store: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLineSize)
+	for scanner.Scan() {
+		var record JobRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // A torn last line from a crash mid-write
+		}
+		if record.Job == job {
+			records[record.Switch] = record
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read job store: %w", err)
+	}
+
+	return records, nil
+}
+
+// Save appends a record to the log.
+func (s *FileJobStore) Save(record JobRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open job store: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write job store: %w", err)
+	}
+	return file.Sync()
+}
+
+// RunJob is RunContext for a named job whose progress is persisted in store. Switches
+// the store already records as done are skipped (reported as FleetSkipped and marked
+// Skipped, with a zero Value); the others run, and each is recorded as done as soon as its task
+// succeeds, or as failed at the end of the run. Running the same job again after a
+// crash or restart resumes where it left off and retries the failed switches.
+func (f *Fleet) RunJob(ctx context.Context, store JobStore, job string, switch_hostnames []string, task FleetTask) ([]FleetResult, error) {
+	records, err := store.Load(job)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FleetResult, len(switch_hostnames))
+	var pending []string
+	var pendingIndex []int
+	for i, switch_hostname := range switch_hostnames {
+		if records[switch_hostname].Status == JobDone {
+			results[i] = FleetResult{Switch: switch_hostname, Skipped: true}
+			f.emit(switch_hostname, FleetSkipped, nil)
+			continue
+		}
+		pending = append(pending, switch_hostname)
+		pendingIndex = append(pendingIndex, i)
+	}
+
+	var storeErrs []error
+	var storeMu sync.Mutex
+	save := func(switch_hostname string, status JobStatus, taskErr error) {
+		record := JobRecord{
+			Job:       job,
+			Switch:    switch_hostname,
+			Status:    status,
+			Attempts:  records[switch_hostname].Attempts + 1,
+			UpdatedAt: time.Now(),
+		}
+		if taskErr != nil {
+			record.Error = taskErr.Error()
+		}
+		if err := store.Save(record); err != nil {
+			storeMu.Lock()
+			storeErrs = append(storeErrs, fmt.Errorf("%s: %w", switch_hostname, err))
+			storeMu.Unlock()
+		}
+	}
+
+	recordedTask := func(client *Client) (any, error) {
+		value, err := task(client)
+		if err == nil {
+			save(client.SwitchHostname, JobDone, nil)
+		}
+		return value, err
+	}
+
+	for i, result := range f.RunContext(ctx, pending, recordedTask) {
+		results[pendingIndex[i]] = result
+		if result.Err != nil && !result.Canceled {
+			save(result.Switch, JobFailed, result.Err)
+		}
+	}
+
+	return results, errors.Join(storeErrs...)
+}
@@ -0,0 +1,87 @@
+package cisco_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xtokio/cisco"
+	"github.com/xtokio/cisco/ciscotest"
+)
+
+// FuzzParse runs the parser of a command on arbitrary output, seeded with every
+// fixture, and fails if it panics. Run it with go test -fuzz FuzzParse.
+func FuzzParse(f *testing.F) {
+	for _, command := range cisco.ParsableCommands() {
+		if rawOutput, ok := cisco.Fixtures[command]; ok {
+			f.Add(command, rawOutput)
+		}
+	}
+	f.Fuzz(func(t *testing.T, command string, rawOutput string) {
+		if _, stats := cisco.SafeParse(command, rawOutput); errors.Is(stats.Err, cisco.ErrParserPanic) {
+			t.Fatal(stats.Err)
+		}
+	})
+}
+
+// fuzzParser fuzzes one parser directly, seeded with the fixture of command, so the
+// fuzzer spends its time on the fixed-width tables most likely to be cut short.
+func fuzzParser[T any](f *testing.F, command string, parse func(rawOutput string) (T, error)) {
+	f.Add(cisco.Fixtures[command])
+	f.Fuzz(func(t *testing.T, rawOutput string) {
+		parse(rawOutput)
+	})
+}
+
+func FuzzParseCdpNeighbors(f *testing.F) {
+	fuzzParser(f, "show cdp neighbors", cisco.ParseCdpNeighbors)
+}
+
+func FuzzParseLldpNeighbors(f *testing.F) {
+	fuzzParser(f, "show lldp neighbors", cisco.ParseLldpNeighbors)
+}
+
+func FuzzParseLldpNeighborsDetail(f *testing.F) {
+	fuzzParser(f, "show lldp neighbors detail", cisco.ParseLldpNeighborsDetail)
+}
+
+func FuzzParseInterfaceStatus(f *testing.F) {
+	fuzzParser(f, "show interface status", cisco.ParseInterfaceStatus)
+}
+
+// FuzzParseInterfaces is seeded with every interface of the "show interface" output
+// of the simulator profiles, one per input, to cover the format of each platform.
+// The fuzzer minimizes every new input it finds, which takes long on inputs this
+// size; run it with -fuzzminimizetime 200x to spend the time fuzzing instead.
+func FuzzParseInterfaces(f *testing.F) {
+	for _, name := range ciscotest.ProfileNames() {
+		profile, _ := ciscotest.LookupProfile(name)
+		for _, block := range interfaceBlocks(profile.Responses["show interface"]) {
+			f.Add(block)
+		}
+	}
+	f.Fuzz(func(t *testing.T, rawOutput string) {
+		cisco.ParseInterfaces(rawOutput)
+	})
+}
+
+// interfaceBlocks splits "show interface" output into one block per interface, each
+// starting at an unindented line, and drops the command echo and the prompt.
+func interfaceBlocks(rawOutput string) []string {
+	var blocks []string
+	var block []string
+	flush := func() {
+		if text := strings.Join(block, "\n"); strings.TrimSpace(text) != "" && !strings.Contains(block[0], "#") {
+			blocks = append(blocks, text+"\n")
+		}
+		block = nil
+	}
+	for _, line := range strings.Split(rawOutput, "\n") {
+		if line != "" && line[0] != ' ' && line[0] != '\t' {
+			flush()
+		}
+		block = append(block, line)
+	}
+	flush()
+	return blocks
+}
@@ -0,0 +1,192 @@
+package cisco
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// GoldenResult is the outcome of running a parser against one golden fixture.
+type GoldenResult struct {
+	Name   string // Fixture name without extension
+	Passed bool
+	Err    error  // Parser or I/O error, if any
+	Diff   string // Line diff between expected and actual JSON, "-" expected, "+" actual
+}
+
+// RegisterParser registers a parser for command, so SafeParse, BenchmarkParser and
+// RunGoldenFiles can use it. Registering a command again replaces its parser, which
+// lets organizations override the built-in parsers with their own. Register parsers
+// during initialization, before any parsing starts; the registry is not locked.
+func RegisterParser(command string, parse func(rawOutput string) (any, error)) {
+	fixtureParsers[command] = func(rawOutput string) (any, int, error) {
+		data, err := parse(rawOutput)
+		return data, recordCount(data), err
+	}
+}
+
+// recordCount returns the number of records in parsed data: the length of a slice
+// or map, or 1 for any other non-nil value.
+func recordCount(data any) int {
+	value := reflect.ValueOf(data)
+	switch value.Kind() {
+	case reflect.Invalid:
+		return 0
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len()
+	}
+	return 1
+}
+
+// RunGoldenFiles runs the parser registered for command against every fixture in dir
+// and compares the result to the expected JSON. Each fixture is a pair of files:
+// <name>.txt with the raw command output and <name>.json with the expected result.
+// Results are returned sorted by name; an error is only returned if dir can't be read.
+func RunGoldenFiles(command string, dir string) ([]GoldenResult, error) {
+	names, err := goldenFixtureNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []GoldenResult
+	for _, name := range names {
+		result := GoldenResult{Name: name}
+
+		actual, err := goldenParse(command, filepath.Join(dir, name+".txt"))
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+
+		expected, err := os.ReadFile(filepath.Join(dir, name+".json"))
+		if err != nil {
+			result.Err = fmt.Errorf("missing expected result: %w", err)
+			results = append(results, result)
+			continue
+		}
+
+		result.Passed, result.Diff, result.Err = compareGoldenJSON(expected, actual)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// UpdateGoldenFiles rewrites <name>.json for every <name>.txt fixture in dir with the
+// current parser result. Review the changes before committing them.
+func UpdateGoldenFiles(command string, dir string) error {
+	names, err := goldenFixtureNames(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		actual, err := goldenParse(command, filepath.Join(dir, name+".txt"))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), actual, 0o644); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func goldenFixtureNames(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to read golden directory: %w", err)
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, strings.TrimSuffix(filepath.Base(match), ".txt"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// goldenParse parses a raw fixture file and returns the result as indented JSON.
+func goldenParse(command string, path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, stats := SafeParse(command, string(raw))
+	if stats.Err != nil {
+		return nil, stats.Err
+	}
+
+	actual, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	return append(actual, '\n'), nil
+}
+
+// compareGoldenJSON compares two JSON documents semantically, so formatting and key
+// order don't matter, and returns a line diff of their indented forms if they differ.
+func compareGoldenJSON(expected []byte, actual []byte) (bool, string, error) {
+	var want, got any
+	if err := json.Unmarshal(expected, &want); err != nil {
+		return false, "", fmt.Errorf("invalid expected JSON: %w", err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		return false, "", err
+	}
+	if reflect.DeepEqual(want, got) {
+		return true, "", nil
+	}
+
+	var wantIndented bytes.Buffer
+	json.Indent(&wantIndented, expected, "", "  ")
+	return false, diffLines(strings.Split(strings.TrimSpace(wantIndented.String()), "\n"), strings.Split(strings.TrimSpace(string(actual)), "\n")), nil
+}
+
+// diffLines returns a minimal line diff of a and b based on their longest common
+// subsequence. Unchanged lines are prefixed with "  ", removed ones with "- " and
+// added ones with "+ ".
+func diffLines(a []string, b []string) string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			diff.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return diff.String()
+}
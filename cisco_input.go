@@ -0,0 +1,44 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidParameter is returned when a value would change the meaning of the CLI
+// line it is put into, e.g. a description containing a newline, which would run
+// the rest as another command.
+var ErrInvalidParameter = errors.New("invalid command parameter")
+
+// reInterfaceParameter matches the interface names accepted in "interface" commands:
+// a type, optionally followed by a space, then slot/port numbers and an optional
+// subinterface, e.g., GigabitEthernet1/0/1, Gi 1/0/1, Port-channel10, Vlan100, mgmt0.
+var reInterfaceParameter = regexp.MustCompile(`^[A-Za-z][A-Za-z\-]* ?[0-9]+(?:/[0-9]+)*(?:[.:][0-9]+)?$`)
+
+// checkInterfaceParameter returns an error wrapping ErrInvalidParameter unless name
+// is a single interface name.
+func checkInterfaceParameter(name string) error {
+	if !reInterfaceParameter.MatchString(name) {
+		return fmt.Errorf("%w: %q is not an interface name", ErrInvalidParameter, name)
+	}
+	return nil
+}
+
+// checkTextParameter returns an error wrapping ErrInvalidParameter if text, to be put
+// into a CLI line as the value of field, contains a control character or "?". A
+// newline or carriage return ends the line early and sends the rest as another
+// command; the CLI answers "?" with help instead of taking it as text.
+func checkTextParameter(field string, text string) error {
+	if i := strings.IndexFunc(text, unicode.IsControl); i != -1 {
+		control, _ := utf8.DecodeRuneInString(text[i:])
+		return fmt.Errorf("%w: %s %q contains control character %U", ErrInvalidParameter, field, text, control)
+	}
+	if strings.Contains(text, "?") {
+		return fmt.Errorf("%w: %s %q contains \"?\"", ErrInvalidParameter, field, text)
+	}
+	return nil
+}
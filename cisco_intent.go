@@ -0,0 +1,226 @@
+package cisco
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VlanIntent is a VLAN that should exist on every switch of an Intent.
+type VlanIntent struct {
+	ID   int
+	Name string
+}
+
+// TrunkIntent is a trunk port on one switch.
+type TrunkIntent struct {
+	Switch       string
+	Interface    string
+	Description  string
+	AllowedVlans []int // Empty allows all VLANs
+	NativeVlan   int   // 0 leaves the native VLAN at the default
+}
+
+// PatchRecord is an access port as documented in the patch records: which outlet
+// it is patched to and which VLAN it belongs in.
+type PatchRecord struct {
+	Switch      string
+	Interface   string
+	Outlet      string // e.g., "B2-114-03"; used as the description when Description is empty
+	Description string
+	AccessVlan  int // 0 leaves the access VLAN unchanged
+	VoiceVlan   int
+}
+
+// Intent describes the desired VLAN, trunk and access port state of a group of switches.
+type Intent struct {
+	Switches []string // Switches that get the VLANs; switches named by trunks and patches are added
+	Vlans    []VlanIntent
+	Trunks   []TrunkIntent
+	Patches  []PatchRecord
+}
+
+// Devices returns every switch the intent applies to, sorted.
+func (i Intent) Devices() []string {
+	seen := make(map[string]bool)
+	for _, switch_hostname := range i.Switches {
+		seen[switch_hostname] = true
+	}
+	for _, trunk := range i.Trunks {
+		seen[trunk.Switch] = true
+	}
+	for _, patch := range i.Patches {
+		seen[patch.Switch] = true
+	}
+
+	devices := make([]string, 0, len(seen))
+	for switch_hostname := range seen {
+		devices = append(devices, switch_hostname)
+	}
+	sort.Strings(devices)
+	return devices
+}
+
+// Generate_intent_config returns the configuration lines one switch needs to match the
+// intent: VLAN definitions first, then one block per interface. The lines can be sent
+// with ConfigTask, ScheduledChange or CheckedChange, or checked with Compare_golden_config.
+// On NX-OS interfaces use long names and are also set to switchport and no shutdown.
+func Generate_intent_config(intent Intent, switch_hostname string, platform Platform) ([]string, error) {
+	var lines []string
+
+	vlans := append([]VlanIntent(nil), intent.Vlans...)
+	sort.Slice(vlans, func(a, b int) bool { return vlans[a].ID < vlans[b].ID })
+	for _, vlan := range vlans {
+		if vlan.ID < 1 || vlan.ID > 4094 {
+			return nil, fmt.Errorf("intent: invalid VLAN ID %d", vlan.ID)
+		}
+		if err := checkTextParameter("VLAN name", vlan.Name); err != nil {
+			return nil, fmt.Errorf("intent: VLAN %d: %w", vlan.ID, err)
+		}
+		lines = append(lines, fmt.Sprintf("vlan %d", vlan.ID))
+		if vlan.Name != "" {
+			lines = append(lines, " name "+vlan.Name)
+		}
+	}
+
+	seen := make(map[string]bool)
+	interfaceHeader := func(name string, description string) (string, error) {
+		if err := checkInterfaceParameter(name); err != nil {
+			return "", fmt.Errorf("intent: %s: %w", switch_hostname, err)
+		}
+		if err := checkTextParameter("description", description); err != nil {
+			return "", fmt.Errorf("intent: %s %s: %w", switch_hostname, name, err)
+		}
+		key := shortInterfaceName(name)
+		if seen[key] {
+			return "", fmt.Errorf("intent: %s %s is defined more than once", switch_hostname, name)
+		}
+		seen[key] = true
+		if platform == PlatformNXOS {
+			return "interface " + ExpandInterfaceName(name), nil
+		}
+		return "interface " + name, nil
+	}
+
+	for _, trunk := range intent.Trunks {
+		if trunk.Switch != switch_hostname {
+			continue
+		}
+		header, err := interfaceHeader(trunk.Interface, trunk.Description)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, header)
+		if trunk.Description != "" {
+			lines = append(lines, " description "+trunk.Description)
+		}
+		if platform == PlatformNXOS {
+			lines = append(lines, " switchport")
+		}
+		lines = append(lines, " switchport mode trunk")
+		if trunk.NativeVlan != 0 {
+			lines = append(lines, fmt.Sprintf(" switchport trunk native vlan %d", trunk.NativeVlan))
+		}
+		if len(trunk.AllowedVlans) > 0 {
+			lines = append(lines, " switchport trunk allowed vlan "+formatVlanList(trunk.AllowedVlans))
+		}
+		if platform == PlatformNXOS {
+			lines = append(lines, " no shutdown")
+		}
+	}
+
+	for _, patch := range intent.Patches {
+		if patch.Switch != switch_hostname {
+			continue
+		}
+		description := patch.Description
+		if description == "" {
+			description = patch.Outlet
+		}
+		header, err := interfaceHeader(patch.Interface, description)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, header)
+		if description != "" {
+			lines = append(lines, " description "+description)
+		}
+		if platform == PlatformNXOS {
+			lines = append(lines, " switchport")
+		}
+		lines = append(lines, " switchport mode access")
+		if patch.AccessVlan != 0 {
+			lines = append(lines, fmt.Sprintf(" switchport access vlan %d", patch.AccessVlan))
+		}
+		if patch.VoiceVlan != 0 {
+			lines = append(lines, fmt.Sprintf(" switchport voice vlan %d", patch.VoiceVlan))
+		}
+		if platform == PlatformNXOS {
+			lines = append(lines, " no shutdown")
+		}
+	}
+
+	return lines, nil
+}
+
+// Generate_intent_configs runs Generate_intent_config for every device of the intent.
+// Switches missing from platforms are treated as IOS.
+func Generate_intent_configs(intent Intent, platforms map[string]Platform) (map[string][]string, error) {
+	configs := make(map[string][]string)
+	for _, switch_hostname := range intent.Devices() {
+		lines, err := Generate_intent_config(intent, switch_hostname, platforms[switch_hostname])
+		if err != nil {
+			return nil, err
+		}
+		configs[switch_hostname] = lines
+	}
+	return configs, nil
+}
+
+// formatVlanList turns VLAN IDs into the compact list IOS prints, e.g. "10,20-22,30".
+func formatVlanList(vlans []int) string {
+	sorted := append([]int(nil), vlans...)
+	sort.Ints(sorted)
+
+	var parts []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] <= sorted[j]+1 {
+			j++
+		}
+		if sorted[j] == sorted[i] {
+			parts = append(parts, strconv.Itoa(sorted[i]))
+		} else {
+			parts = append(parts, strconv.Itoa(sorted[i])+"-"+strconv.Itoa(sorted[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseVlanList expands a VLAN list as IOS prints it, e.g. "10,20-22,30", into IDs.
+func parseVlanList(list string) ([]int, error) {
+	var vlans []int
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VLAN list %q", list)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil || end < start {
+				return nil, fmt.Errorf("invalid VLAN list %q", list)
+			}
+		}
+		for vlan := start; vlan <= end; vlan++ {
+			vlans = append(vlans, vlan)
+		}
+	}
+	return vlans, nil
+}
@@ -0,0 +1,99 @@
+package cisco
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// InteractiveSession connects to the switch and attaches stdin and stdout to its
+// shell until the operator logs out. See Client.InteractiveSession.
+func InteractiveSession(switch_hostname string, stdin io.Reader, stdout io.Writer) error {
+	client, err := connectToSwitch(switch_hostname)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return client.InteractiveSession(stdin, stdout)
+}
+
+// InteractiveSession attaches stdin and stdout to a live shell on the client's
+// connection, so an operator can drop from automation into the device for
+// troubleshooting. Every line the operator enters is logged like the commands run by
+// the library. The shell's terminal is 120x40; put the local terminal in raw mode
+// first (e.g. with golang.org/x/term) so keystrokes are passed through unbuffered.
+func (c *Client) InteractiveSession(stdin io.Reader, stdout io.Writer) error {
+	if c.Client == nil {
+		return errors.New("interactive sessions are not available when replaying a cassette")
+	}
+
+	release := c.acquireSession()
+	defer release()
+	defer c.trackSession()()
+
+	session, err := c.NewSession()
+	if err != nil {
+		return fmt.Errorf("%s :: interactive :: Failed to create session :: %v", c.SwitchHostname, err)
+	}
+	defer session.Close()
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty("xterm", 40, 120, modes); err != nil {
+		return fmt.Errorf("request for pseudo-terminal failed for %s: %v", c.SwitchHostname, err)
+	}
+
+	session.Stdin = &auditReader{r: stdin, client: c}
+	session.Stdout = stdout
+	session.Stderr = stdout
+
+	if err := session.Shell(); err != nil {
+		return fmt.Errorf("failed to start shell on %s: %v", c.SwitchHostname, err)
+	}
+
+	c.logf(slog.LevelInfo, "%s :: interactive :: Session started", c.SwitchHostname)
+	err = session.Wait()
+	c.logf(slog.LevelInfo, "%s :: interactive :: Session ended", c.SwitchHostname)
+
+	var exitMissing *ssh.ExitMissingError
+	if err != nil && err != io.EOF && !errors.As(err, &exitMissing) {
+		return fmt.Errorf("session wait failed on %s: %w", c.SwitchHostname, err)
+	}
+	return nil
+}
+
+// auditReader passes the operator's keystrokes through and logs each completed line.
+type auditReader struct {
+	r      io.Reader
+	client *Client
+	line   bytes.Buffer
+}
+
+func (a *auditReader) Read(p []byte) (int, error) {
+	n, err := a.r.Read(p)
+	for _, b := range p[:n] {
+		switch b {
+		case '\r', '\n':
+			if line := strings.TrimSpace(a.line.String()); line != "" {
+				a.client.logf(slog.LevelInfo, "%s :: interactive :: %s", a.client.SwitchHostname, line)
+			}
+			a.line.Reset()
+		case 0x7f, '\b': // Backspace and delete
+			if a.line.Len() > 0 {
+				a.line.Truncate(a.line.Len() - 1)
+			}
+		default:
+			a.line.WriteByte(b)
+		}
+	}
+	return n, err
+}
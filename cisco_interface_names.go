@@ -0,0 +1,116 @@
+package cisco
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// InterfaceNameStyle selects how the Show_* functions and analyses report interface names.
+type InterfaceNameStyle int
+
+const (
+	// InterfaceNameShort reports abbreviated names, e.g., Gi1/0/1. This is the default.
+	InterfaceNameShort InterfaceNameStyle = iota
+	// InterfaceNameLong reports full names, e.g., GigabitEthernet1/0/1.
+	InterfaceNameLong
+	// InterfaceNameAsPrinted leaves names exactly as the switch printed them.
+	InterfaceNameAsPrinted
+)
+
+// interfaceTypeNames maps the short interface types produced by shortInterfaceName
+// to their long form.
+var interfaceTypeNames = map[string]string{
+	"Ap":  "AppGigabitEthernet",
+	"Fa":  "FastEthernet",
+	"Gi":  "GigabitEthernet",
+	"Fi":  "FiveGigabitEthernet",
+	"Te":  "TenGigabitEthernet",
+	"Twe": "TwentyFiveGigE",
+	"Fo":  "FortyGigabitEthernet",
+	"Hu":  "HundredGigE",
+	"Eth": "Ethernet",
+	"Et":  "Ethernet",
+	"Po":  "Port-channel",
+	"Vl":  "Vlan",
+	"Lo":  "Loopback",
+	"Tu":  "Tunnel",
+	"BE":  "Bundle-Ether",
+}
+
+var interfaceNaming struct {
+	sync.RWMutex
+	style InterfaceNameStyle
+	rules *strings.Replacer
+}
+
+// SetInterfaceNameStyle sets the form of every interface name returned from now on,
+// e.g. InterfaceNameLong to join results against systems storing long names.
+func SetInterfaceNameStyle(style InterfaceNameStyle) {
+	interfaceNaming.Lock()
+	defer interfaceNaming.Unlock()
+	interfaceNaming.style = style
+}
+
+// SetInterfaceNameRules installs extra old, new replacement pairs applied after the
+// style conversion, e.g. SetInterfaceNameRules("Gi", "GE") to match an inventory
+// system's naming. Calling it without arguments removes the rules.
+func SetInterfaceNameRules(oldnew ...string) error {
+	if len(oldnew)%2 != 0 {
+		return fmt.Errorf("interface name rules need old, new pairs, got %d strings", len(oldnew))
+	}
+
+	interfaceNaming.Lock()
+	defer interfaceNaming.Unlock()
+	interfaceNaming.rules = nil
+	if len(oldnew) > 0 {
+		interfaceNaming.rules = strings.NewReplacer(oldnew...)
+	}
+	return nil
+}
+
+// NormalizeInterfaceName converts an interface name to the given style for a single
+// call, ignoring the package settings, e.g. to turn a Gi1/0/1 from parsed data into
+// the GigabitEthernet1/0/1 stored elsewhere.
+func NormalizeInterfaceName(name string, style InterfaceNameStyle) string {
+	switch style {
+	case InterfaceNameAsPrinted:
+		return name
+	case InterfaceNameLong:
+		return expandInterfaceName(shortInterfaceName(name))
+	}
+	return shortInterfaceName(name)
+}
+
+// ExpandInterfaceName returns the long form of an interface name in any spelling,
+// e.g. GigabitEthernet1/0/13 for Gi1/0/13, for configuration commands built from
+// parsed data. Names of an unknown type are only stripped of spaces.
+func ExpandInterfaceName(name string) string {
+	return NormalizeInterfaceName(name, InterfaceNameLong)
+}
+
+// normalizeInterfaceName converts interface names to the configured style and rules.
+func normalizeInterfaceName(name string) string {
+	interfaceNaming.RLock()
+	style, rules := interfaceNaming.style, interfaceNaming.rules
+	interfaceNaming.RUnlock()
+
+	name = NormalizeInterfaceName(name, style)
+	if rules != nil {
+		name = rules.Replace(name)
+	}
+	return name
+}
+
+// expandInterfaceName turns a short interface name into its long form. Names with
+// an unknown type are returned unchanged.
+func expandInterfaceName(name string) string {
+	digits := strings.IndexAny(name, "0123456789")
+	if digits <= 0 {
+		return name
+	}
+	if long, ok := interfaceTypeNames[name[:digits]]; ok {
+		return long + name[digits:]
+	}
+	return name
+}
@@ -0,0 +1,115 @@
+package cisco
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// InterfaceName is an interface name split into its components.
+type InterfaceName struct {
+	Type         string // Short type, e.g., Gi, Te, Po, Vl
+	Numbers      []int  // e.g., [1 0 13] for Gi1/0/13: stack member or chassis, module, port
+	Subinterface int    // Number after the dot, e.g., 100 for Gi0/0/0.100; -1 when none
+}
+
+// interfaceTypeOrder ranks the short interface types for sorting: physical ports by
+// speed, then aggregates and logical interfaces. Unknown types sort after all of
+// these, alphabetically.
+var interfaceTypeOrder = []string{"Fa", "Gi", "Fi", "Te", "Twe", "Fo", "Hu", "Et", "Ap", "BE", "Po", "Lo", "Tu", "Vl"}
+
+// ParseInterfaceName splits an interface name in any spelling into its type and
+// numbers, e.g. GigabitEthernet1/0/13 into Gi and [1 0 13].
+func ParseInterfaceName(name string) InterfaceName {
+	name = shortInterfaceName(name)
+	parsed := InterfaceName{Subinterface: -1}
+
+	digits := strings.IndexAny(name, "0123456789")
+	if digits == -1 {
+		parsed.Type = name
+		return parsed
+	}
+	parsed.Type = shortInterfaceType(name[:digits])
+
+	numbers, sub, hasSub := strings.Cut(name[digits:], ".")
+	for _, field := range strings.FieldsFunc(numbers, func(r rune) bool { return r < '0' || r > '9' }) {
+		n, _ := strconv.Atoi(field)
+		parsed.Numbers = append(parsed.Numbers, n)
+	}
+	if hasSub {
+		parsed.Subinterface, _ = strconv.Atoi(sub)
+	}
+	return parsed
+}
+
+// Compare orders interface names by type rank, then numerically component by
+// component, so Gi1/0/2 comes before Gi1/0/10 and a parent before its subinterfaces.
+func (n InterfaceName) Compare(other InterfaceName) int {
+	if c := compareInterfaceTypes(n.Type, other.Type); c != 0 {
+		return c
+	}
+	if c := slices.Compare(n.Numbers, other.Numbers); c != 0 {
+		return c
+	}
+	return cmp.Compare(n.Subinterface, other.Subinterface)
+}
+
+// shortInterfaceType returns the shortest spelling of an interface type known to
+// interfaceTypeNames, so that e.g. Eth, Ethernet and Et, or Vlan and Vl, are one type.
+func shortInterfaceType(t string) string {
+	if long, ok := interfaceTypeNames[t]; ok {
+		t = long
+	}
+	short := t
+	for abbreviation, long := range interfaceTypeNames {
+		if long == t && (len(abbreviation) < len(short) || len(abbreviation) == len(short) && abbreviation < short) {
+			short = abbreviation
+		}
+	}
+	return short
+}
+
+func compareInterfaceTypes(a string, b string) int {
+	rank := func(t string) int {
+		if i := slices.Index(interfaceTypeOrder, t); i != -1 {
+			return i
+		}
+		return len(interfaceTypeOrder)
+	}
+	if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// CompareInterfaceNames compares two interface names in natural order, returning -1,
+// 0 or +1. Names in different spellings of the same interface compare equal.
+func CompareInterfaceNames(a string, b string) int {
+	return ParseInterfaceName(a).Compare(ParseInterfaceName(b))
+}
+
+// SortInterfaceNames sorts interface names in natural order.
+func SortInterfaceNames(names []string) {
+	slices.SortStableFunc(names, CompareInterfaceNames)
+}
+
+// SortByInterface sorts parsed records in natural interface order, e.g.
+// SortByInterface(statuses, func(s InterfaceStatus) string { return s.Interface }).
+func SortByInterface[T any](items []T, name func(T) string) {
+	slices.SortStableFunc(items, func(a T, b T) int {
+		return CompareInterfaceNames(name(a), name(b))
+	})
+}
+
+// GroupByInterface groups parsed records by a key derived from their interface name,
+// e.g. by type with func(n InterfaceName) string { return n.Type }. Each group keeps
+// the order of items.
+func GroupByInterface[T any, K comparable](items []T, name func(T) string, key func(InterfaceName) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range items {
+		k := key(ParseInterfaceName(name(item)))
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
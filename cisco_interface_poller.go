@@ -0,0 +1,84 @@
+package cisco
+
+import (
+	"sync"
+)
+
+// InterfacePoller polls "show interface" on a single switch and remembers the
+// previous result, so successive polls only return interfaces that changed.
+// It is safe for concurrent use.
+type InterfacePoller struct {
+	SwitchHostname string
+
+	mu       sync.Mutex
+	previous map[string]InterfaceDetails
+}
+
+// NewInterfacePoller creates a poller for switch_hostname. The first call to Poll
+// returns every interface, since there is no previous poll to compare against.
+func NewInterfacePoller(switch_hostname string) *InterfacePoller {
+	return &InterfacePoller{SwitchHostname: switch_hostname}
+}
+
+// Poll runs "show interface" and returns only the interfaces whose status,
+// settings or counters changed since the previous Poll or Snapshot, including
+// interfaces that appeared for the first time.
+func (p *InterfacePoller) Poll() ([]InterfaceDetails, error) {
+	current, err := Show_interfaces(p.SwitchHostname)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var changed []InterfaceDetails
+	for _, iface := range current {
+		previous, ok := p.previous[iface.Interface]
+		if !ok || interfaceChanged(previous, iface) {
+			changed = append(changed, iface)
+		}
+	}
+	p.store(current)
+
+	return changed, nil
+}
+
+// Snapshot runs "show interface" and returns every interface, resetting the
+// baseline that the next Poll compares against.
+func (p *InterfacePoller) Snapshot() ([]InterfaceDetails, error) {
+	current, err := Show_interfaces(p.SwitchHostname)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.store(current)
+
+	return current, nil
+}
+
+// Reset forgets the previous poll, so the next Poll returns every interface.
+func (p *InterfacePoller) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.previous = nil
+}
+
+// store replaces the remembered poll result. The caller must hold p.mu.
+func (p *InterfacePoller) store(interfaces []InterfaceDetails) {
+	p.previous = make(map[string]InterfaceDetails, len(interfaces))
+	for _, iface := range interfaces {
+		p.previous[iface.Interface] = iface
+	}
+}
+
+// interfaceChanged reports whether two samples of the same interface differ in
+// anything other than the "Last input/output" timers, which tick on every poll.
+func interfaceChanged(previous InterfaceDetails, current InterfaceDetails) bool {
+	previous.LastInput, current.LastInput = "", ""
+	previous.LastOutput, current.LastOutput = "", ""
+	previous.OutputHang, current.OutputHang = "", ""
+	return previous != current
+}
@@ -0,0 +1,43 @@
+package cisco
+
+import (
+	"log/slog"
+	"time"
+)
+
+// keepAlive sends an SSH keepalive over the connection every interval, so firewalls
+// and the switch do not drop it while idle, until the connection closes. A keepalive
+// left unanswered for keepaliveTimeout, or the connection closing, marks the client
+// unhealthy.
+func (c *Client) keepAlive(interval time.Duration) {
+	closed := make(chan struct{})
+	go func() {
+		c.Client.Wait()
+		close(closed)
+	}()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-closed:
+				c.unhealthy.Store(true)
+				return
+			case <-ticker.C:
+				if !c.alive(keepaliveTimeout) {
+					c.unhealthy.Store(true)
+					c.logf(slog.LevelWarn, "%s :: keepalive :: No answer within %s, connection is unhealthy", c.SwitchHostname, keepaliveTimeout)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Healthy reports whether the connection is still usable as far as the keepalives
+// of Config.KeepAlive tell: false once one went unanswered or the connection closed.
+// Without keepalives it is always true.
+func (c *Client) Healthy() bool {
+	return !c.unhealthy.Load()
+}
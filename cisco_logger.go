@@ -0,0 +1,57 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+var loggerSettings struct {
+	sync.RWMutex
+	logger *slog.Logger
+}
+
+// SetLogger sends the library's log messages to logger, for clients that do not set
+// their own. Connections, sessions and changes are logged at Info, problems the
+// library works around at Warn, failures at Error and parser details at Debug. Pass
+// nil to go back to slog.Default(), and slog.New(slog.DiscardHandler) to silence the
+// library.
+func SetLogger(logger *slog.Logger) {
+	loggerSettings.Lock()
+	defer loggerSettings.Unlock()
+	loggerSettings.logger = logger
+}
+
+func activeLogger() *slog.Logger {
+	loggerSettings.RLock()
+	defer loggerSettings.RUnlock()
+	if loggerSettings.logger == nil {
+		return slog.Default()
+	}
+	return loggerSettings.logger
+}
+
+// logf logs a formatted message to the logger set with SetLogger.
+func logf(level slog.Level, format string, args ...any) {
+	logTo(nil, level, format, args...)
+}
+
+// logf logs a formatted message to the client's Logger, or the one set with
+// SetLogger.
+func (c *Client) logf(level slog.Level, format string, args ...any) {
+	logTo(c.Logger, level, format, args...)
+}
+
+// logTo logs a formatted message to logger, or the one set with SetLogger when nil,
+// formatting it only when the level is enabled.
+func logTo(logger *slog.Logger, level slog.Level, format string, args ...any) {
+	if logger == nil {
+		logger = activeLogger()
+	}
+	ctx := context.Background()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	logger.Log(ctx, level, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,99 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// LogoutTimeout is how long the switch gets to end a session after it was told to
+// log out, and how long Close waits for sessions still running, before they are torn
+// down from this side.
+const LogoutTimeout = 2 * time.Second
+
+// ErrUncleanTeardown is returned by Close when a session had to be torn down before
+// the switch ended it. The switch may keep its VTY line busy until exec-timeout.
+var ErrUncleanTeardown = errors.New("session torn down before the switch ended it")
+
+// logoutSequence returns the commands that end a shell session from any mode. IOS-XR
+// would ask whether to commit on "end", so uncommitted changes are aborted instead.
+func (p Platform) logoutSequence() []string {
+	if p == PlatformIOSXR {
+		return []string{"abort", "exit"}
+	}
+	return []string{"end", "exit"}
+}
+
+// logout ends a shell session that is still running, e.g. after a command timed out:
+// it sends the logout sequence and waits up to LogoutTimeout for the switch to close
+// the channel, signalled on done. Otherwise the session is closed from this side and
+// counted as an unclean teardown.
+func (c *Client) logout(session *ssh.Session, stdin io.Writer, done <-chan error) {
+	for _, cmd := range c.platform().logoutSequence() {
+		if _, err := fmt.Fprintf(stdin, "%s\n", cmd); err != nil {
+			break
+		}
+	}
+
+	select {
+	case <-done:
+		return
+	case <-time.After(LogoutTimeout):
+	}
+
+	session.Close()
+	c.uncleanTeardowns.Add(1)
+	c.logf(slog.LevelWarn, "%s :: logout :: Session did not end within %s, closed it", c.SwitchHostname, LogoutTimeout)
+}
+
+// waitSessions waits up to timeout for the sessions running on the client to end
+// and reports whether they did.
+func (c *Client) waitSessions(timeout time.Duration) bool {
+	ended := make(chan struct{})
+	go func() {
+		c.active.Wait()
+		close(ended)
+	}()
+
+	select {
+	case <-ended:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// trackSession counts a session as running until the returned function is called, so
+// Close can wait for it.
+func (c *Client) trackSession() func() {
+	c.active.Add(1)
+	return c.active.Done
+}
+
+// Close closes the SSH connection once the sessions still running on it have ended,
+// waiting for them up to LogoutTimeout. It returns ErrUncleanTeardown if any session
+// of the client had to be torn down, here or after a command timed out.
+func (c *Client) Close() error {
+	if c.Client == nil {
+		return nil // Replayed from a cassette
+	}
+
+	if !c.waitSessions(LogoutTimeout) {
+		c.uncleanTeardowns.Add(1)
+		c.logf(slog.LevelWarn, "%s :: close :: Sessions still running after %s, closing the connection under them", c.SwitchHostname, LogoutTimeout)
+	}
+
+	c.Client.Close()
+	for i := len(c.jumps) - 1; i >= 0; i-- {
+		c.jumps[i].Close()
+	}
+
+	if n := c.uncleanTeardowns.Load(); n > 0 {
+		return fmt.Errorf("%s :: %w (%d)", c.SwitchHostname, ErrUncleanTeardown, n)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package cisco
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaintenanceWindow is a recurring period in which changes may be made, defined by a
+// cron-like start spec and a duration, e.g. "0 22 * * 2,4" for 22:00 on Tuesdays and
+// Thursdays. ParseMaintenanceWindow checks the spec up front; a window built as a
+// struct literal has its Spec parsed on use and is never open if the Spec is invalid.
+type MaintenanceWindow struct {
+	Spec     string
+	Duration time.Duration
+	Location *time.Location // Defaults to time.Local
+
+	schedule *cronSchedule // Parsed Spec, set by ParseMaintenanceWindow
+}
+
+// cronSchedule is the set of values each field of a cron spec matches.
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays map[int]bool
+
+	// eitherDay is set when both the day of month and the day of week are restricted,
+	// in which case, as in cron, a day matching either one matches.
+	eitherDay bool
+}
+
+// ParseMaintenanceWindow parses a five-field cron spec (minute, hour, day of month,
+// month, day of week; Sunday is 0) with "*", lists, ranges and "*/n" steps. As in
+// cron, when both the day of month and the day of week are restricted, a day matching
+// either one opens the window: "0 2 1 * 6" is 02:00 on the 1st and on every Saturday.
+func ParseMaintenanceWindow(spec string, duration time.Duration, location *time.Location) (MaintenanceWindow, error) {
+	if duration <= 0 {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q: duration must be positive", spec)
+	}
+	schedule, err := parseCronSchedule(spec)
+	if err != nil {
+		return MaintenanceWindow{}, err
+	}
+	if location == nil {
+		location = time.Local
+	}
+
+	return MaintenanceWindow{Spec: spec, Duration: duration, Location: location, schedule: &schedule}, nil
+}
+
+// parseCronSchedule parses the five fields of a cron spec.
+func parseCronSchedule(spec string) (cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("maintenance window %q: need 5 fields, got %d", spec, len(fields))
+	}
+
+	var schedule cronSchedule
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	sets := []*map[int]bool{&schedule.minutes, &schedule.hours, &schedule.days, &schedule.months, &schedule.weekdays}
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("maintenance window %q: %w", spec, err)
+		}
+		*sets[i] = set
+	}
+	schedule.eitherDay = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+// parseCronField expands one cron field into the set of values it matches.
+func parseCronField(field string, min int, max int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		low, high := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("invalid range in %q", part)
+				}
+			} else if hasStep {
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+
+		for value := low; value <= high; value += step {
+			set[value] = true
+		}
+	}
+	return set, nil
+}
+
+// starts reports whether a window of the schedule starts at the minute of t.
+func (c cronSchedule) starts(t time.Time) bool {
+	if !c.minutes[t.Minute()] || !c.hours[t.Hour()] || !c.months[int(t.Month())] {
+		return false
+	}
+	if c.eitherDay {
+		return c.days[t.Day()] || c.weekdays[int(t.Weekday())]
+	}
+	return c.days[t.Day()] && c.weekdays[int(t.Weekday())]
+}
+
+// parsed returns the schedule of the window and its location, parsing Spec and
+// defaulting Location for windows not built by ParseMaintenanceWindow.
+func (w MaintenanceWindow) parsed() (cronSchedule, *time.Location, bool) {
+	location := w.Location
+	if location == nil {
+		location = time.Local
+	}
+	if w.schedule != nil {
+		return *w.schedule, location, true
+	}
+	schedule, err := parseCronSchedule(w.Spec)
+	return schedule, location, err == nil
+}
+
+// Open reports whether t falls inside the window.
+func (w MaintenanceWindow) Open(t time.Time) bool {
+	schedule, location, ok := w.parsed()
+	if !ok {
+		return false
+	}
+	t = t.In(location).Truncate(time.Minute)
+	for start := t; t.Sub(start) < w.Duration; start = start.Add(-time.Minute) {
+		if schedule.starts(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next time at or after t at which the window opens, searching up
+// to a year ahead. It returns the zero time if the spec never matches.
+func (w MaintenanceWindow) Next(t time.Time) time.Time {
+	schedule, location, ok := w.parsed()
+	if !ok {
+		return time.Time{}
+	}
+	start := t.In(location).Truncate(time.Minute)
+	if start.Before(t) {
+		start = start.Add(time.Minute)
+	}
+	for limit := start.AddDate(1, 0, 0); start.Before(limit); start = start.Add(time.Minute) {
+		if schedule.starts(start) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// ScheduledChange is a configuration change queued for a switch.
+type ScheduledChange struct {
+	Switch      string
+	Description string
+	Commands    []string // Configuration commands, sent between "configure terminal" and "end"
+	QueuedAt    time.Time
+}
+
+// ChangeStatus is what happened to a queued change when the scheduler ran.
+type ChangeStatus string
+
+const (
+	ChangeApplied  ChangeStatus = "applied"
+	ChangeDeferred ChangeStatus = "deferred"
+	ChangeFailed   ChangeStatus = "failed"
+)
+
+// ChangeOutcome reports one queued change after a scheduler run.
+type ChangeOutcome struct {
+	Change     ScheduledChange
+	Status     ChangeStatus
+	Output     string    // Switch output, for applied and failed changes
+	Err        error     // Why it failed, or why it was deferred without a window
+	NextWindow time.Time // When a deferred change can run; zero if it has no window
+}
+
+// ChangeScheduler holds configuration changes until the maintenance window of the
+// switch's group is open. Switches are assigned to groups (a site or device group)
+// and each group has a window; changes for switches without one are never applied.
+type ChangeScheduler struct {
+	mu      sync.Mutex
+	windows map[string]MaintenanceWindow // group → window
+	groups  map[string]string            // switch → group
+	queue   []ScheduledChange
+}
+
+// NewChangeScheduler returns an empty ChangeScheduler.
+func NewChangeScheduler() *ChangeScheduler {
+	return &ChangeScheduler{windows: make(map[string]MaintenanceWindow), groups: make(map[string]string)}
+}
+
+// SetWindow sets the maintenance window of a group.
+func (s *ChangeScheduler) SetWindow(group string, window MaintenanceWindow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windows[group] = window
+}
+
+// AssignGroup puts switches into a group.
+func (s *ChangeScheduler) AssignGroup(group string, switch_hostnames ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, switch_hostname := range switch_hostnames {
+		s.groups[switch_hostname] = group
+	}
+}
+
+// Queue adds a change to the queue.
+func (s *ChangeScheduler) Queue(change ScheduledChange) {
+	if change.QueuedAt.IsZero() {
+		change.QueuedAt = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = append(s.queue, change)
+}
+
+// Pending returns the changes still queued.
+func (s *ChangeScheduler) Pending() []ScheduledChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ScheduledChange(nil), s.queue...)
+}
+
+// Run applies every queued change whose switch is inside its maintenance window at
+// now, in queue order, and reports all changes: applied and failed ones leave the
+// queue, deferred ones stay queued with the time their window opens next. Call it
+// periodically, e.g. every minute.
+func (s *ChangeScheduler) Run(now time.Time) []ChangeOutcome {
+	s.mu.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.mu.Unlock()
+
+	var outcomes []ChangeOutcome
+	var deferred []ScheduledChange
+
+	for _, change := range queue {
+		outcome := ChangeOutcome{Change: change}
+
+		s.mu.Lock()
+		window, ok := s.windows[s.groups[change.Switch]]
+		s.mu.Unlock()
+
+		switch {
+		case !ok:
+			outcome.Status = ChangeDeferred
+			outcome.Err = fmt.Errorf("%s has no maintenance window", change.Switch)
+			deferred = append(deferred, change)
+		case !window.Open(now):
+			outcome.Status = ChangeDeferred
+			outcome.NextWindow = window.Next(now)
+			deferred = append(deferred, change)
+		default:
+			output, err := pushConfig(change.Switch, change.Commands)
+			outcome.Output, outcome.Err = output, err
+			outcome.Status = ChangeApplied
+			if err != nil {
+				outcome.Status = ChangeFailed
+			}
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	s.mu.Lock()
+	s.queue = append(deferred, s.queue...)
+	s.mu.Unlock()
+
+	return outcomes
+}
@@ -0,0 +1,56 @@
+package cisco_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xtokio/cisco"
+)
+
+func TestMaintenanceWindowLiteral(t *testing.T) {
+	// Built without ParseMaintenanceWindow: the spec is parsed on use and the
+	// location defaults to time.Local.
+	window := cisco.MaintenanceWindow{Spec: "0 2 * * 6", Duration: time.Hour}
+
+	saturday := time.Date(2026, 10, 17, 2, 30, 0, 0, time.Local)
+	if !window.Open(saturday) {
+		t.Errorf("window closed at %s", saturday)
+	}
+	if next := window.Next(saturday.AddDate(0, 0, -1)); !next.Equal(saturday.Add(-30 * time.Minute)) {
+		t.Errorf("Next() = %s, want %s", next, saturday.Add(-30*time.Minute))
+	}
+
+	invalid := cisco.MaintenanceWindow{Spec: "every saturday", Duration: time.Hour}
+	if invalid.Open(saturday) || !invalid.Next(saturday).IsZero() {
+		t.Error("a window with an invalid spec opens")
+	}
+}
+
+func TestMaintenanceWindowDays(t *testing.T) {
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 2, 10, 0, 0, time.UTC)
+	}
+	for _, test := range []struct {
+		spec string
+		at   time.Time
+		open bool
+	}{
+		// Both days restricted: either one opens the window, as in cron.
+		{"0 2 1 * 6", at(time.November, 1), true}, // Sunday the 1st
+		{"0 2 1 * 6", at(time.October, 17), true}, // Saturday the 17th
+		{"0 2 1 * 6", at(time.October, 18), false},
+		// One day restricted: only that one counts.
+		{"0 2 * * 6", at(time.November, 1), false},
+		{"0 2 1 * *", at(time.November, 1), true},
+		{"0 2 1 * *", at(time.October, 17), false},
+		{"0 2 */2 * *", at(time.October, 17), true},
+	} {
+		window, err := cisco.ParseMaintenanceWindow(test.spec, time.Hour, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if open := window.Open(test.at); open != test.open {
+			t.Errorf("%q at %s: Open() = %t, want %t", test.spec, test.at.Format("Mon Jan 2"), open, test.open)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package cisco
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OpenConfig models, limited to the leaves the CLI parsers can fill. They marshal with
+// encoding/json into RFC 7951 JSON (module-qualified top-level names, 64-bit counters
+// as strings) as accepted by gNMI and other OpenConfig consumers.
+
+// OpenConfigInterfaces is the openconfig-interfaces top-level container.
+type OpenConfigInterfaces struct {
+	Interfaces struct {
+		Interface []OpenConfigInterface `json:"interface"`
+	} `json:"openconfig-interfaces:interfaces"`
+}
+
+// OpenConfigInterface is one /interfaces/interface list entry.
+type OpenConfigInterface struct {
+	Name     string                    `json:"name"`
+	Config   OpenConfigInterfaceConfig `json:"config"`
+	State    OpenConfigInterfaceState  `json:"state"`
+	Ethernet *OpenConfigEthernet       `json:"openconfig-if-ethernet:ethernet,omitempty"`
+}
+
+// OpenConfigInterfaceConfig is /interfaces/interface/config.
+type OpenConfigInterfaceConfig struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Mtu         uint16 `json:"mtu,omitempty"`
+}
+
+// OpenConfigInterfaceState is /interfaces/interface/state.
+type OpenConfigInterfaceState struct {
+	Name        string                       `json:"name"`
+	Type        string                       `json:"type"`
+	Description string                       `json:"description,omitempty"`
+	Enabled     bool                         `json:"enabled"`
+	Mtu         uint16                       `json:"mtu,omitempty"`
+	AdminStatus string                       `json:"admin-status"` // UP, DOWN
+	OperStatus  string                       `json:"oper-status"`  // UP, DOWN
+	Counters    *OpenConfigInterfaceCounters `json:"counters,omitempty"`
+}
+
+// OpenConfigInterfaceCounters is /interfaces/interface/state/counters.
+type OpenConfigInterfaceCounters struct {
+	InOctets    uint64 `json:"in-octets,string"`
+	InPkts      uint64 `json:"in-pkts,string"`
+	InErrors    uint64 `json:"in-errors,string"`
+	InDiscards  uint64 `json:"in-discards,string"`
+	InFcsErrors uint64 `json:"in-fcs-errors,string"`
+	OutOctets   uint64 `json:"out-octets,string"`
+	OutPkts     uint64 `json:"out-pkts,string"`
+	OutErrors   uint64 `json:"out-errors,string"`
+	OutDiscards uint64 `json:"out-discards,string"`
+}
+
+// OpenConfigEthernet is the openconfig-if-ethernet augmentation of an interface,
+// including the openconfig-vlan switched-vlan container.
+type OpenConfigEthernet struct {
+	Config struct {
+		AutoNegotiate bool   `json:"auto-negotiate"`
+		PortSpeed     string `json:"port-speed,omitempty"`  // e.g., openconfig-if-ethernet:SPEED_1GB
+		DuplexMode    string `json:"duplex-mode,omitempty"` // FULL, HALF
+	} `json:"config"`
+	State struct {
+		MacAddress string `json:"mac-address,omitempty"` // aa:bb:cc:dd:ee:ff
+	} `json:"state"`
+	SwitchedVlan *OpenConfigSwitchedVlan `json:"openconfig-vlan:switched-vlan,omitempty"`
+}
+
+// OpenConfigSwitchedVlan is the layer 2 VLAN configuration of an Ethernet interface.
+type OpenConfigSwitchedVlan struct {
+	Config struct {
+		InterfaceMode string `json:"interface-mode"` // ACCESS, TRUNK
+		AccessVlan    uint16 `json:"access-vlan,omitempty"`
+	} `json:"config"`
+}
+
+// OpenConfigVlans is the openconfig-network-instance container holding the VLANs of
+// the default instance.
+type OpenConfigVlans struct {
+	NetworkInstances struct {
+		NetworkInstance []OpenConfigNetworkInstance `json:"network-instance"`
+	} `json:"openconfig-network-instance:network-instances"`
+}
+
+// OpenConfigNetworkInstance is a network instance with its VLANs.
+type OpenConfigNetworkInstance struct {
+	Name  string `json:"name"`
+	Vlans struct {
+		Vlan []OpenConfigVlan `json:"vlan"`
+	} `json:"vlans"`
+}
+
+// OpenConfigVlan is one /network-instances/network-instance/vlans/vlan list entry.
+type OpenConfigVlan struct {
+	VlanID uint16 `json:"vlan-id"`
+	Config struct {
+		VlanID uint16 `json:"vlan-id"`
+		Name   string `json:"name,omitempty"`
+		Status string `json:"status"` // ACTIVE, SUSPENDED
+	} `json:"config"`
+	Members struct {
+		Member []OpenConfigVlanMember `json:"member,omitempty"`
+	} `json:"members"`
+}
+
+// OpenConfigVlanMember is an interface carrying a VLAN.
+type OpenConfigVlanMember struct {
+	InterfaceRef struct {
+		State struct {
+			Interface string `json:"interface"`
+		} `json:"state"`
+	} `json:"interface-ref"`
+}
+
+// To_openconfig_interfaces maps "show interface" and "show interface status" results
+// to the OpenConfig interface model, merging both by interface name. Either list may
+// be empty. Interfaces are named in long form, the way IOS names them natively.
+func To_openconfig_interfaces(details []InterfaceDetails, statuses []InterfaceStatus) OpenConfigInterfaces {
+	entries := make(map[string]*OpenConfigInterface)
+	entry := func(name string) *OpenConfigInterface {
+		key := shortInterfaceName(name)
+		if entries[key] == nil {
+			longName := ExpandInterfaceName(name)
+			entries[key] = &OpenConfigInterface{
+				Name:   longName,
+				Config: OpenConfigInterfaceConfig{Name: longName, Type: openConfigInterfaceType(longName), Enabled: true},
+			}
+		}
+		return entries[key]
+	}
+
+	for _, d := range details {
+		oc := entry(d.Interface)
+		oc.Config.Description = d.Description
+		oc.Config.Enabled = d.LinkStatus != "administratively down"
+		if mtu, err := strconv.ParseUint(d.Mtu, 10, 16); err == nil {
+			oc.Config.Mtu = uint16(mtu)
+		}
+		oc.State.OperStatus = "DOWN"
+		if d.LinkStatus == "up" && (d.ProtocolStatus == "" || d.ProtocolStatus == "up") {
+			oc.State.OperStatus = "UP"
+		}
+		oc.State.Counters = &OpenConfigInterfaceCounters{
+			InOctets:    parseCounter(d.BytesInput),
+			InPkts:      parseCounter(d.PacketsInput),
+			InErrors:    parseCounter(d.InputErrors),
+			InDiscards:  parseCounter(d.InputDrops),
+			InFcsErrors: parseCounter(d.CrcErrors),
+			OutOctets:   parseCounter(d.BytesOutput),
+			OutPkts:     parseCounter(d.PacketsOutput),
+			OutErrors:   parseCounter(d.OutputErrors),
+			OutDiscards: parseCounter(d.OutputDrops),
+		}
+		if oc.Config.Type == "iana-if-type:ethernetCsmacd" {
+			oc.Ethernet = openConfigEthernet(oc.Ethernet, d.Speed, d.Duplex)
+			if mac := normalizeMacAddress(d.MacAddress); mac != "" {
+				oc.Ethernet.State.MacAddress = openConfigMac(mac)
+			}
+		}
+	}
+
+	for _, s := range statuses {
+		oc := entry(s.Interface)
+		if s.Description != "" {
+			oc.Config.Description = s.Description
+		}
+		oc.Config.Enabled = s.Status != "disabled"
+		if oc.State.OperStatus == "" {
+			oc.State.OperStatus = "DOWN"
+			if s.Status == "connected" {
+				oc.State.OperStatus = "UP"
+			}
+		}
+		if oc.Config.Type != "iana-if-type:ethernetCsmacd" {
+			continue
+		}
+		oc.Ethernet = openConfigEthernet(oc.Ethernet, s.Speed, s.Duplex)
+		mode, accessVlan := "", uint16(0)
+		if s.VlanID == "trunk" {
+			mode = "TRUNK"
+		} else if vlan, err := strconv.ParseUint(s.VlanID, 10, 16); err == nil {
+			mode, accessVlan = "ACCESS", uint16(vlan)
+		}
+		if mode != "" {
+			oc.Ethernet.SwitchedVlan = &OpenConfigSwitchedVlan{}
+			oc.Ethernet.SwitchedVlan.Config.InterfaceMode = mode
+			oc.Ethernet.SwitchedVlan.Config.AccessVlan = accessVlan
+		}
+	}
+
+	var model OpenConfigInterfaces
+	for _, oc := range entries {
+		oc.State.Name, oc.State.Type, oc.State.Description = oc.Config.Name, oc.Config.Type, oc.Config.Description
+		oc.State.Enabled, oc.State.Mtu = oc.Config.Enabled, oc.Config.Mtu
+		oc.State.AdminStatus = "DOWN"
+		if oc.Config.Enabled {
+			oc.State.AdminStatus = "UP"
+		}
+		model.Interfaces.Interface = append(model.Interfaces.Interface, *oc)
+	}
+	sort.Slice(model.Interfaces.Interface, func(i, j int) bool {
+		return model.Interfaces.Interface[i].Name < model.Interfaces.Interface[j].Name
+	})
+	return model
+}
+
+// To_openconfig_vlans maps "show vlan" results to the OpenConfig VLAN model of the
+// default network instance. VLANs with a non-numeric ID are skipped.
+func To_openconfig_vlans(vlans []VlanInfo) OpenConfigVlans {
+	var model OpenConfigVlans
+	instance := OpenConfigNetworkInstance{Name: "default"}
+
+	for _, v := range vlans {
+		id, err := strconv.ParseUint(v.VLANID, 10, 16)
+		if err != nil {
+			continue
+		}
+		var oc OpenConfigVlan
+		oc.VlanID, oc.Config.VlanID, oc.Config.Name = uint16(id), uint16(id), v.VLANName
+		oc.Config.Status = "ACTIVE"
+		if strings.Contains(v.Status, "suspend") || strings.Contains(v.Status, "shutdown") {
+			oc.Config.Status = "SUSPENDED"
+		}
+		for _, port := range v.Ports {
+			var member OpenConfigVlanMember
+			member.InterfaceRef.State.Interface = ExpandInterfaceName(port)
+			oc.Members.Member = append(oc.Members.Member, member)
+		}
+		instance.Vlans.Vlan = append(instance.Vlans.Vlan, oc)
+	}
+	model.NetworkInstances.NetworkInstance = []OpenConfigNetworkInstance{instance}
+	return model
+}
+
+// openConfigInterfaceType maps a long interface name to its IANA interface type.
+func openConfigInterfaceType(name string) string {
+	switch {
+	case strings.HasPrefix(name, "Vlan"):
+		return "iana-if-type:l3ipvlan"
+	case strings.HasPrefix(name, "Port-channel"):
+		return "iana-if-type:ieee8023adLag"
+	case strings.HasPrefix(name, "Loopback"):
+		return "iana-if-type:softwareLoopback"
+	case strings.HasPrefix(name, "Tunnel"):
+		return "iana-if-type:tunnel"
+	case strings.Contains(name, "Ethernet") || strings.HasPrefix(name, "mgmt"):
+		return "iana-if-type:ethernetCsmacd"
+	}
+	return "iana-if-type:other"
+}
+
+// openConfigEthernet fills speed and duplex from CLI values such as "a-1000",
+// "1000Mb/s", "a-full" or "Full-duplex", keeping values already set.
+func openConfigEthernet(eth *OpenConfigEthernet, speed string, duplex string) *OpenConfigEthernet {
+	if eth == nil {
+		eth = &OpenConfigEthernet{}
+	}
+	speed, duplex = strings.ToLower(speed), strings.ToLower(duplex)
+	if strings.HasPrefix(speed, "a-") || strings.HasPrefix(speed, "auto") {
+		eth.Config.AutoNegotiate = true
+	}
+	if eth.Config.PortSpeed == "" {
+		speeds := []struct{ cli, oc string }{
+			{"100000", "SPEED_100GB"}, {"100g", "SPEED_100GB"}, {"40000", "SPEED_40GB"}, {"40g", "SPEED_40GB"},
+			{"25000", "SPEED_25GB"}, {"25g", "SPEED_25GB"}, {"10000", "SPEED_10GB"}, {"10g", "SPEED_10GB"},
+			{"1000", "SPEED_1GB"}, {"1g", "SPEED_1GB"}, {"100", "SPEED_100MB"}, {"10", "SPEED_10MB"},
+		}
+		number := strings.TrimPrefix(speed, "a-")
+		for _, s := range speeds {
+			if number == s.cli || strings.HasPrefix(number, s.cli+"mb") || strings.HasPrefix(number, s.cli+"b/s") {
+				eth.Config.PortSpeed = "openconfig-if-ethernet:" + s.oc
+				break
+			}
+		}
+	}
+	if eth.Config.DuplexMode == "" {
+		duplex = strings.TrimPrefix(duplex, "a-")
+		if strings.HasPrefix(duplex, "full") {
+			eth.Config.DuplexMode = "FULL"
+		} else if strings.HasPrefix(duplex, "half") {
+			eth.Config.DuplexMode = "HALF"
+		}
+	}
+	return eth
+}
+
+// openConfigMac turns a dotted MAC address (aabb.ccdd.eeff) into aa:bb:cc:dd:ee:ff.
+func openConfigMac(dotted string) string {
+	hex := strings.ReplaceAll(dotted, ".", "")
+	var parts []string
+	for i := 0; i+2 <= len(hex); i += 2 {
+		parts = append(parts, hex[i:i+2])
+	}
+	return strings.Join(parts, ":")
+}
+
+// parseCounter parses a CLI counter, returning 0 if it is missing.
+func parseCounter(value string) uint64 {
+	n, _ := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	return n
+}
@@ -0,0 +1,129 @@
+package cisco
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldCoverage tells how many parsed records had a field filled in.
+type FieldCoverage struct {
+	Field     string // e.g., "Duplex", or "Modules.Available" for nested records
+	Populated int
+	Records   int
+}
+
+// ParserCoverage reports how well a parser handled output from a given device.
+type ParserCoverage struct {
+	Command string
+	Records int
+	Err     error
+	Fields  []FieldCoverage
+}
+
+// Missing returns the fields no record had filled in.
+func (c ParserCoverage) Missing() []string {
+	var missing []string
+	for _, field := range c.Fields {
+		if field.Populated == 0 {
+			missing = append(missing, field.Field)
+		}
+	}
+	return missing
+}
+
+// Ratio returns the share of fields that were filled in at least once, from 0 to 1.
+func (c ParserCoverage) Ratio() float64 {
+	if len(c.Fields) == 0 {
+		return 0
+	}
+	return float64(len(c.Fields)-len(c.Missing())) / float64(len(c.Fields))
+}
+
+// coverageParsers override the registered parser where it returns a flattened form,
+// so coverage is measured against the full record type.
+var coverageParsers = map[string]func(rawOutput string) (any, error){
+	"show version": func(rawOutput string) (any, error) {
+		return ParseVersionStruct(rawOutput)
+	},
+}
+
+// CheckCompatibility runs the parsers on output captured from a platform variant,
+// keyed by command, and reports for each command which fields could and couldn't be
+// extracted. Fields that are never populated usually mean the variant prints them
+// differently, so the coverage matrix shows whether the library supports a device
+// before it is deployed. Results are sorted by command.
+func CheckCompatibility(outputs map[string]string) []ParserCoverage {
+	var report []ParserCoverage
+
+	for command, rawOutput := range outputs {
+		coverage := ParserCoverage{Command: command}
+
+		data, stats := SafeParse(command, rawOutput)
+		coverage.Records, coverage.Err = stats.Records, stats.Err
+		if parse, ok := coverageParsers[command]; ok && stats.Err == nil {
+			data, _ = parse(rawOutput)
+		}
+
+		fields := make(map[string]*FieldCoverage)
+		var order []string
+		collectCoverage(reflect.ValueOf(data), "", fields, &order)
+		for _, name := range order {
+			coverage.Fields = append(coverage.Fields, *fields[name])
+		}
+
+		report = append(report, coverage)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Command < report[j].Command
+	})
+	return report
+}
+
+// collectCoverage counts populated fields of a parsed value: a struct is one record,
+// a slice holds one record per element, and struct fields holding slices of structs
+// are counted as nested records under "<Field>.".
+func collectCoverage(value reflect.Value, prefix string, fields map[string]*FieldCoverage, order *[]string) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			collectCoverage(value.Index(i), prefix, fields, order)
+		}
+	case reflect.Struct:
+		valueType := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			field := valueType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldValue := value.Field(i)
+
+			if fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() == reflect.Struct {
+				collectCoverage(fieldValue, prefix+field.Name+".", fields, order)
+				continue
+			}
+
+			name := prefix + field.Name
+			coverage, ok := fields[name]
+			if !ok {
+				coverage = &FieldCoverage{Field: name}
+				fields[name] = coverage
+				*order = append(*order, name)
+			}
+			coverage.Records++
+			if !fieldValue.IsZero() {
+				coverage.Populated++
+			}
+		}
+	}
+}
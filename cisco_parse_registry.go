@@ -0,0 +1,82 @@
+package cisco
+
+import (
+	"fmt"
+)
+
+// platformParser is the key of a parser registered for one platform.
+type platformParser struct {
+	platform Platform
+	command  string
+}
+
+// platformParsers hold the parsers of commands whose output differs by platform. They
+// take precedence over fixtureParsers, which parse the output of any platform.
+var platformParsers = map[platformParser]func(rawOutput string) (any, int, error){}
+
+func init() {
+	for _, platform := range []Platform{PlatformIOS, PlatformIOSXE, PlatformNXOS, PlatformIOSXR, PlatformASA, PlatformSmallBusiness} {
+		platformParsers[platformParser{platform, "show interface"}] = func(rawOutput string) (any, int, error) {
+			data, err := ParseInterfacesFor(platform, rawOutput)
+			return data, len(data), err
+		}
+	}
+}
+
+// RegisterPlatformParser registers a parser for the output of command on platform, so
+// Parse and the Show_* functions of clients on that platform use it instead of the
+// built-in one, e.g. for a platform variant the built-in parser does not cover. The
+// parser must return the type the built-in one does, e.g. []InterfaceDetails for
+// "show interface", or the Show_* functions fail with an error. PlatformUnknown
+// registers the parser for every platform without one of its own, like
+// RegisterParser. Register parsers during initialization, before any parsing starts;
+// the registry is not locked.
+func RegisterPlatformParser(platform Platform, command string, parse func(rawOutput string) (any, error)) {
+	if platform == PlatformUnknown {
+		RegisterParser(command, parse)
+		return
+	}
+	platformParsers[platformParser{platform, command}] = func(rawOutput string) (any, int, error) {
+		data, err := parse(rawOutput)
+		return data, recordCount(data), err
+	}
+}
+
+// Parse parses the raw output of command from a device of the given platform with the
+// parser registered for that platform, or else the one for every platform. Use
+// PlatformUnknown when the platform is not known.
+func Parse(platform Platform, command string, rawOutput string) (any, error) {
+	parse, ok := parserFor(platform, command)
+	if !ok {
+		return nil, fmt.Errorf("no parser registered for command %q", command)
+	}
+	data, _, err := parse(rawOutput)
+	return data, err
+}
+
+// parserFor returns the parser of command for platform, falling back to the one for
+// every platform.
+func parserFor(platform Platform, command string) (func(rawOutput string) (any, int, error), bool) {
+	if parse, ok := platformParsers[platformParser{platform, command}]; ok {
+		return parse, true
+	}
+	parse, ok := fixtureParsers[command]
+	return parse, ok
+}
+
+// parseWith parses the output of command with the parser registered for platform, or
+// with builtin when there is none. Parsers registered for every platform with
+// RegisterParser are left to SafeParse and Parse, as they may return another type.
+func parseWith[T any](platform Platform, command string, rawOutput string, builtin func(rawOutput string) (T, error)) (T, error) {
+	parse, ok := platformParsers[platformParser{platform, command}]
+	if !ok {
+		return builtin(rawOutput)
+	}
+
+	data, _, err := parse(rawOutput)
+	typed, ok := data.(T)
+	if !ok && data != nil {
+		return typed, fmt.Errorf("parser registered for %q on %s returned %T instead of %T", command, platform, data, typed)
+	}
+	return typed, err
+}
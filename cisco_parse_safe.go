@@ -0,0 +1,98 @@
+package cisco
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrParserPanic is reported by SafeParse when a parser panicked on its input.
+var ErrParserPanic = errors.New("parser panicked")
+
+// SafeParse runs the registered parser for command on arbitrary input and never
+// panics. The parsed data is returned together with the stats of the run; if the
+// parser failed or panicked, the data is whatever the parser produced (possibly nil)
+// and stats.Err explains why. Parsers skip lines they cannot make sense of, so
+// truncated or mangled output yields the records that could be read.
+func SafeParse(command string, rawOutput string) (result any, stats ParseStats) {
+	stats = ParseStats{Command: command, Bytes: len(rawOutput)}
+
+	parse, ok := fixtureParsers[command]
+	if !ok {
+		stats.Err = fmt.Errorf("no parser registered for command %q", command)
+		return nil, stats
+	}
+
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			stats.Err = fmt.Errorf("%w on %q output: %v", ErrParserPanic, command, r)
+		}
+		stats.Duration = time.Since(start)
+	}()
+
+	result, stats.Records, stats.Err = parse(rawOutput)
+	return result, stats
+}
+
+// ParsableCommands returns the commands SafeParse and BenchmarkParser have parsers for, sorted.
+func ParsableCommands() []string {
+	commands := make([]string, 0, len(fixtureParsers))
+	for command := range fixtureParsers {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// columnField returns the trimmed text of a fixed-width column spanning [start, end)
+// of line, or up to the end of the line when end is negative. Columns that are cut
+// short or missing yield the part that exists, or "", instead of a slice bounds panic.
+func columnField(line string, start int, end int) string {
+	if start < 0 || start >= len(line) {
+		return ""
+	}
+	if end < 0 || end > len(line) {
+		end = len(line)
+	}
+	if end <= start {
+		return ""
+	}
+	return strings.TrimSpace(line[start:end])
+}
+
+// headerColumns locates column titles in the header line of a fixed-width table and
+// returns the [start, end) span of each one found; end is the start of the next
+// column, or -1 for the last. A title only matches as whole words, so "ID" does not
+// match inside "SSID". Titles missing from the header are left out of the map.
+func headerColumns(headerLine string, titles ...string) map[string][2]int {
+	starts := make(map[string]int)
+	for _, title := range titles {
+		for offset := 0; offset < len(headerLine); {
+			i := strings.Index(headerLine[offset:], title)
+			if i == -1 {
+				break
+			}
+			start, end := offset+i, offset+i+len(title)
+			if (start == 0 || headerLine[start-1] == ' ') && (end == len(headerLine) || headerLine[end] == ' ') {
+				starts[title] = start
+				break
+			}
+			offset = end
+		}
+	}
+
+	columns := make(map[string][2]int, len(starts))
+	for title, start := range starts {
+		end := -1
+		for _, other := range starts {
+			if other > start && (end == -1 || other < end) {
+				end = other
+			}
+		}
+		columns[title] = [2]int{start, end}
+	}
+	return columns
+}
@@ -0,0 +1,288 @@
+package cisco
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ParseStats describes a single parser run: which command's output was parsed,
+// how much input it consumed, how many records it produced and how long it took.
+type ParseStats struct {
+	Switch   string // Empty for parser runs not tied to a switch, e.g. BenchmarkParser
+	Command  string
+	Bytes    int
+	Records  int
+	Duration time.Duration
+	Err      error
+}
+
+// BytesPerSecond returns the parser throughput in input bytes per second.
+func (s ParseStats) BytesPerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Bytes) / s.Duration.Seconds()
+}
+
+// RecordsPerSecond returns the parser throughput in parsed records per second.
+func (s ParseStats) RecordsPerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Records) / s.Duration.Seconds()
+}
+
+var (
+	parseHookMu sync.RWMutex
+	parseHook   func(ParseStats)
+)
+
+// SetParseHook installs a function that is called after every parser run made by
+// the Show_* functions, so callers can export per-parser timing to their metrics
+// system. Passing nil removes the hook. The hook must be safe for concurrent use.
+func SetParseHook(hook func(ParseStats)) {
+	parseHookMu.Lock()
+	defer parseHookMu.Unlock()
+	parseHook = hook
+}
+
+// reportParse sends the stats of a finished parser run to the installed hook, if any,
+// and returns err wrapped in a ParseError carrying the raw output, or nil.
+func reportParse(switch_hostname string, command string, rawOutput string, records int, start time.Time, err error) error {
+	parseHookMu.RLock()
+	hook := parseHook
+	parseHookMu.RUnlock()
+
+	if hook != nil {
+		hook(ParseStats{
+			Switch:   switch_hostname,
+			Command:  command,
+			Bytes:    len(rawOutput),
+			Records:  records,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+
+	if err == nil {
+		return nil
+	}
+	return &ParseError{Switch: switch_hostname, Command: command, RawOutput: rawOutput, Err: err}
+}
+
+// fixtureParsers runs the parser for a command against raw output and returns the
+// parsed data and the number of records produced. It is used by BenchmarkParser and SafeParse.
+var fixtureParsers = map[string]func(rawOutput string) (any, int, error){
+	"show running-config": func(rawOutput string) (any, int, error) {
+		data, err := ParseInterfaceConfig(rawOutput)
+		return data, len(data), err
+	},
+	"show version": func(rawOutput string) (any, int, error) {
+		data, err := ParseVersionInfo(rawOutput)
+		return data, len(data), err
+	},
+	"show interface": func(rawOutput string) (any, int, error) {
+		data, err := ParseInterfaces(rawOutput)
+		return data, len(data), err
+	},
+	"show interface status": func(rawOutput string) (any, int, error) {
+		data, err := ParseInterfaceStatus(rawOutput)
+		return data, len(data), err
+	},
+	"show mac address-table": func(rawOutput string) (any, int, error) {
+		data, err := ParseMacAddressTable(rawOutput)
+		return data, len(data), err
+	},
+	"show vlan": func(rawOutput string) (any, int, error) {
+		data, err := ParseVlanInfo(rawOutput)
+		return data, len(data), err
+	},
+	"show power inline": func(rawOutput string) (any, int, error) {
+		modules, interfaces, err := ParsePowerInline(rawOutput)
+		return PowerInline{Modules: modules, Interfaces: interfaces}, len(modules) + len(interfaces), err
+	},
+	"show cdp neighbors": func(rawOutput string) (any, int, error) {
+		data, err := ParseCdpNeighbors(rawOutput)
+		return data, len(data), err
+	},
+	"show lldp neighbors": func(rawOutput string) (any, int, error) {
+		data, err := ParseLldpNeighbors(rawOutput)
+		return data, len(data), err
+	},
+	"show interfaces status err-disabled": func(rawOutput string) (any, int, error) {
+		data := ParseErrDisabledStatus(rawOutput)
+		return data, len(data), nil
+	},
+	"show spanning-tree blockedports": func(rawOutput string) (any, int, error) {
+		data, err := ParseSpanningTreeBlockedPorts(rawOutput)
+		return data, len(data), err
+	},
+	"show spanning-tree inconsistentports": func(rawOutput string) (any, int, error) {
+		data, err := ParseSpanningTreeInconsistentPorts(rawOutput)
+		return data, len(data), err
+	},
+	"show ip dhcp binding": func(rawOutput string) (any, int, error) {
+		data, err := ParseDhcpBinding(rawOutput)
+		return data, len(data), err
+	},
+	"show tacacs": func(rawOutput string) (any, int, error) {
+		data, err := ParseTacacs(rawOutput)
+		return data, len(data), err
+	},
+	"show crypto pki certificates": func(rawOutput string) (any, int, error) {
+		data, err := ParsePkiCertificates(rawOutput)
+		return data, len(data), err
+	},
+	"show ip ssh": func(rawOutput string) (any, int, error) {
+		data, err := ParseIPSSH(rawOutput)
+		return data, 1, err
+	},
+	"show line": func(rawOutput string) (any, int, error) {
+		data, err := ParseTerminalLines(rawOutput)
+		return data, len(data), err
+	},
+	"show clock detail": func(rawOutput string) (any, int, error) {
+		data, err := ParseClock(rawOutput)
+		return data, 1, err
+	},
+	"show spanning-tree root": func(rawOutput string) (any, int, error) {
+		data, err := ParseSpanningTreeRoot(rawOutput)
+		return data, len(data), err
+	},
+	"show ap summary": func(rawOutput string) (any, int, error) {
+		data, err := ParseApSummary(rawOutput)
+		return data, len(data), err
+	},
+	"show wlan summary": func(rawOutput string) (any, int, error) {
+		data, err := ParseWlanSummary(rawOutput)
+		return data, len(data), err
+	},
+	"show wireless client summary": func(rawOutput string) (any, int, error) {
+		data, err := ParseClientSummary(rawOutput)
+		return data, len(data), err
+	},
+	"show failover": func(rawOutput string) (any, int, error) {
+		data, err := ParseFailover(rawOutput)
+		return data, 1, err
+	},
+	"show conn count": func(rawOutput string) (any, int, error) {
+		data, err := ParseConnCount(rawOutput)
+		return data, 1, err
+	},
+	"show lldp neighbors detail": func(rawOutput string) (any, int, error) {
+		data, err := ParseLldpNeighborsDetail(rawOutput)
+		return data, len(data), err
+	},
+	"show ip dhcp snooping statistics detail": func(rawOutput string) (any, int, error) {
+		data, err := ParseDhcpSnoopingStatistics(rawOutput)
+		return data, 1, err
+	},
+	"show ip arp inspection statistics": func(rawOutput string) (any, int, error) {
+		data, err := ParseArpInspectionStatistics(rawOutput)
+		return data, len(data), err
+	},
+	"show ip arp inspection interfaces": func(rawOutput string) (any, int, error) {
+		data, err := ParseArpInspectionInterfaces(rawOutput)
+		return data, len(data), err
+	},
+	"show interfaces description": func(rawOutput string) (any, int, error) {
+		data, err := ParseInterfaceDescriptions(rawOutput)
+		return data, len(data), err
+	},
+	"show errdisable recovery": func(rawOutput string) (any, int, error) {
+		data := ParseErrDisableRecovery(rawOutput)
+		return data, len(data), nil
+	},
+	"show logging | include ERR_DISABLE": func(rawOutput string) (any, int, error) {
+		data := ParseErrDisableLog(rawOutput)
+		return data, len(data), nil
+	},
+	"show access-session interface": func(rawOutput string) (any, int, error) {
+		data := ParseAuthSessions(rawOutput)
+		return data, len(data), nil
+	},
+	"show privilege": func(rawOutput string) (any, int, error) {
+		data, err := ParsePrivilege(rawOutput)
+		return data, 1, err
+	},
+	"show ip interface brief": func(rawOutput string) (any, int, error) {
+		data, err := ParseIPInterfaceBrief(rawOutput)
+		return data, len(data), err
+	},
+	"show ip route": func(rawOutput string) (any, int, error) {
+		data, err := ParseIPRoute(rawOutput)
+		return data, len(data), err
+	},
+	"show ip arp": func(rawOutput string) (any, int, error) {
+		data, err := ParseArp(rawOutput)
+		return data, len(data), err
+	},
+	"show arp": func(rawOutput string) (any, int, error) {
+		data, err := ParseArp(rawOutput)
+		return data, len(data), err
+	},
+	"show inventory": func(rawOutput string) (any, int, error) {
+		data, err := ParseInventory(rawOutput)
+		return data, len(data), err
+	},
+	"show env all": func(rawOutput string) (any, int, error) {
+		data, err := ParseEnvironment(rawOutput)
+		return data, len(data.Fans) + len(data.PowerSupplies) + len(data.Temperatures), err
+	},
+	"show interfaces trunk": func(rawOutput string) (any, int, error) {
+		data, err := ParseInterfacesTrunk(rawOutput)
+		return data, len(data), err
+	},
+	"show logging": func(rawOutput string) (any, int, error) {
+		data, err := ParseLogging(rawOutput)
+		return data, len(data), err
+	},
+	"show ip ospf neighbor": func(rawOutput string) (any, int, error) {
+		data, err := ParseOSPFNeighbors(rawOutput)
+		return data, len(data), err
+	},
+	"show ip bgp summary": func(rawOutput string) (any, int, error) {
+		data, err := ParseBGPSummary(rawOutput)
+		return data, len(data), err
+	},
+	"show vlan brief": func(rawOutput string) (any, int, error) {
+		data, err := ParseVlanInfo(rawOutput)
+		return data, len(data), err
+	},
+	"show switch": func(rawOutput string) (any, int, error) {
+		data, err := ParseSwitch(rawOutput)
+		return data, len(data), err
+	},
+}
+
+// BenchmarkParser parses rawOutput with the parser for command the given number of
+// times and returns the aggregated stats. When rawOutput is empty the matching
+// entry from Fixtures is used, which makes it easy to compare parser throughput
+// across library versions or against output captured from new platform variants.
+func BenchmarkParser(command string, rawOutput string, iterations int) (ParseStats, error) {
+	parse, ok := fixtureParsers[command]
+	if !ok {
+		return ParseStats{}, fmt.Errorf("no parser registered for command %q", command)
+	}
+	if rawOutput == "" {
+		rawOutput = Fixtures[command]
+	}
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	stats := ParseStats{Command: command}
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		_, records, err := parse(rawOutput)
+		if err != nil {
+			return ParseStats{}, fmt.Errorf("parsing %q output: %w", command, err)
+		}
+		stats.Bytes += len(rawOutput)
+		stats.Records += records
+	}
+	stats.Duration = time.Since(start)
+
+	return stats, nil
+}
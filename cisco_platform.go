@@ -0,0 +1,185 @@
+package cisco
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Platform identifies the operating system family of a device.
+type Platform string
+
+const (
+	PlatformUnknown Platform = ""
+	PlatformIOS     Platform = "ios"
+	PlatformIOSXE   Platform = "ios-xe"
+	PlatformNXOS    Platform = "nx-os"
+	PlatformIOSXR   Platform = "ios-xr"
+	PlatformASA     Platform = "asa"
+
+	// PlatformSmallBusiness covers the Small Business SG and CBS switches, whose CLI
+	// resembles IOS but differs in paging and several show commands.
+	PlatformSmallBusiness Platform = "small-business"
+)
+
+// detectPlatform guesses the platform from "show version" output.
+func detectPlatform(rawOutput string) Platform {
+	switch {
+	case strings.Contains(rawOutput, "Cisco Nexus Operating System") || strings.Contains(rawOutput, "NX-OS"):
+		return PlatformNXOS
+	case strings.Contains(rawOutput, "IOS XR") || strings.Contains(rawOutput, "IOS-XR"):
+		return PlatformIOSXR
+	case strings.Contains(rawOutput, "Adaptive Security Appliance"):
+		return PlatformASA
+	case strings.Contains(rawOutput, "Active-image:"):
+		return PlatformSmallBusiness
+	case strings.Contains(rawOutput, "IOS-XE") || strings.Contains(rawOutput, "IOS XE"):
+		return PlatformIOSXE
+	case strings.Contains(rawOutput, "Cisco IOS Software") || strings.Contains(rawOutput, "IOS (tm)"):
+		return PlatformIOS
+	}
+	return PlatformUnknown
+}
+
+// Detect_platform connects to a switch, runs "show version" and returns the platform
+// it reports along with the parsed output. The platform is remembered with
+// SetPlatform, so later connections to the host use its dialect and parsers.
+func Detect_platform(switch_hostname string) (Platform, VersionInfo, error) {
+	return Detect_platform_context(context.Background(), switch_hostname)
+}
+
+// Detect_platform_context is Detect_platform with a context that cancels the command.
+func Detect_platform_context(ctx context.Context, switch_hostname string) (Platform, VersionInfo, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return PlatformUnknown, VersionInfo{}, err
+	}
+	defer client.Close()
+
+	platform, info, err := client.DetectPlatformContext(ctx)
+	if err == nil && platform != PlatformUnknown {
+		SetPlatform(switch_hostname, platform)
+	}
+	return platform, info, err
+}
+
+// DetectPlatform runs "show version" once and returns the platform it reports along
+// with the parsed output. A platform other than PlatformUnknown becomes the client's
+// Platform, which selects the dialect of its sessions and the formats its parsers
+// expect. Sessions already running keep the platform they started with.
+func (c *Client) DetectPlatform() (Platform, VersionInfo, error) {
+	return c.DetectPlatformContext(context.Background())
+}
+
+// DetectPlatformContext is DetectPlatform with a context that cancels the command.
+func (c *Client) DetectPlatformContext(ctx context.Context) (Platform, VersionInfo, error) {
+	info, err := c.ShowVersionStructContext(ctx)
+	if err != nil {
+		return PlatformUnknown, VersionInfo{}, err
+	}
+	if info.Platform != PlatformUnknown {
+		c.platformMu.Lock()
+		c.Platform = info.Platform
+		c.platformMu.Unlock()
+	}
+	return info.Platform, info, nil
+}
+
+// platform returns the client's Platform, which DetectPlatform may change while other
+// goroutines use the client.
+func (c *Client) platform() Platform {
+	c.platformMu.RLock()
+	defer c.platformMu.RUnlock()
+	return c.Platform
+}
+
+var platforms struct {
+	sync.RWMutex
+	byHost map[string]Platform
+}
+
+// SetPlatform sets the platform of a host, so connections to it use that platform's
+// dialect, e.g. SetPlatform("xr01", PlatformIOSXR) for commit-based configuration.
+// Hosts without a platform are driven as IOS.
+func SetPlatform(switch_hostname string, platform Platform) {
+	platforms.Lock()
+	defer platforms.Unlock()
+	if platforms.byHost == nil {
+		platforms.byHost = make(map[string]Platform)
+	}
+	platforms.byHost[switch_hostname] = platform
+}
+
+var terminalSetups struct {
+	sync.RWMutex
+	byPlatform map[Platform][]string
+}
+
+// SetTerminalSetup replaces the commands that prepare every shell session on a
+// platform, e.g. to add "terminal exec prompt no-timestamp". An empty list sends
+// nothing, for devices that reject the defaults; nil restores the defaults.
+func SetTerminalSetup(platform Platform, commands []string) {
+	terminalSetups.Lock()
+	defer terminalSetups.Unlock()
+	if commands == nil {
+		delete(terminalSetups.byPlatform, platform)
+		return
+	}
+	if terminalSetups.byPlatform == nil {
+		terminalSetups.byPlatform = make(map[Platform][]string)
+	}
+	terminalSetups.byPlatform[platform] = slices.Clone(commands)
+}
+
+// terminalSetup returns the commands that prepare a shell session: no "--More--"
+// paging and, where the platform allows it, the widest terminal, so long lines are
+// not wrapped at 80 characters in the middle of a table column.
+func (p Platform) terminalSetup() []string {
+	if p == PlatformUnknown {
+		p = PlatformIOS
+	}
+
+	terminalSetups.RLock()
+	commands, ok := terminalSetups.byPlatform[p]
+	terminalSetups.RUnlock()
+	if ok {
+		return commands
+	}
+
+	switch p {
+	case PlatformSmallBusiness:
+		return []string{"terminal datadump"}
+	case PlatformASA:
+		return []string{"terminal pager 0"}
+	case PlatformNXOS:
+		// dont-ask answers confirmation prompts with their default instead of waiting.
+		return []string{"terminal length 0", "terminal width 511", "terminal dont-ask"}
+	case PlatformIOSXR:
+		return []string{"terminal length 0", "terminal width 512"}
+	}
+	return []string{"terminal length 0", "terminal width 511"}
+}
+
+// sessionSetup returns the commands sent at the start of every shell session: enable
+// and its password on ASA, whose logins always start in user EXEC, and on clients
+// raised with Enable, then the terminal setup.
+func (c *Client) sessionSetup() []string {
+	var commands []string
+	switch {
+	case c.platform() == PlatformASA:
+		commands = append(commands, "enable", c.enablePassword)
+	case c.escalate.Load():
+		// IOS asks up to three times; after a wrong password the empty answers use up
+		// the other attempts, so the commands that follow are not taken for passwords.
+		commands = append(commands, "enable", c.enablePassword, "", "")
+	}
+	return append(commands, c.platform().terminalSetup()...)
+}
+
+// platformOf returns the platform set for a host with SetPlatform.
+func platformOf(switch_hostname string) Platform {
+	platforms.RLock()
+	defer platforms.RUnlock()
+	return platforms.byHost[switch_hostname]
+}
@@ -0,0 +1,363 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPoolMaxPerHost is the number of connections a Pool keeps to one switch
+	// when MaxPerHost is not set. Every connection holds a VTY line on the switch.
+	DefaultPoolMaxPerHost = 1
+
+	// DefaultPoolIdleTimeout is how long a Pool keeps an unused connection open when
+	// IdleTimeout is not set. It outlasts a 5 minute polling cycle.
+	DefaultPoolIdleTimeout = 10 * time.Minute
+
+	// DefaultPoolHealthInterval is how often a Pool checks its idle connections when
+	// HealthInterval is not set.
+	DefaultPoolHealthInterval = time.Minute
+)
+
+// keepaliveTimeout is how long a connection gets to answer a keepalive before it is
+// considered dead.
+const keepaliveTimeout = 10 * time.Second
+
+// ErrPoolClosed is returned by Pool.Get after the pool was closed.
+var ErrPoolClosed = errors.New("connection pool closed")
+
+// PoolStats counts the connections of a Pool.
+type PoolStats struct {
+	Open    int // Connections open, idle or in use
+	Idle    int // Connections waiting in the pool
+	Dialed  int // Connections dialed since the pool was created
+	Reused  int // Gets served with an idle connection
+	Evicted int // Idle connections closed for being idle too long or dead
+}
+
+// Pool keeps SSH connections to switches open between uses, keyed by hostname, so
+// repeated polling does not pay for a TCP and SSH handshake every time. Idle
+// connections are checked with an SSH keepalive before they are handed out and
+// every HealthInterval, and closed after IdleTimeout. A zero Pool is ready to use.
+type Pool struct {
+	// MaxPerHost caps the connections open to one switch, in use or idle. Get
+	// waits for a connection to be returned when the cap is reached. Zero means
+	// DefaultPoolMaxPerHost.
+	MaxPerHost int
+
+	// IdleTimeout closes connections unused for that long. Zero means
+	// DefaultPoolIdleTimeout.
+	IdleTimeout time.Duration
+
+	// HealthInterval is how often idle connections are checked and expired. Zero
+	// means DefaultPoolHealthInterval.
+	HealthInterval time.Duration
+
+	// Dial opens a new connection to a switch, e.g. to log in with other credentials
+	// than those of the environment. Nil means ConnectContext.
+	Dial func(ctx context.Context, switch_hostname string) (*Client, error)
+
+	mu     sync.Mutex
+	hosts  map[string]*poolHost
+	owners map[*Client]*poolHost
+	stats  PoolStats
+	closed bool
+	stop   chan struct{}
+}
+
+// poolHost holds the connections of one switch. A slot is taken for every open
+// connection, so the slots channel caps them at MaxPerHost, and idle connections
+// wait in a channel of the same size.
+type poolHost struct {
+	slots chan struct{}
+	idle  chan pooledClient
+}
+
+type pooledClient struct {
+	client *Client
+	since  time.Time
+}
+
+// NewPool returns a Pool keeping up to maxPerHost connections per switch; zero means
+// DefaultPoolMaxPerHost. The pool must be closed when no longer needed.
+func NewPool(maxPerHost int) *Pool {
+	return &Pool{MaxPerHost: maxPerHost}
+}
+
+// Get returns a connection to the switch: an idle one that still answers a keepalive,
+// or a new one. It waits while MaxPerHost connections to the switch are in use. The
+// client must be handed back with Put, or Discard when it is broken, and not closed.
+func (p *Pool) Get(ctx context.Context, switch_hostname string) (*Client, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrPoolClosed
+	}
+	host := p.host(switch_hostname)
+	p.startHealthChecks()
+	p.mu.Unlock()
+
+	for {
+		// Prefer an idle connection over dialing a new one.
+		var pooled pooledClient
+		var reused bool
+		select {
+		case pooled = <-host.idle:
+			reused = true
+		default:
+			select {
+			case pooled = <-host.idle:
+				reused = true
+			case host.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%s :: waiting for a pooled connection :: %w", switch_hostname, ctx.Err())
+			}
+		}
+
+		if reused {
+			// An idle connection holds its slot already.
+			if !pooled.client.alive(keepaliveTimeout) {
+				pooled.client.logf(slog.LevelWarn, "%s :: Pool :: Idle connection is dead, dropping it", switch_hostname)
+				p.count(func(stats *PoolStats) { stats.Evicted++ })
+				p.Discard(pooled.client)
+				continue
+			}
+			p.count(func(stats *PoolStats) { stats.Reused++ })
+			return pooled.client, nil
+		}
+
+		client, err := p.dial(ctx, switch_hostname)
+		if err != nil {
+			<-host.slots
+			return nil, err
+		}
+
+		p.mu.Lock()
+		p.owners[client] = host
+		p.stats.Open++
+		p.stats.Dialed++
+		p.mu.Unlock()
+		return client, nil
+	}
+}
+
+// dial opens a new connection with Dial, or ConnectContext when it is not set.
+func (p *Pool) dial(ctx context.Context, switch_hostname string) (*Client, error) {
+	if p.Dial != nil {
+		return p.Dial(ctx, switch_hostname)
+	}
+	return connectToSwitchContext(ctx, switch_hostname)
+}
+
+// Put hands a connection back to the pool for reuse, or closes it when its keepalives
+// found it unhealthy.
+func (p *Pool) Put(client *Client) {
+	p.mu.Lock()
+	host, ok := p.owners[client]
+	if ok && !p.closed && client.Healthy() {
+		// Never blocks: the connection holds one of the slots the channel is sized
+		// for. Sent under the lock so Close cannot miss it.
+		host.idle <- pooledClient{client: client, since: time.Now()}
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	if ok {
+		p.Discard(client)
+	}
+}
+
+// Discard closes a connection taken from the pool instead of handing it back, e.g.
+// after its commands failed in a way that points at the connection.
+func (p *Pool) Discard(client *Client) {
+	p.mu.Lock()
+	host, ok := p.owners[client]
+	if ok {
+		delete(p.owners, client)
+		p.stats.Open--
+	}
+	p.mu.Unlock()
+
+	client.Close()
+	if ok {
+		<-host.slots
+	}
+}
+
+// Do runs fn on a pooled connection to the switch and hands the connection back, or
+// discards it when fn failed and the connection no longer answers.
+func (p *Pool) Do(ctx context.Context, switch_hostname string, fn func(client *Client) error) error {
+	client, err := p.Get(ctx, switch_hostname)
+	if err != nil {
+		return err
+	}
+	err = fn(client)
+	if err != nil && (ctx.Err() != nil || !client.alive(keepaliveTimeout)) {
+		p.Discard(client)
+		return err
+	}
+	p.Put(client)
+	return err
+}
+
+// Stats returns the connection counts of the pool.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	for _, host := range p.hosts {
+		stats.Idle += len(host.idle)
+	}
+	return stats
+}
+
+// Close closes the idle connections and stops the health checks. Connections in use
+// are closed when they are put back.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	if p.stop != nil {
+		close(p.stop)
+	}
+	hosts := make([]*poolHost, 0, len(p.hosts))
+	for _, host := range p.hosts {
+		hosts = append(hosts, host)
+	}
+	p.mu.Unlock()
+
+	for _, host := range hosts {
+		for _, pooled := range drainIdle(host) {
+			p.Discard(pooled.client)
+		}
+	}
+	return nil
+}
+
+// host returns the connections of a switch, creating the entry on first use. p.mu
+// must be held.
+func (p *Pool) host(switch_hostname string) *poolHost {
+	if p.hosts == nil {
+		p.hosts = make(map[string]*poolHost)
+		p.owners = make(map[*Client]*poolHost)
+	}
+	host, ok := p.hosts[switch_hostname]
+	if !ok {
+		max := p.MaxPerHost
+		if max <= 0 {
+			max = DefaultPoolMaxPerHost
+		}
+		host = &poolHost{slots: make(chan struct{}, max), idle: make(chan pooledClient, max)}
+		p.hosts[switch_hostname] = host
+	}
+	return host
+}
+
+func (p *Pool) count(update func(stats *PoolStats)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	update(&p.stats)
+}
+
+// drainIdle takes every idle connection of host out of the pool.
+func drainIdle(host *poolHost) []pooledClient {
+	var idle []pooledClient
+	for {
+		select {
+		case pooled := <-host.idle:
+			idle = append(idle, pooled)
+		default:
+			return idle
+		}
+	}
+}
+
+// startHealthChecks starts the goroutine that expires idle connections, once. p.mu
+// must be held.
+func (p *Pool) startHealthChecks() {
+	if p.stop != nil {
+		return
+	}
+	p.stop = make(chan struct{})
+
+	interval := p.HealthInterval
+	if interval <= 0 {
+		interval = DefaultPoolHealthInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.checkIdle()
+			}
+		}
+	}()
+}
+
+// checkIdle closes idle connections past IdleTimeout and those that no longer answer
+// a keepalive. Connections are taken out of the pool while they are checked, so Get
+// cannot hand them out meanwhile.
+func (p *Pool) checkIdle() {
+	idleTimeout := p.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultPoolIdleTimeout
+	}
+
+	p.mu.Lock()
+	hosts := make([]*poolHost, 0, len(p.hosts))
+	for _, host := range p.hosts {
+		hosts = append(hosts, host)
+	}
+	p.mu.Unlock()
+
+	for _, host := range hosts {
+		for _, pooled := range drainIdle(host) {
+			if time.Since(pooled.since) > idleTimeout || !pooled.client.alive(keepaliveTimeout) {
+				p.count(func(stats *PoolStats) { stats.Evicted++ })
+				p.Discard(pooled.client)
+				continue
+			}
+			p.mu.Lock()
+			if !p.closed {
+				host.idle <- pooled
+				p.mu.Unlock()
+				continue
+			}
+			p.mu.Unlock()
+			p.Discard(pooled.client)
+		}
+	}
+}
+
+// alive reports whether the connection answers an SSH keepalive within timeout. A
+// reply of any kind will do; IOS refuses the request but still answers it.
+func (c *Client) alive(timeout time.Duration) bool {
+	if c.Client == nil {
+		return true // Replayed from a cassette
+	}
+
+	answered := make(chan error, 1)
+	go func() {
+		_, _, err := c.SendRequest("keepalive@openssh.com", true, nil)
+		answered <- err
+	}()
+
+	select {
+	case err := <-answered:
+		return err == nil
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,86 @@
+package cisco_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xtokio/cisco"
+)
+
+func TestPoolReusesConnections(t *testing.T) {
+	_, host := newSimulator(t, "2960x")
+	pool := cisco.NewPool(1)
+	defer pool.Close()
+
+	for range 3 {
+		err := pool.Do(context.Background(), host, func(client *cisco.Client) error {
+			_, err := client.RunCommand("show version")
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := pool.Stats(); stats.Dialed != 1 || stats.Reused != 2 || stats.Open != 1 || stats.Idle != 1 {
+		t.Errorf("Stats() = %+v, want one connection dialed and reused twice", stats)
+	}
+}
+
+func TestPoolWaitsForMaxPerHost(t *testing.T) {
+	_, host := newSimulator(t, "2960x")
+	pool := cisco.NewPool(1)
+	defer pool.Close()
+
+	client, err := pool.Get(context.Background(), host)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Get(ctx, host); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() with the only connection in use = %v, want the deadline", err)
+	}
+
+	pool.Put(client)
+	again, err := pool.Get(context.Background(), host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != client {
+		t.Error("Get() after Put dialed a new connection")
+	}
+	pool.Discard(again)
+	if stats := pool.Stats(); stats.Open != 0 {
+		t.Errorf("Stats() = %+v after Discard, want no connection open", stats)
+	}
+}
+
+func TestPoolDial(t *testing.T) {
+	server, _ := newSimulator(t, "2960x")
+	server.Username, server.Password = "netops", "vault-secret"
+
+	pool := cisco.NewPool(1)
+	defer pool.Close()
+	pool.Dial = func(ctx context.Context, switch_hostname string) (*cisco.Client, error) {
+		return cisco.NewClientContext(ctx, cisco.Config{Host: switch_hostname, Port: server.Port(), Username: "netops", Password: "vault-secret"})
+	}
+
+	client, err := pool.Get(context.Background(), "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.Put(client)
+}
+
+func TestPoolClosed(t *testing.T) {
+	_, host := newSimulator(t, "2960x")
+	pool := cisco.NewPool(1)
+	pool.Close()
+
+	if _, err := pool.Get(context.Background(), host); !errors.Is(err, cisco.ErrPoolClosed) {
+		t.Fatalf("Get() = %v, want ErrPoolClosed", err)
+	}
+}
@@ -0,0 +1,463 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PortChannel describes a port-channel to create with Create_port_channel. The
+// switchport settings are applied to the port-channel and to every member alike, so
+// no member starts out suspended.
+type PortChannel struct {
+	ID           int
+	Members      []string
+	Mode         string // Channel-group mode: active (default) or passive for LACP, on for a static channel
+	Description  string
+	Trunk        bool
+	AccessVlan   int   // Access port-channels only; 0 leaves the access VLAN at the default
+	AllowedVlans []int // Trunks only; empty allows all VLANs
+	NativeVlan   int   // Trunks only; 0 leaves the native VLAN at the default
+}
+
+// PortChannelMismatch is a member setting that differs from its port-channel, or
+// from the other members.
+type PortChannelMismatch struct {
+	Member   string
+	Setting  string // e.g., "switchport mode", "allowed vlan", "speed", "channel-group mode"
+	Expected string
+	Actual   string
+}
+
+// PortChannelValidation is the outcome of Validate_port_channel.
+type PortChannelValidation struct {
+	Switch      string
+	PortChannel string
+	Members     []string
+	Suspended   []string // Members the switch reports as suspended
+	Mismatches  []PortChannelMismatch
+}
+
+// Consistent reports whether no member differs from the port-channel.
+func (v PortChannelValidation) Consistent() bool {
+	return len(v.Mismatches) == 0
+}
+
+// portChannelSettings are the interface settings that must match between a
+// port-channel and its members, with the value IOS assumes when the line is absent.
+var portChannelSettings = []struct {
+	setting string
+	prefix  string
+	absent  string
+}{
+	{"switchport mode", "switchport mode ", ""},
+	{"trunk encapsulation", "switchport trunk encapsulation ", ""},
+	{"access vlan", "switchport access vlan ", "1"},
+	{"native vlan", "switchport trunk native vlan ", "1"},
+	{"allowed vlan", "switchport trunk allowed vlan ", "all"},
+}
+
+// Generate_port_channel_config returns the configuration lines that create a
+// port-channel and bundle its members. On NX-OS interfaces use long names and are
+// also set to switchport and no shutdown.
+func Generate_port_channel_config(pc PortChannel, platform Platform) ([]string, error) {
+	if pc.ID < 1 {
+		return nil, fmt.Errorf("port-channel: invalid ID %d", pc.ID)
+	}
+	if len(pc.Members) == 0 {
+		return nil, fmt.Errorf("port-channel %d: no members", pc.ID)
+	}
+	mode := pc.Mode
+	if mode == "" {
+		mode = "active"
+	}
+	if mode != "active" && mode != "passive" && mode != "on" {
+		return nil, fmt.Errorf("port-channel %d: unsupported mode %q", pc.ID, pc.Mode)
+	}
+	if err := checkTextParameter("description", pc.Description); err != nil {
+		return nil, fmt.Errorf("port-channel %d: %w", pc.ID, err)
+	}
+
+	var settings []string
+	if platform == PlatformNXOS {
+		settings = append(settings, " switchport")
+	}
+	if pc.Trunk {
+		settings = append(settings, " switchport mode trunk")
+		if pc.NativeVlan != 0 {
+			settings = append(settings, fmt.Sprintf(" switchport trunk native vlan %d", pc.NativeVlan))
+		}
+		if len(pc.AllowedVlans) > 0 {
+			settings = append(settings, " switchport trunk allowed vlan "+formatVlanList(pc.AllowedVlans))
+		}
+	} else {
+		settings = append(settings, " switchport mode access")
+		if pc.AccessVlan != 0 {
+			settings = append(settings, fmt.Sprintf(" switchport access vlan %d", pc.AccessVlan))
+		}
+	}
+	if platform == PlatformNXOS {
+		settings = append(settings, " no shutdown")
+	}
+
+	name := func(iface string) string {
+		if platform == PlatformNXOS {
+			return ExpandInterfaceName(iface)
+		}
+		return iface
+	}
+
+	lines := []string{"interface " + name(fmt.Sprintf("Po%d", pc.ID))}
+	if pc.Description != "" {
+		lines = append(lines, " description "+pc.Description)
+	}
+	lines = append(lines, settings...)
+
+	seen := make(map[string]bool)
+	for _, member := range pc.Members {
+		if err := checkInterfaceParameter(member); err != nil {
+			return nil, fmt.Errorf("port-channel %d: %w", pc.ID, err)
+		}
+		if seen[shortInterfaceName(member)] {
+			return nil, fmt.Errorf("port-channel %d: member %s is listed more than once", pc.ID, member)
+		}
+		seen[shortInterfaceName(member)] = true
+
+		lines = append(lines, "interface "+name(member))
+		if pc.Description != "" {
+			lines = append(lines, " description "+pc.Description)
+		}
+		lines = append(lines, settings...)
+		lines = append(lines, fmt.Sprintf(" channel-group %d mode %s", pc.ID, mode))
+	}
+
+	return lines, nil
+}
+
+// Create_port_channel creates a port-channel with LACP (or the mode set in pc) and
+// bundles its members, all in one configuration session.
+func Create_port_channel(switch_hostname string, pc PortChannel) (string, error) {
+	return Create_port_channel_context(context.Background(), switch_hostname, pc)
+}
+
+// Create_port_channel_context is Create_port_channel with a context that cancels connecting and the change.
+func Create_port_channel_context(ctx context.Context, switch_hostname string, pc PortChannel) (string, error) {
+	client, err := connectForConfigContext(ctx, switch_hostname)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	lines, err := Generate_port_channel_config(pc, client.platform())
+	if err != nil {
+		return "", err
+	}
+
+	outputString, err := client.configureContext(ctx, "port-channel", lines, client.commandTimeout(10*time.Second))
+	if err != nil {
+		return "", err
+	}
+
+	client.logf(slog.LevelInfo, "Successfully created Port-channel%d with %d members on %s.", pc.ID, len(pc.Members), switch_hostname)
+
+	return outputString, nil
+}
+
+// Add_port_channel_members adds interfaces to an existing port-channel. The members
+// get the switchport settings of the port-channel from the running configuration and
+// the channel-group mode of the current members, active when it has none.
+func Add_port_channel_members(switch_hostname string, id int, members []string) (string, error) {
+	return Add_port_channel_members_context(context.Background(), switch_hostname, id, members)
+}
+
+// Add_port_channel_members_context is Add_port_channel_members with a context that cancels connecting and the change.
+func Add_port_channel_members_context(ctx context.Context, switch_hostname string, id int, members []string) (string, error) {
+	for _, member := range members {
+		if err := checkInterfaceParameter(member); err != nil {
+			return "", err
+		}
+	}
+
+	client, err := connectForConfigContext(ctx, switch_hostname)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	outputString, err := client.RunCommandContext(ctx, "show running-config")
+	if err != nil {
+		return "", err
+	}
+	configs, err := ParseInterfaceConfig(outputString)
+	if err != nil {
+		return "", fmt.Errorf("%s :: show running-config :: %w", switch_hostname, err)
+	}
+
+	portChannel, ok := findPortChannelConfig(configs, fmt.Sprintf("Po%d", id))
+	if !ok {
+		return "", fmt.Errorf("%s :: port-channel %d does not exist", switch_hostname, id)
+	}
+	mode := "active"
+	for _, config := range configs {
+		if group, groupMode, ok := channelGroup(config); ok && group == id {
+			mode = groupMode
+			break
+		}
+	}
+
+	var lines []string
+	for _, member := range members {
+		lines = append(lines, "interface "+member)
+		for _, line := range portChannel.ConfigLines[1:] {
+			if strings.HasPrefix(line, "switchport") || strings.HasPrefix(line, "description ") {
+				lines = append(lines, " "+line)
+			}
+		}
+		lines = append(lines, fmt.Sprintf(" channel-group %d mode %s", id, mode))
+	}
+
+	outputString, err = client.configureContext(ctx, "port-channel", lines, client.commandTimeout(10*time.Second))
+	if err != nil {
+		return "", err
+	}
+
+	client.logf(slog.LevelInfo, "Successfully added %d members to Port-channel%d on %s.", len(members), id, switch_hostname)
+
+	return outputString, nil
+}
+
+// Validate_port_channel compares the configuration and status of every member of a
+// port-channel with the port-channel itself, see Check_port_channel.
+func Validate_port_channel(switch_hostname string, id int) (PortChannelValidation, error) {
+	return Validate_port_channel_context(context.Background(), switch_hostname, id)
+}
+
+// Validate_port_channel_context is Validate_port_channel with a context that cancels the commands.
+func Validate_port_channel_context(ctx context.Context, switch_hostname string, id int) (PortChannelValidation, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return PortChannelValidation{}, err
+	}
+	defer client.Close()
+
+	outputs, err := client.RunCommandsConcurrentlyContext(ctx, []string{"show running-config", "show interfaces status"})
+	if err != nil {
+		return PortChannelValidation{}, err
+	}
+	configs, err := ParseInterfaceConfig(outputs[0])
+	if err != nil {
+		return PortChannelValidation{}, fmt.Errorf("%s :: show running-config :: %w", switch_hostname, err)
+	}
+	statuses, err := ParseInterfaceStatus(outputs[1])
+	if err != nil {
+		return PortChannelValidation{}, fmt.Errorf("%s :: show interfaces status :: %w", switch_hostname, err)
+	}
+
+	validation, err := Check_port_channel(id, configs, statuses)
+	validation.Switch = switch_hostname
+	return validation, err
+}
+
+// Check_port_channel finds the members of a port-channel by their channel-group line
+// and flags the differences that make a switch suspend a member: switchport mode,
+// access, native and allowed VLANs that differ from the port-channel, speed and duplex
+// that differ from the port-channel or the other members, and a channel-group mode
+// that mixes LACP, PAgP and static members.
+func Check_port_channel(id int, configs []InterfaceConfig, statuses []InterfaceStatus) (PortChannelValidation, error) {
+	validation := PortChannelValidation{PortChannel: normalizeInterfaceName(fmt.Sprintf("Po%d", id))}
+
+	portChannel, ok := findPortChannelConfig(configs, fmt.Sprintf("Po%d", id))
+	if !ok {
+		return validation, fmt.Errorf("port-channel %d does not exist", id)
+	}
+	expected := memberSettings(portChannel)
+
+	var members []InterfaceConfig
+	for _, config := range configs {
+		if group, _, ok := channelGroup(config); ok && group == id {
+			members = append(members, config)
+		}
+	}
+	SortByInterface(members, func(config InterfaceConfig) string { return config.Interface })
+
+	mismatch := func(member string, setting string, expected string, actual string) {
+		validation.Mismatches = append(validation.Mismatches, PortChannelMismatch{Member: member, Setting: setting, Expected: expected, Actual: actual})
+	}
+
+	var firstMode, firstSpeed, firstDuplex string
+	poStatus, _ := findPortChannelStatus(statuses, portChannel.Interface)
+	for _, config := range members {
+		member := normalizeInterfaceName(config.Interface)
+		validation.Members = append(validation.Members, member)
+
+		actual := memberSettings(config)
+		for _, s := range portChannelSettings {
+			if actual[s.setting] != expected[s.setting] {
+				mismatch(member, s.setting, expected[s.setting], actual[s.setting])
+			}
+		}
+
+		_, mode, _ := channelGroup(config)
+		if firstMode == "" {
+			firstMode = mode
+		} else if channelProtocol(mode) != channelProtocol(firstMode) {
+			mismatch(member, "channel-group mode", firstMode, mode)
+		}
+
+		status, ok := findInterfaceStatus(statuses, config.Interface)
+		if !ok {
+			continue
+		}
+		if status.Status == "suspended" || status.Status == "suspnd" {
+			validation.Suspended = append(validation.Suspended, member)
+		}
+		if speed := fixedLinkValue(status.Speed); speed != "" {
+			if want := fixedLinkValue(poStatus.Speed); want != "" && speed != want {
+				mismatch(member, "speed", want, speed)
+			} else if firstSpeed != "" && speed != firstSpeed {
+				mismatch(member, "speed", firstSpeed, speed)
+			} else if firstSpeed == "" {
+				firstSpeed = speed
+			}
+		}
+		if duplex := fixedLinkValue(status.Duplex); duplex != "" {
+			if want := fixedLinkValue(poStatus.Duplex); want != "" && duplex != want {
+				mismatch(member, "duplex", want, duplex)
+			} else if firstDuplex != "" && duplex != firstDuplex {
+				mismatch(member, "duplex", firstDuplex, duplex)
+			} else if firstDuplex == "" {
+				firstDuplex = duplex
+			}
+		}
+	}
+
+	return validation, nil
+}
+
+// portChannelName shortens an interface name like shortInterfaceName does and also
+// turns "Port-channel1", and "port-channel1" on NX-OS, into "Po1", the form "show
+// interfaces status" prints, so a port-channel matches across commands.
+func portChannelName(name string) string {
+	name = shortInterfaceName(name)
+	for _, prefix := range []string{"Port-channel", "port-channel"} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			return "Po" + rest
+		}
+	}
+	return name
+}
+
+// findPortChannelConfig returns the running configuration of a port-channel in any spelling.
+func findPortChannelConfig(configs []InterfaceConfig, name string) (InterfaceConfig, bool) {
+	name = portChannelName(name)
+	for _, config := range configs {
+		if portChannelName(config.Interface) == name {
+			return config, true
+		}
+	}
+	return InterfaceConfig{}, false
+}
+
+// findPortChannelStatus returns the status of a port-channel in any spelling.
+func findPortChannelStatus(statuses []InterfaceStatus, name string) (InterfaceStatus, bool) {
+	name = portChannelName(name)
+	for _, status := range statuses {
+		if portChannelName(status.Interface) == name {
+			return status, true
+		}
+	}
+	return InterfaceStatus{}, false
+}
+
+// channelGroup returns the port-channel number and mode of a "channel-group 1 mode
+// active" line in an interface configuration.
+func channelGroup(config InterfaceConfig) (int, string, bool) {
+	for _, line := range config.ConfigLines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "channel-group" {
+			continue
+		}
+		group, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, "", false
+		}
+		mode := "on" // NX-OS omits "mode on"
+		if len(fields) >= 4 && fields[2] == "mode" {
+			mode = fields[3]
+		}
+		return group, mode, true
+	}
+	return 0, "", false
+}
+
+// channelProtocol groups channel-group modes that can bundle with each other.
+func channelProtocol(mode string) string {
+	switch mode {
+	case "active", "passive":
+		return "LACP"
+	case "desirable", "auto":
+		return "PAgP"
+	}
+	return "static"
+}
+
+// memberSettings returns the portChannelSettings of an interface configuration.
+// Allowed VLAN lines, including "add" continuations, are merged into one list.
+func memberSettings(config InterfaceConfig) map[string]string {
+	settings := make(map[string]string)
+	var allowed []int
+	allowedSet := false
+	for _, line := range config.ConfigLines {
+		for _, s := range portChannelSettings {
+			value, ok := strings.CutPrefix(line, s.prefix)
+			if !ok {
+				continue
+			}
+			if s.setting != "allowed vlan" {
+				settings[s.setting] = value
+				continue
+			}
+			value = strings.TrimPrefix(value, "add ")
+			if value == "none" {
+				allowed, allowedSet = nil, true
+				continue
+			}
+			vlans, err := parseVlanList(value)
+			if err != nil {
+				settings[s.setting] = value
+				continue
+			}
+			allowed, allowedSet = append(allowed, vlans...), true
+		}
+	}
+
+	if allowedSet && settings["allowed vlan"] == "" {
+		switch list := formatVlanList(allowed); list {
+		case "":
+			settings["allowed vlan"] = "none"
+		case "1-4094":
+			settings["allowed vlan"] = "all"
+		default:
+			settings["allowed vlan"] = list
+		}
+	}
+	for _, s := range portChannelSettings {
+		if settings[s.setting] == "" {
+			settings[s.setting] = s.absent
+		}
+	}
+	return settings
+}
+
+// fixedLinkValue strips the "a-" that marks an autonegotiated speed or duplex in
+// "show interfaces status" and returns "" for values that are not settled yet.
+func fixedLinkValue(value string) string {
+	value = strings.TrimPrefix(value, "a-")
+	if value == "auto" || value == "--" || value == "N/A" {
+		return ""
+	}
+	return value
+}
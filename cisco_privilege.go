@@ -0,0 +1,169 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ConfigPrivilegeLevel is the privilege level required to enter configuration mode.
+const ConfigPrivilegeLevel = 15
+
+var (
+	// ErrInsufficientPrivilege is returned when the login lacks the privilege an operation needs.
+	ErrInsufficientPrivilege = errors.New("insufficient privilege")
+	// ErrEnableFailed is returned by Enable when the switch is still in user EXEC
+	// afterwards, usually because it refused the enable password.
+	ErrEnableFailed = errors.New("enable failed")
+)
+
+var (
+	// rePrivilegeLevel matches the "show privilege" output line.
+	rePrivilegeLevel = regexp.MustCompile(`Current privilege level is (\d+)`)
+	// rePromptChar matches a prompt at the start of a line, including the IOS-XR
+	// "RP/0/RSP0/CPU0:" node prefix, and captures its last character.
+	rePromptChar = regexp.MustCompile(`^(?:[\w/]+:)?[\w\-\.]+(?:\([\w\-]+\))?([>#])`)
+)
+
+// ParsePrivilege extracts the privilege level from "show privilege" output. When the
+// command is not supported, the prompt is used instead: "#" means privileged EXEC
+// (level 15) and ">" user EXEC (level 1).
+func ParsePrivilege(rawOutput string) (int, error) {
+	if matches := rePrivilegeLevel.FindStringSubmatch(rawOutput); len(matches) > 1 {
+		return strconv.Atoi(matches[1])
+	}
+
+	lines := strings.Split(rawOutput, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		matches := rePromptChar.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if len(matches) < 2 {
+			continue
+		}
+		if matches[1] == "#" {
+			return 15, nil
+		}
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("could not determine privilege level")
+}
+
+// DetectPrivilege determines the effective privilege level of the login and stores it
+// in the client's PrivilegeLevel field.
+func (c *Client) DetectPrivilege() (int, error) {
+	c.privilegeMu.Lock()
+	defer c.privilegeMu.Unlock()
+	return c.detectPrivilege(context.Background())
+}
+
+// detectPrivilege runs "show privilege" and stores the level. The caller holds
+// privilegeMu.
+func (c *Client) detectPrivilege(ctx context.Context) (int, error) {
+	outputString, err := c.runShell(ctx, "show privilege", []string{"show privilege"}, c.commandTimeout(DefaultCommandTimeout))
+	// Platforms without "show privilege" reject it; the prompt still tells the level.
+	var cmdErr *CommandError
+	if err != nil && !errors.As(err, &cmdErr) {
+		return 0, err
+	}
+
+	level, err := ParsePrivilege(outputString)
+	if err != nil {
+		return 0, fmt.Errorf("%s :: %w", c.SwitchHostname, err)
+	}
+
+	c.PrivilegeLevel = level
+	return level, nil
+}
+
+// Enable raises the login from user EXEC (">") to privileged EXEC ("#") with "enable"
+// and verifies the privilege level afterwards. From then on every session of the
+// client starts with "enable". An empty password uses the enable password the client
+// was connected with. Logins already in privileged EXEC are left alone.
+func (c *Client) Enable(password string) error {
+	return c.EnableContext(context.Background(), password)
+}
+
+// EnableContext is Enable with a context that cancels the sessions it runs.
+func (c *Client) EnableContext(ctx context.Context, password string) error {
+	c.privilegeMu.Lock()
+	defer c.privilegeMu.Unlock()
+	return c.enable(ctx, password)
+}
+
+// enable is EnableContext for callers holding privilegeMu.
+func (c *Client) enable(ctx context.Context, password string) error {
+	if c.PrivilegeLevel == 0 {
+		// "enable" in privileged EXEC does not ask for a password, which would then
+		// be run as a command.
+		if _, err := c.detectPrivilege(ctx); err != nil {
+			return err
+		}
+	}
+	if c.PrivilegeLevel >= ConfigPrivilegeLevel || c.escalate.Load() {
+		return nil
+	}
+
+	if password != "" {
+		c.enablePassword = password
+	}
+	c.escalate.Store(true)
+	level, err := c.detectPrivilege(ctx)
+	if err == nil && level < ConfigPrivilegeLevel {
+		err = fmt.Errorf("%s :: privilege level %d after enable: %w", c.SwitchHostname, level, ErrEnableFailed)
+	}
+	if err != nil {
+		c.escalate.Store(false)
+		return err
+	}
+
+	c.logf(slog.LevelInfo, "%s :: Enable :: Privileged EXEC, level %d", c.SwitchHostname, level)
+	return nil
+}
+
+// requirePrivilege fails with ErrInsufficientPrivilege unless the login has at least
+// the given privilege level. The level is detected once per client; concurrent
+// callers wait for the first. Clients connected with Config.AutoEnable run Enable
+// first when the level is too low.
+func (c *Client) requirePrivilege(ctx context.Context, level int) error {
+	c.privilegeMu.Lock()
+	defer c.privilegeMu.Unlock()
+
+	if c.PrivilegeLevel == 0 {
+		if _, err := c.detectPrivilege(ctx); err != nil {
+			return err
+		}
+	}
+	if c.PrivilegeLevel < level && c.autoEnable && !c.escalate.Load() {
+		if err := c.enable(ctx, ""); err != nil {
+			return err
+		}
+	}
+	if c.PrivilegeLevel < level {
+		return fmt.Errorf("%s :: privilege level %d, %d required: %w", c.SwitchHostname, c.PrivilegeLevel, level, ErrInsufficientPrivilege)
+	}
+	return nil
+}
+
+// connectForConfig connects to the switch and makes sure the login may enter
+// configuration mode.
+func connectForConfig(switch_hostname string) (*Client, error) {
+	return connectForConfigContext(context.Background(), switch_hostname)
+}
+
+// connectForConfigContext is connectForConfig with a context that cancels connecting
+// and the privilege check.
+func connectForConfigContext(ctx context.Context, switch_hostname string) (*Client, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.requirePrivilege(ctx, ConfigPrivilegeLevel); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
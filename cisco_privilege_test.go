@@ -0,0 +1,99 @@
+package cisco_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/xtokio/cisco"
+)
+
+func TestEnable(t *testing.T) {
+	server, _ := newSimulator(t, "2960x")
+	server.UserExec = true
+	server.EnablePassword = "secret"
+	client, err := cisco.NewClient(cisco.Config{Host: "127.0.0.1", Port: server.Port(), Username: "admin", Password: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if level, err := client.DetectPrivilege(); err != nil || level != 1 {
+		t.Fatalf("DetectPrivilege() = %d, %v, want level 1", level, err)
+	}
+	if err := client.Enable("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if client.PrivilegeLevel != cisco.ConfigPrivilegeLevel {
+		t.Errorf("PrivilegeLevel = %d after Enable, want %d", client.PrivilegeLevel, cisco.ConfigPrivilegeLevel)
+	}
+
+	// Every later session starts in privileged EXEC.
+	if level, err := client.DetectPrivilege(); err != nil || level != cisco.ConfigPrivilegeLevel {
+		t.Errorf("DetectPrivilege() = %d, %v in a new session, want level %d", level, err, cisco.ConfigPrivilegeLevel)
+	}
+}
+
+func TestEnableWrongPassword(t *testing.T) {
+	server, _ := newSimulator(t, "2960x")
+	server.UserExec = true
+	server.EnablePassword = "secret"
+	client, err := cisco.NewClient(cisco.Config{Host: "127.0.0.1", Port: server.Port(), Username: "admin", Password: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Enable("wrong"); !errors.Is(err, cisco.ErrEnableFailed) {
+		t.Fatalf("Enable() = %v, want ErrEnableFailed", err)
+	}
+	// A failed enable leaves the sessions in user EXEC instead of sending a wrong password.
+	if level, err := client.DetectPrivilege(); err != nil || level != 1 {
+		t.Errorf("DetectPrivilege() = %d, %v after a failed Enable, want level 1", level, err)
+	}
+}
+
+func TestAutoEnableForConfiguration(t *testing.T) {
+	server, host := newSimulator(t, "2960x")
+	server.UserExec = true
+	server.EnablePassword = "secret"
+
+	t.Run("without enable password", func(t *testing.T) {
+		_, err := cisco.Interface_change_description(host, "Gi1/0/1", "printer")
+		if !errors.Is(err, cisco.ErrInsufficientPrivilege) {
+			t.Fatalf("err = %v, want ErrInsufficientPrivilege", err)
+		}
+	})
+	t.Run("with enable password", func(t *testing.T) {
+		t.Setenv("CISCO_ENABLE_PASSWORD", "secret")
+		if _, err := cisco.Interface_change_description(host, "Gi1/0/1", "printer"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestConcurrentPrivilegeChecks(t *testing.T) {
+	server, _ := newSimulator(t, "2960x")
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	errs := make(chan error, 4)
+	for range cap(errs) {
+		go func() { errs <- client.Enable("") }()
+	}
+	for range cap(errs) {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+	if client.PrivilegeLevel != cisco.ConfigPrivilegeLevel {
+		t.Errorf("PrivilegeLevel = %d, want %d", client.PrivilegeLevel, cisco.ConfigPrivilegeLevel)
+	}
+	// The level is detected once, however many goroutines ask for it.
+	if first := slices.Index(server.Received(), "show privilege"); first < 0 || slices.Contains(server.Received()[first+1:], "show privilege") {
+		t.Errorf("received %q, want show privilege once", server.Received())
+	}
+}
@@ -0,0 +1,250 @@
+package cisco
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// noDeadline clears a connection deadline.
+var noDeadline time.Time
+
+// Dialer opens the TCP connection to a switch, or to the first jump host. *net.Dialer
+// satisfies it.
+type Dialer interface {
+	DialContext(ctx context.Context, network string, address string) (net.Conn, error)
+}
+
+var dialerSettings struct {
+	sync.RWMutex
+	dialer Dialer
+}
+
+// SetDialer makes connections use dialer, e.g. one from ProxyDialer to reach isolated
+// segments through an approved proxy. Pass nil to dial directly again.
+func SetDialer(dialer Dialer) {
+	dialerSettings.Lock()
+	defer dialerSettings.Unlock()
+	dialerSettings.dialer = dialer
+}
+
+func activeDialer() Dialer {
+	dialerSettings.RLock()
+	defer dialerSettings.RUnlock()
+	if dialerSettings.dialer == nil {
+		return &net.Dialer{}
+	}
+	return dialerSettings.dialer
+}
+
+// ProxyDialer returns a Dialer tunneling through the proxy at proxyURL:
+// "socks5://[user:password@]host:port" or "http://[user:password@]host:port" for HTTP
+// CONNECT. Switch hostnames are resolved by the proxy.
+func ProxyDialer(proxyURL string) (Dialer, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy URL: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", proxyURL)
+	}
+
+	switch u.Scheme {
+	case "socks5", "socks5h":
+		return &socks5Dialer{address: u.Host, user: u.User}, nil
+	case "http":
+		return &httpConnectDialer{address: u.Host, user: u.User}, nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q, want socks5 or http", u.Scheme)
+}
+
+// socks5Dialer implements the CONNECT command of RFC 1928, with the username and
+// password authentication of RFC 1929.
+type socks5Dialer struct {
+	address string
+	user    *url.Userinfo
+}
+
+// socks5Errors maps SOCKS5 reply codes to the errors a direct dial would return, so
+// ConnectError classifies failures behind the proxy the same way.
+var socks5Errors = map[byte]error{
+	1: errors.New("general SOCKS server failure"),
+	2: errors.New("connection not allowed by ruleset"),
+	3: syscall.ENETUNREACH,
+	4: syscall.EHOSTUNREACH,
+	5: syscall.ECONNREFUSED,
+	6: errors.New("TTL expired"),
+	7: errors.New("command not supported"),
+	8: errors.New("address type not supported"),
+}
+
+func (d *socks5Dialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, network, d.address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(noDeadline)
+	}
+	if err := d.connect(conn, address); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("socks5 proxy %s: %w", d.address, err)
+	}
+	return conn, nil
+}
+
+func (d *socks5Dialer) connect(conn net.Conn, address string) error {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", portString)
+	}
+	if len(host) > 255 {
+		return fmt.Errorf("hostname too long")
+	}
+
+	method := byte(0) // No authentication
+	if d.user != nil {
+		method = 2 // Username and password
+	}
+	if _, err := conn.Write([]byte{5, 1, method}); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != 5 || reply[1] != method {
+		return fmt.Errorf("proxy refused authentication method %d", method)
+	}
+
+	if method == 2 {
+		password, _ := d.user.Password()
+		request := []byte{1, byte(len(d.user.Username()))}
+		request = append(request, d.user.Username()...)
+		request = append(request, byte(len(password)))
+		request = append(request, password...)
+		if _, err := conn.Write(request); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(conn, reply); err != nil {
+			return err
+		}
+		if reply[1] != 0 {
+			return fmt.Errorf("proxy rejected the credentials")
+		}
+	}
+
+	// CONNECT with the hostname as a domain name, so the proxy resolves it.
+	request := []byte{5, 1, 0, 3, byte(len(host))}
+	request = append(request, host...)
+	request = binary.BigEndian.AppendUint16(request, uint16(port))
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	if header[1] != 0 {
+		if err, ok := socks5Errors[header[1]]; ok {
+			return err
+		}
+		return fmt.Errorf("proxy reply code %d", header[1])
+	}
+
+	// Skip the bound address and port.
+	var skip int
+	switch header[3] {
+	case 1:
+		skip = net.IPv4len + 2
+	case 4:
+		skip = net.IPv6len + 2
+	case 3:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return err
+		}
+		skip = int(length[0]) + 2
+	default:
+		return fmt.Errorf("proxy reply address type %d", header[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, skip))
+	return err
+}
+
+// httpConnectDialer tunnels through an HTTP proxy with the CONNECT method.
+type httpConnectDialer struct {
+	address string
+	user    *url.Userinfo
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, network, d.address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(noDeadline)
+	}
+
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if d.user != nil {
+		password, _ := d.user.Password()
+		request.SetBasicAuth(d.user.Username(), password)
+		request.Header.Set("Proxy-Authorization", request.Header.Get("Authorization"))
+		request.Header.Del("Authorization")
+	}
+	if err := request.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy %s: %w", d.address, err)
+	}
+
+	// The reader must not buffer past the response, as the SSH handshake follows; a
+	// CONNECT response has no body, so reading byte by byte up to it is enough.
+	response, err := http.ReadResponse(bufio.NewReaderSize(oneByteReader{conn}, 16), request)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy %s: %w", d.address, err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy %s: CONNECT %s: %s", d.address, address, response.Status)
+	}
+	return conn, nil
+}
+
+// oneByteReader reads at most one byte per call, so a bufio.Reader on top of it never
+// consumes more than it hands out.
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (o oneByteReader) Read(p []byte) (int, error) {
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	return o.r.Read(p)
+}
@@ -0,0 +1,111 @@
+package cisco
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultRetryBackoff is the wait before the first retry when RetryPolicy.Backoff
+	// is not set. It doubles after every attempt.
+	DefaultRetryBackoff = time.Second
+
+	// DefaultRetryMaxBackoff caps the wait between attempts when
+	// RetryPolicy.MaxBackoff is not set.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy retries connecting to a switch after transient failures, e.g. a reset
+// or refused connection while the switch restarts its SSH server. Commands are never
+// retried, as running them twice may not be safe.
+type RetryPolicy struct {
+	// MaxAttempts is the number of connection attempts, the first included. One or
+	// less means no retries.
+	MaxAttempts int
+
+	// Backoff is the wait before the first retry, doubled after every attempt up to
+	// MaxBackoff. Zero means DefaultRetryBackoff and DefaultRetryMaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Retryable reports whether a failed attempt is worth repeating. Nil means
+	// IsRetryable.
+	Retryable func(err error) bool
+}
+
+// IsRetryable reports whether err is a connection failure that may go away by
+// itself: a refused, reset, unanswered or unroutable connection. Rejected
+// credentials, unknown hostnames and algorithm mismatches are not.
+func IsRetryable(err error) bool {
+	switch ConnectFailureOf(err) {
+	case ConnectRefused, ConnectClosed, ConnectTimeout, ConnectUnreachable:
+		return true
+	}
+	return false
+}
+
+var retrySettings struct {
+	sync.RWMutex
+	policy *RetryPolicy
+}
+
+// SetRetry makes every connection that does not set Config.Retry retry with policy,
+// including those of the functions taking only a hostname. Pass nil to connect once
+// again.
+func SetRetry(policy *RetryPolicy) {
+	retrySettings.Lock()
+	defer retrySettings.Unlock()
+	retrySettings.policy = policy
+}
+
+func activeRetry() *RetryPolicy {
+	retrySettings.RLock()
+	defer retrySettings.RUnlock()
+	return retrySettings.policy
+}
+
+// dialWithRetry is dialTarget repeated as the retry policy of config allows. Failures
+// are returned as a ConnectError.
+func dialWithRetry(ctx context.Context, target sshTarget, config Config) (*ssh.Client, []*ssh.Client, error) {
+	policy := config.Retry
+	if policy == nil {
+		policy = activeRetry()
+	}
+	if policy == nil {
+		policy = &RetryPolicy{}
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	backoff, maxBackoff := policy.Backoff, policy.MaxBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		sshClient, jumps, err := dialTarget(ctx, target, config)
+		if err == nil {
+			return sshClient, jumps, nil
+		}
+		connectErr := newConnectError(config.Host, err)
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil || !retryable(connectErr) {
+			return nil, nil, connectErr
+		}
+
+		logTo(config.Logger, slog.LevelWarn, "%s :: Connect :: Attempt %d of %d failed, retrying in %s :: %v", config.Host, attempt, policy.MaxAttempts, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, nil, newConnectError(config.Host, ctx.Err())
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
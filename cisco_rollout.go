@@ -0,0 +1,109 @@
+package cisco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrRolloutHalted is returned when a rollout stops because too many switches failed.
+var ErrRolloutHalted = errors.New("rollout halted")
+
+// Rollout applies a change to a fleet gradually: first to a few canary switches, then
+// in batches, verifying each switch and stopping once failures pass a threshold.
+type Rollout struct {
+	Fleet          *Fleet    // Runs each batch; nil uses NewFleet(0, nil)
+	Canaries       int       // Switches in the first batch; defaults to 1. Any canary failure halts the rollout.
+	BatchSize      int       // Switches per later batch; defaults to DefaultFleetConcurrency
+	MaxFailureRate float64   // Halt when failed/attempted exceeds this after a batch, e.g. 0.05; 0 halts on any failure
+	Verify         FleetTask // Runs on each switch after the change; an error counts as a failure
+}
+
+// RolloutResult is the outcome of a rollout.
+type RolloutResult struct {
+	Results   []FleetResult // One per attempted switch, in rollout order
+	Batches   int
+	Failed    int
+	Halted    bool
+	Remaining []string // Switches not attempted because the rollout halted or was canceled
+}
+
+// FailureRate returns the share of attempted switches that failed.
+func (r RolloutResult) FailureRate() float64 {
+	if len(r.Results) == 0 {
+		return 0
+	}
+	return float64(r.Failed) / float64(len(r.Results))
+}
+
+// Run applies change to switch_hostnames batch by batch. It returns ErrRolloutHalted
+// when the canaries or the failure rate stop it, or the context error when canceled;
+// either way the switches not reached are listed in Remaining.
+func (r Rollout) Run(ctx context.Context, switch_hostnames []string, change FleetTask) (RolloutResult, error) {
+	fleet := r.Fleet
+	if fleet == nil {
+		fleet = NewFleet(0, nil)
+	}
+	canaries := r.Canaries
+	if canaries <= 0 {
+		canaries = 1
+	}
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultFleetConcurrency
+	}
+
+	task := change
+	if r.Verify != nil {
+		task = func(client *Client) (any, error) {
+			value, err := change(client)
+			if err != nil {
+				return value, err
+			}
+			if _, err := r.Verify(client); err != nil {
+				return value, fmt.Errorf("verification: %w", err)
+			}
+			return value, nil
+		}
+	}
+
+	var result RolloutResult
+	remaining := switch_hostnames
+	size := canaries
+
+	for len(remaining) > 0 {
+		if err := ctx.Err(); err != nil {
+			result.Remaining = remaining
+			return result, err
+		}
+
+		batch := remaining[:min(size, len(remaining))]
+		remaining = remaining[len(batch):]
+		size = batchSize
+		result.Batches++
+
+		batchFailed := 0
+		for _, batchResult := range fleet.RunContext(ctx, batch, task) {
+			result.Results = append(result.Results, batchResult)
+			if batchResult.Err != nil && !batchResult.Canceled {
+				batchFailed++
+			}
+		}
+		result.Failed += batchFailed
+
+		if err := ctx.Err(); err != nil {
+			result.Remaining = remaining
+			return result, err
+		}
+		if result.Batches == 1 && batchFailed > 0 {
+			result.Halted, result.Remaining = true, remaining
+			return result, fmt.Errorf("%w: %d of %d canaries failed", ErrRolloutHalted, batchFailed, len(batch))
+		}
+		if result.FailureRate() > r.MaxFailureRate {
+			result.Halted, result.Remaining = true, remaining
+			return result, fmt.Errorf("%w: failure rate %.0f%% after %d switches", ErrRolloutHalted, 100*result.FailureRate(), len(result.Results))
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,194 @@
+package cisco
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// errEchoNotFound is the error of a command whose echo was not found in the session
+// output, so its output could not be told apart from that of its neighbors.
+var errEchoNotFound = errors.New("command echo not found in the session output")
+
+// CommandResult is the outcome of one command run by RunCommandsDetailed.
+type CommandResult struct {
+	Command  string
+	Output   string        // What the switch printed for the command, without the echoed command and prompt
+	Duration time.Duration // From the echo of the command to the echo of the next one; zero when replayed
+	Error    error         // A CommandError when the switch rejected the command, or the session error when it failed before the command completed
+}
+
+// RunCommandsDetailed runs the commands in order in a single session, like
+// RunCommands, and returns the output of each command separately. The error is for
+// the session as a whole, e.g. a failed connection or a timeout, and the commands it
+// cut short carry it too; or, when the session went through, the CommandError of the
+// first command the switch rejected.
+func RunCommandsDetailed(switch_hostname string, switch_commands []string) ([]CommandResult, error) {
+	return RunCommandsDetailedContext(context.Background(), switch_hostname, switch_commands)
+}
+
+// RunCommandsDetailedContext is RunCommandsDetailed with a context, see RunCommandContext.
+func RunCommandsDetailedContext(ctx context.Context, switch_hostname string, switch_commands []string) ([]CommandResult, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return failedResults(switch_commands, err), err
+	}
+	defer client.Close()
+
+	return client.RunCommandsDetailedContext(ctx, switch_commands)
+}
+
+// RunCommandsDetailed is RunCommandsDetailed over the client's existing connection.
+func (c *Client) RunCommandsDetailed(switch_commands []string) ([]CommandResult, error) {
+	return c.RunCommandsDetailedContext(context.Background(), switch_commands)
+}
+
+// RunCommandsDetailedContext is RunCommandsDetailed with a context that ends the
+// session when done.
+func (c *Client) RunCommandsDetailedContext(ctx context.Context, switch_commands []string) ([]CommandResult, error) {
+	release, err := c.acquireSessionContext(ctx)
+	if err != nil {
+		return failedResults(switch_commands, err), err
+	}
+	defer release()
+
+	label := fmt.Sprint(switch_commands)
+	splitter := newCommandSplitter(switch_commands)
+
+	if replay := activeCassette(CassetteReplay); replay != nil {
+		outputString, err := replay.Replay(c.SwitchHostname, switch_commands)
+		if err != nil {
+			return failedResults(switch_commands, err), err
+		}
+		io.WriteString(splitter, outputString)
+		results := splitter.results(nil)
+		return results, commandErrors(c.SwitchHostname, results, splitter.columns)
+	}
+
+	var buf bytes.Buffer
+	_, err = c.streamShell(ctx, label, switch_commands, c.commandTimeout(DefaultCommandTimeout), io.MultiWriter(&buf, splitter))
+	if err == nil {
+		c.record(label, switch_commands, buf.String())
+	}
+	results := splitter.results(err)
+	if err == nil {
+		err = commandErrors(c.SwitchHostname, results, splitter.columns)
+	}
+	return results, err
+}
+
+// failedResults returns a result carrying err for every command.
+func failedResults(switch_commands []string, err error) []CommandResult {
+	results := make([]CommandResult, len(switch_commands))
+	for i, cmd := range switch_commands {
+		results[i] = CommandResult{Command: cmd, Error: err}
+	}
+	return results
+}
+
+// commandSplitter is an io.Writer that cuts the output of a shell session into the
+// output of each command at the lines where the switch echoes the next command after
+// its prompt, e.g. "switch01#show vlan", and times each command by those lines.
+type commandSplitter struct {
+	commands []string
+	found    []CommandResult
+	columns  []int // Where each command starts on its echo line
+	next     int   // Index of the command whose echo comes next
+	line     []byte
+	output   strings.Builder
+	started  time.Time
+}
+
+func newCommandSplitter(switch_commands []string) *commandSplitter {
+	return &commandSplitter{commands: switch_commands}
+}
+
+func (s *commandSplitter) Write(p []byte) (int, error) {
+	s.line = append(s.line, p...)
+	for {
+		i := bytes.IndexByte(s.line, '\n')
+		if i < 0 {
+			break
+		}
+		s.addLine(string(s.line[:i+1]))
+		s.line = s.line[i+1:]
+	}
+	return len(p), nil
+}
+
+func (s *commandSplitter) addLine(line string) {
+	// runShell ends every session with "exit", which closes the output of the last command.
+	expected := "exit"
+	if s.next < len(s.commands) {
+		expected = s.commands[s.next]
+	}
+	column := -1
+	if s.next <= len(s.commands) {
+		column = commandEchoColumn(line, expected)
+	}
+	if column < 0 {
+		if s.next > 0 {
+			s.output.WriteString(line)
+		}
+		return
+	}
+
+	now := time.Now()
+	s.cut(now)
+	s.started = now
+	if s.next < len(s.commands) {
+		s.columns = append(s.columns, column)
+	}
+	s.next++
+}
+
+// cut closes the output of the command being collected, if any.
+func (s *commandSplitter) cut(now time.Time) {
+	if s.next == 0 || len(s.found) == len(s.commands) {
+		return
+	}
+	s.found = append(s.found, CommandResult{
+		Command:  s.commands[len(s.found)],
+		Output:   s.output.String(),
+		Duration: now.Sub(s.started),
+	})
+	s.output.Reset()
+}
+
+// results returns a result for every command. When the session failed with err, the
+// command it was running keeps its partial output, and it and the commands that did
+// not run carry err.
+func (s *commandSplitter) results(err error) []CommandResult {
+	if len(s.found) < len(s.commands) && s.next > len(s.found) {
+		s.output.Write(s.line)
+		s.cut(time.Now())
+		if err != nil {
+			s.found[len(s.found)-1].Error = err
+		}
+	}
+
+	results := s.found
+	for _, cmd := range s.commands[len(results):] {
+		result := CommandResult{Command: cmd, Error: err}
+		if err == nil {
+			result.Error = errEchoNotFound
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// commandEchoColumn returns where the command starts in line when line is a prompt
+// followed by the command, and -1 otherwise.
+func commandEchoColumn(line string, command string) int {
+	line = strings.TrimRight(line, "\r\n")
+	prompt := rePromptChar.FindStringIndex(line)
+	if prompt == nil || strings.TrimSpace(line[prompt[1]:]) != strings.TrimSpace(command) {
+		return -1
+	}
+	return prompt[1] + len(line[prompt[1]:]) - len(strings.TrimLeft(line[prompt[1]:], " "))
+}
@@ -0,0 +1,45 @@
+package cisco
+
+import (
+	"context"
+	"time"
+)
+
+// RunOnDevicesOptions tune RunOnDevices.
+type RunOnDevicesOptions struct {
+	// Concurrency is the number of switches worked on at once. Zero means
+	// DefaultFleetConcurrency.
+	Concurrency int
+
+	// Timeout caps the time spent on each switch, connecting included; a switch that
+	// takes longer fails with ErrTimeout, up to LogoutTimeout later. Zero leaves only
+	// the dial and command timeouts.
+	Timeout time.Duration
+
+	// Pool, if set, provides the connections, see Fleet.Pool.
+	Pool *Pool
+}
+
+// DeviceResult is the outcome of RunOnDevices on one switch.
+type DeviceResult struct {
+	Output   string // What the switch printed, also when it rejected the command
+	Err      error
+	Duration time.Duration
+}
+
+// RunOnDevices runs command on every switch, opts.Concurrency at a time, and returns
+// the result of each keyed by hostname. A failing switch does not stop the others;
+// when ctx is canceled, switches not done yet carry its error.
+func RunOnDevices(ctx context.Context, switch_hostnames []string, command string, opts RunOnDevicesOptions) map[string]DeviceResult {
+	fleet := &Fleet{Concurrency: opts.Concurrency, Timeout: opts.Timeout, Pool: opts.Pool}
+	fleetResults := fleet.RunContext(ctx, switch_hostnames, func(client *Client) (any, error) {
+		return client.RunCommand(command)
+	})
+
+	results := make(map[string]DeviceResult, len(fleetResults))
+	for _, fleetResult := range fleetResults {
+		output, _ := fleetResult.Value.(string)
+		results[fleetResult.Switch] = DeviceResult{Output: output, Err: fleetResult.Err, Duration: fleetResult.Duration}
+	}
+	return results
+}
@@ -0,0 +1,23 @@
+package cisco_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xtokio/cisco/ciscotest"
+)
+
+// newSimulator starts a simulated switch of the named profile, closed when the test
+// ends, and returns it with the hostname the package functions reach it by. They log
+// in with the environment credentials, which the simulator accepts whatever they are.
+func newSimulator(t *testing.T, profileName string) (*ciscotest.Server, string) {
+	t.Helper()
+	server, err := ciscotest.NewSimulator(profileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	t.Setenv("CISCO_USERNAME", "admin")
+	t.Setenv("CISCO_PASSWORD", "admin")
+	return server, fmt.Sprintf("127.0.0.1:%d", server.Port())
+}
@@ -0,0 +1,189 @@
+package cisco
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SSHConfigHost holds the settings an OpenSSH client config gives one host. Empty
+// fields were not set.
+type SSHConfigHost struct {
+	HostName      string
+	User          string
+	Port          string
+	IdentityFiles []string // With ~ expanded
+	ProxyJump     []string // Jump hosts in order, each as [user@]host[:port]
+}
+
+// sshConfigBlock is one "Host" section; the settings before the first one apply to
+// every host and have the pattern "*".
+type sshConfigBlock struct {
+	patterns []string
+	settings [][2]string // keyword (lowercase), value
+}
+
+// SSHConfig is a parsed OpenSSH client config, e.g. ~/.ssh/config. Only the
+// settings this package uses are read; Match and Include sections are skipped.
+type SSHConfig struct {
+	blocks []sshConfigBlock
+}
+
+// LoadSSHConfig reads an OpenSSH client config file; "" reads ~/.ssh/config.
+func LoadSSHConfig(path string) (*SSHConfig, error) {
+	home, _ := os.UserHomeDir()
+	if path == "" {
+		path = filepath.Join(home, ".ssh", "config")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ssh config: %w", err)
+	}
+	defer file.Close()
+
+	config := &SSHConfig{blocks: []sshConfigBlock{{patterns: []string{"*"}}}}
+	current := &config.blocks[0]
+	skipping := false
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// "Keyword value" and "Keyword=value" are both allowed.
+		keyword, value := line, ""
+		if i := strings.IndexAny(line, " \t="); i != -1 {
+			keyword, value = line[:i], strings.TrimLeft(line[i:], " \t")
+			value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+		}
+		keyword = strings.ToLower(keyword)
+		value = strings.Trim(value, `"`)
+
+		switch keyword {
+		case "host":
+			config.blocks = append(config.blocks, sshConfigBlock{patterns: strings.Fields(value)})
+			current = &config.blocks[len(config.blocks)-1]
+			skipping = false
+		case "match":
+			skipping = true
+		case "hostname", "user", "port", "identityfile", "proxyjump":
+			if !skipping {
+				current.settings = append(current.settings, [2]string{keyword, value})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading ssh config: %w", err)
+	}
+
+	return config, nil
+}
+
+// Lookup returns the settings for a host alias. As in OpenSSH, the first value found
+// for each setting wins, except IdentityFile which accumulates.
+func (c *SSHConfig) Lookup(alias string) SSHConfigHost {
+	var host SSHConfigHost
+	home, _ := os.UserHomeDir()
+
+	for _, block := range c.blocks {
+		if !sshConfigMatches(block.patterns, alias) {
+			continue
+		}
+		for _, setting := range block.settings {
+			value := setting[1]
+			switch setting[0] {
+			case "hostname":
+				if host.HostName == "" {
+					host.HostName = strings.ReplaceAll(value, "%h", alias)
+				}
+			case "user":
+				if host.User == "" {
+					host.User = value
+				}
+			case "port":
+				if host.Port == "" {
+					host.Port = value
+				}
+			case "identityfile":
+				if strings.HasPrefix(value, "~/") {
+					value = filepath.Join(home, value[2:])
+				}
+				host.IdentityFiles = append(host.IdentityFiles, strings.ReplaceAll(value, "%d", home))
+			case "proxyjump":
+				if host.ProxyJump == nil && value != "none" {
+					host.ProxyJump = strings.Split(value, ",")
+				}
+			}
+		}
+	}
+
+	return host
+}
+
+// sshConfigMatches reports whether alias matches a Host line: at least one pattern
+// matches and no negated ("!") pattern does.
+func sshConfigMatches(patterns []string, alias string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			if wildcardMatch(negated, alias) {
+				return false
+			}
+		} else if wildcardMatch(pattern, alias) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// wildcardMatch matches s against a pattern where "*" is any run of characters and "?"
+// any single character.
+func wildcardMatch(pattern string, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(s); i >= 0; i-- {
+				if wildcardMatch(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if s == "" {
+				return false
+			}
+		default:
+			if s == "" || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return s == ""
+}
+
+var sshConfigSettings struct {
+	sync.RWMutex
+	config *SSHConfig
+}
+
+// SetSSHConfig makes every connection pick up its host's HostName, User, Port,
+// IdentityFile and ProxyJump from config. A User only applies when no username was
+// given. Pass nil to stop.
+func SetSSHConfig(config *SSHConfig) {
+	sshConfigSettings.Lock()
+	defer sshConfigSettings.Unlock()
+	sshConfigSettings.config = config
+}
+
+func activeSSHConfig() *SSHConfig {
+	sshConfigSettings.RLock()
+	defer sshConfigSettings.RUnlock()
+	return sshConfigSettings.config
+}
@@ -0,0 +1,26 @@
+package cisco
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxStreamLineSize bounds a single line read from a stream. "show tech-support"
+// can contain very long lines (e.g. hex dumps), so the default 64KB is too small.
+const maxStreamLineSize = 1024 * 1024
+
+// scanLines reads r line by line and calls fn for every line with the trailing
+// "\r" removed. Scanning stops at the first error returned by fn.
+func scanLines(r io.Reader, fn func(line string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+
+	for scanner.Scan() {
+		if err := fn(strings.TrimRight(scanner.Text(), "\r")); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,129 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCommandTimeout is how long commands may take to print their output when no
+// command timeout is set. Single-interface changes default to a few seconds instead.
+const DefaultCommandTimeout = 30 * time.Second
+
+// Timeouts are the package-wide defaults for connections whose Config, or Client,
+// leaves them unset.
+type Timeouts struct {
+	Dial    time.Duration // Opening the TCP connection; zero means DefaultDialTimeout
+	Command time.Duration // Waiting for the output of a session; zero means the default of each operation
+}
+
+var timeoutSettings struct {
+	sync.RWMutex
+	timeouts Timeouts
+}
+
+// SetTimeouts sets the dial and command timeouts of every connection that does not
+// set its own, including those of the functions taking only a hostname. The command
+// timeout does not apply to Collect_tech_support, see TechSupportTimeout.
+func SetTimeouts(timeouts Timeouts) {
+	timeoutSettings.Lock()
+	defer timeoutSettings.Unlock()
+	timeoutSettings.timeouts = timeouts
+}
+
+func activeTimeouts() Timeouts {
+	timeoutSettings.RLock()
+	defer timeoutSettings.RUnlock()
+	return timeoutSettings.timeouts
+}
+
+// commandTimeout returns the client's command timeout, or the one set with
+// SetTimeouts, or else fallback, the default of the operation.
+func (c *Client) commandTimeout(fallback time.Duration) time.Duration {
+	if c.CommandTimeout > 0 {
+		return c.CommandTimeout
+	}
+	if timeout := activeTimeouts().Command; timeout > 0 {
+		return timeout
+	}
+	return fallback
+}
+
+// CommandOptions change how a single call runs its commands.
+type CommandOptions struct {
+	// Timeout overrides the command timeout for this call, e.g. for a large
+	// running-config on a stack. Zero keeps the client's.
+	Timeout time.Duration
+}
+
+func (c *Client) optionsTimeout(opts CommandOptions) time.Duration {
+	if opts.Timeout > 0 {
+		return opts.Timeout
+	}
+	return c.commandTimeout(DefaultCommandTimeout)
+}
+
+// RunCommandWithOptions is RunCommand with options for this call only.
+func RunCommandWithOptions(switch_hostname string, switch_command string, opts CommandOptions) (string, error) {
+	return RunCommandWithOptionsContext(context.Background(), switch_hostname, switch_command, opts)
+}
+
+// RunCommandWithOptionsContext is RunCommandContext with options for this call only.
+func RunCommandWithOptionsContext(ctx context.Context, switch_hostname string, switch_command string, opts CommandOptions) (string, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	return client.RunCommandWithOptionsContext(ctx, switch_command, opts)
+}
+
+// RunCommandsWithOptions is RunCommands with options for this call only.
+func RunCommandsWithOptions(switch_hostname string, switch_commands []string, opts CommandOptions) (string, error) {
+	return RunCommandsWithOptionsContext(context.Background(), switch_hostname, switch_commands, opts)
+}
+
+// RunCommandsWithOptionsContext is RunCommandsContext with options for this call only.
+func RunCommandsWithOptionsContext(ctx context.Context, switch_hostname string, switch_commands []string, opts CommandOptions) (string, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	return client.RunCommandsWithOptionsContext(ctx, switch_commands, opts)
+}
+
+// RunCommandWithOptions is RunCommand with options for this call only.
+func (c *Client) RunCommandWithOptions(switch_command string, opts CommandOptions) (string, error) {
+	return c.RunCommandWithOptionsContext(context.Background(), switch_command, opts)
+}
+
+// RunCommandWithOptionsContext is RunCommandContext with options for this call only.
+func (c *Client) RunCommandWithOptionsContext(ctx context.Context, switch_command string, opts CommandOptions) (string, error) {
+	release, err := c.acquireSessionContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return c.runShell(ctx, switch_command, []string{switch_command}, c.optionsTimeout(opts))
+}
+
+// RunCommandsWithOptions is RunCommands with options for this call only.
+func (c *Client) RunCommandsWithOptions(switch_commands []string, opts CommandOptions) (string, error) {
+	return c.RunCommandsWithOptionsContext(context.Background(), switch_commands, opts)
+}
+
+// RunCommandsWithOptionsContext is RunCommandsContext with options for this call only.
+func (c *Client) RunCommandsWithOptionsContext(ctx context.Context, switch_commands []string, opts CommandOptions) (string, error) {
+	release, err := c.acquireSessionContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return c.runShell(ctx, fmt.Sprint(switch_commands), switch_commands, c.optionsTimeout(opts))
+}
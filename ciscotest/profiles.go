@@ -0,0 +1,997 @@
+package ciscotest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xtokio/cisco"
+)
+
+// Profile is a canned device: a hostname and the output it prints for each supported
+// show command. Commands a real device of that kind does not support are left out, so
+// the simulator answers them with InvalidInput.
+type Profile struct {
+	Name         string
+	Hostname     string
+	PromptPrefix string // See Server.PromptPrefix
+	Platform     cisco.Platform
+	Responses    map[string]string
+}
+
+// Profile names accepted by LookupProfile and NewSimulator.
+const (
+	Profile2960X     = "2960x"
+	Profile9300Stack = "9300-stack"
+	ProfileNexus9K   = "nexus9k"
+	ProfileASR9K     = "asr9k"
+	ProfileCBS350    = "cbs350"
+	ProfileASA5516   = "asa5516"
+)
+
+// profileBuilders return a fresh copy of each profile so callers may modify it.
+var profileBuilders = map[string]func() Profile{
+	Profile2960X:     catalyst2960XProfile,
+	Profile9300Stack: catalyst9300StackProfile,
+	ProfileNexus9K:   nexus9KProfile,
+	ProfileASR9K:     asr9KProfile,
+	ProfileCBS350:    cbs350Profile,
+	ProfileASA5516:   asa5516Profile,
+}
+
+// ProfileNames returns the names of the available profiles, sorted.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profileBuilders))
+	for name := range profileBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupProfile returns the profile with the given name.
+func LookupProfile(name string) (Profile, bool) {
+	build, ok := profileBuilders[name]
+	if !ok {
+		return Profile{}, false
+	}
+	return build(), true
+}
+
+// NewSimulator starts a Server behaving like the device of the named profile, e.g.
+// Profile9300Stack. The caller must Close the server.
+func NewSimulator(profileName string) (*Server, error) {
+	profile, ok := LookupProfile(profileName)
+	if !ok {
+		return nil, fmt.Errorf("unknown simulator profile %q, available: %v", profileName, ProfileNames())
+	}
+	server, err := NewServer(profile.Hostname, profile.Responses)
+	if err != nil {
+		return nil, err
+	}
+	server.PromptPrefix, server.Platform = profile.PromptPrefix, profile.Platform
+	return server, nil
+}
+
+// catalyst2960XProfile is a standalone WS-C2960X-48FPD-L on IOS 15.2; the package
+// fixtures were captured from one.
+func catalyst2960XProfile() Profile {
+	responses := make(map[string]string, len(cisco.Fixtures))
+	for command, output := range cisco.Fixtures {
+		responses[command] = output
+	}
+	return Profile{Name: Profile2960X, Hostname: "switch01", Responses: responses}
+}
+
+// catalyst9300StackProfile is a two-member C9300-48P stack on IOS-XE 17.9. Commands
+// not overridden print the same output as the 2960X.
+func catalyst9300StackProfile() Profile {
+	profile := catalyst2960XProfile()
+	profile.Name = Profile9300Stack
+	profile.Hostname = "stack01"
+
+	profile.Responses["show version"] = `Cisco IOS XE Software, Version 17.09.04a
+Cisco IOS Software [Cupertino], Catalyst L3 Switch Software (CAT9K_IOSXE), Version 17.9.4a, RELEASE SOFTWARE (fc3)
+Technical Support: http://www.cisco.com/techsupport
+Copyright (c) 1986-2023 by Cisco Systems, Inc.
+Compiled Fri 20-Oct-23 10:44 by mcpre
+
+ROM: IOS-XE ROMMON
+BOOTLDR: System Bootstrap, Version 17.9.1r[FC2], RELEASE SOFTWARE (P)
+
+stack01 uptime is 23 weeks, 2 days, 6 hours, 41 minutes
+Uptime for this control processor is 23 weeks, 2 days, 6 hours, 44 minutes
+System returned to ROM by Reload Command
+System restarted at 09:12:40 EST Tue May 7 2024
+System image file is "flash:packages.conf"
+Last reload reason: Reload Command
+
+cisco C9300-48P (X86) processor with 1338934K/6147K bytes of memory.
+Processor board ID FOC2345A1BC
+2 Virtual Ethernet interfaces
+104 Gigabit Ethernet interfaces
+16 Ten Gigabit Ethernet interfaces
+
+Switch Ports Model              SW Version        SW Image              Mode
+------ ----- -----              ----------        ----------            ----
+*    1 65    C9300-48P          17.09.04a         CAT9K_IOSXE           INSTALL
+     2 65    C9300-48P          17.09.04a         CAT9K_IOSXE           INSTALL
+
+Switch 01
+---------
+Switch uptime                      : 23 weeks, 2 days, 6 hours, 44 minutes
+Model Number                       : C9300-48P
+System Serial Number               : FOC2345A1BC
+
+Switch 02
+---------
+Switch uptime                      : 23 weeks, 2 days, 6 hours, 40 minutes
+Model Number                       : C9300-48P
+System Serial Number               : FOC2345B2CD
+
+Configuration register is 0x102
+`
+	profile.Responses["show interface status"] = `
+Port         Name               Status       Vlan       Duplex  Speed Type
+Gi1/0/1      AP 3-West          connected    30         a-full a-1000 10/100/1000BaseTX
+Gi1/0/2      Workstation 3-201  connected    10         a-full  a-100 10/100/1000BaseTX
+Gi1/0/3                         notconnect   10           auto   auto 10/100/1000BaseTX
+Gi1/0/4      Printer 3-210      connected    20         a-full a-1000 10/100/1000BaseTX
+Gi2/0/1      Workstation 3-220  connected    10         a-full a-1000 10/100/1000BaseTX
+Gi2/0/2                         notconnect   10           auto   auto 10/100/1000BaseTX
+Gi2/0/3                         disabled     10           auto   auto 10/100/1000BaseTX
+Gi2/0/4      Camera 3-Lobby     err-disabled 40           auto   auto 10/100/1000BaseTX
+Te1/1/1      Uplink to core01   connected    trunk        full    10G SFP-10GBase-SR
+Te2/1/1      Uplink to core02   connected    trunk        full    10G SFP-10GBase-SR
+Po1          Uplink core        connected    trunk      a-full  a-10G N/A
+`
+	profile.Responses["show power inline"] = `
+Module   Available     Used     Remaining
+          (Watts)     (Watts)    (Watts)
+------   ---------   --------   ---------
+1           715.0       44.8       670.2
+2           715.0       13.0       702.0
+Interface Admin  Oper       Power   Device              Class Max
+                            (Watts)
+--------- ------ ---------- ------- ------------------- ----- ----
+Gi1/0/1   auto   on         17.6    C9120AXI-B          4     30.0
+Gi1/0/2   auto   on         6.1     IP Phone 8845       2     30.0
+Gi1/0/3   auto   off        0.0     n/a                 n/a   30.0
+Gi1/0/4   auto   on         21.1    Ieee PD             4     30.0
+Gi2/0/1   auto   on         6.1     IP Phone 8845       2     30.0
+Gi2/0/2   auto   off        0.0     n/a                 n/a   30.0
+Gi2/0/3   auto   off        0.0     n/a                 n/a   30.0
+Gi2/0/4   auto   on         6.9     Ieee PD             0     30.0
+--------- ------ ---------- ------- ------------------- ----- ----
+Totals:          5   on     57.8
+`
+	profile.Responses["show cdp neighbors"] = `Capability Codes: R - Router, T - Trans Bridge, B - Source Route Bridge
+                  S - Switch, H - Host, I - IGMP, r - Repeater, P - Phone,
+                  D - Remote, C - CVTA, M - Two-port Mac Relay
+
+Device ID        Local Intrfce     Holdtme    Capability  Platform  Port ID
+core01.example.com
+                 Ten 1/1/1         151             R S I  C9500-48Y Twe 1/0/13
+core02.example.com
+                 Ten 2/1/1         147             R S I  C9500-48Y Twe 1/0/13
+SEP00A1B2C3D4F6  Gig 1/0/2         137              H P M IP Phone  Port 1
+SEP00A1B2C3D4F7  Gig 2/0/1         159              H P M IP Phone  Port 1
+AP3-West.example.com
+                 Gig 1/0/1         122              T B I C9120AXI- Gig 0
+
+Total cdp entries displayed : 5
+`
+	profile.Responses["show lldp neighbors"] = `Capability codes:
+    (R) Router, (B) Bridge, (T) Telephone, (C) DOCSIS Cable Device
+    (W) WLAN Access Point, (P) Repeater, (S) Station, (O) Other
+
+Device ID           Local Intf     Hold-time  Capability      Port ID
+core01.example.com  Te1/1/1        120        B,R             Twe1/0/13
+core02.example.com  Te2/1/1        120        B,R             Twe1/0/13
+AP3-West            Gi1/0/1        120        B,W             Gi0
+
+Total entries displayed: 3
+`
+	profile.Responses["show clock detail"] = `*10:42:17.381 EST Wed Oct 16 2024
+Time source is NTP
+`
+	profile.Responses["show inventory"] = `NAME: "c93xx Stack", DESCR: "c93xx Stack"
+PID: C9300-48P         , VID: V02  , SN: FOC2345A1BC
+
+NAME: "Switch 1", DESCR: "C9300-48P"
+PID: C9300-48P         , VID: V02  , SN: FOC2345A1BC
+
+NAME: "Switch 1 - Power Supply A", DESCR: "Switch 1 - Power Supply A"
+PID: PWR-C1-715WAC     , VID: V02  , SN: LIT2330A1B2
+
+NAME: "Switch 1 FRU Uplink Module 1", DESCR: "4x10G Uplink Module"
+PID: C9300-NM-4G       , VID: V02  , SN: FOC23310XYZ
+
+NAME: "Switch 2", DESCR: "C9300-48P"
+PID: C9300-48P         , VID: V02  , SN: FOC2345B2CD
+
+NAME: "Switch 2 - Power Supply A", DESCR: "Switch 2 - Power Supply A"
+PID: PWR-C1-715WAC     , VID: V02  , SN: LIT2330C3D4
+
+NAME: "Te1/1/1", DESCR: "SFP-10GBase-SR"
+PID: SFP-10G-SR          , VID: V03  , SN: AVD2233K1LM
+
+NAME: "Te2/1/1", DESCR: "SFP-10GBase-SR"
+PID: SFP-10G-SR          , VID: V03  , SN: AVD2233K1LN
+
+`
+	profile.Responses["show switch"] = `Switch/Stack Mac Address : 00a1.b2c3.d400 - Local Mac Address
+Mac persistency wait time: Indefinite
+                                             H/W   Current
+Switch#   Role    Mac Address     Priority Version  State
+-------------------------------------------------------------
+*1       Active   00a1.b2c3.d400     15     V02     Ready
+ 2       Standby  00a1.b2c3.e500     14     V02     Ready
+ 3       Member   0000.0000.0000     0      V01     Provisioned
+`
+	profile.Responses["show ip ospf neighbor"] = `
+Neighbor ID     Pri   State           Dead Time   Address         Interface
+10.255.0.1        0   FULL/  -        00:00:37    10.1.1.1        TenGigabitEthernet1/1/1
+10.255.0.5        0   FULL/  -        00:00:31    10.1.1.5        TenGigabitEthernet2/1/1
+10.20.0.3         1   2WAY/DROTHER    00:00:34    10.20.0.3       Vlan20
+10.20.0.254     128   FULL/DR         00:00:39    10.20.0.254     Vlan20
+`
+	profile.Responses["show ip bgp summary"] = `% BGP not active
+`
+	profile.Responses["show interfaces trunk"] = `
+Port        Mode             Encapsulation  Status        Native vlan
+Te1/1/1     on               802.1q         trunking      99
+Te2/1/1     desirable        n-802.1q       trunking      99
+Po1         on               802.1q         trunking      1
+
+Port        Vlans allowed on trunk
+Te1/1/1     10,20,30,99
+Te2/1/1     10,20,30,99
+Po1         1-9,11,13,15,17,19,21,23,25,27,29,31,33,35,37,39,41,43,45,47,49,51,53,
+            55,57,100-199,300-4094
+
+Port        Vlans allowed and active in management domain
+Te1/1/1     10,20,30,99
+Te2/1/1     10,20,30,99
+Po1         1
+
+Port        Vlans in spanning tree forwarding state and not pruned
+Te1/1/1     10,20,30,99
+Te2/1/1     none
+Po1         1
+`
+	profile.Responses["show env all"] = `Switch 1 FAN 1 is OK
+Switch 1 FAN 2 is OK
+Switch 1 FAN 3 is OK
+Switch 2 FAN 1 is OK
+Switch 2 FAN 2 is OK
+Switch 2 FAN 3 is OK
+FAN PS-1 is OK
+FAN PS-2 is NOT PRESENT
+FAN PS-3 is OK
+FAN PS-4 is NOT PRESENT
+Switch 1: SYSTEM TEMPERATURE is OK
+Inlet Temperature Value: 28 Degree Celsius
+Temperature State: GREEN
+Yellow Threshold : 46 Degree Celsius
+Red Threshold    : 56 Degree Celsius
+
+Hotspot Temperature Value: 43 Degree Celsius
+Temperature State: GREEN
+Yellow Threshold : 125 Degree Celsius
+Red Threshold    : 135 Degree Celsius
+Switch 2: SYSTEM TEMPERATURE is OK
+Inlet Temperature Value: 47 Degree Celsius
+Temperature State: YELLOW
+Yellow Threshold : 46 Degree Celsius
+Red Threshold    : 56 Degree Celsius
+
+Hotspot Temperature Value: 58 Degree Celsius
+Temperature State: GREEN
+Yellow Threshold : 125 Degree Celsius
+Red Threshold    : 135 Degree Celsius
+Switch   FAN     Speed   State   Airflow direction
+---------------------------------------------------
+  1       1     5440     OK     Front to Back
+  1       2     5440     OK     Front to Back
+  1       3     5440     OK     Front to Back
+  2       1     8160     OK     Front to Back
+  2       2     8160     OK     Front to Back
+  2       3     8160     OK     Front to Back
+
+SW  PID                 Serial#     Status           Sys Pwr  PoE Pwr  Watts
+--  ------------------  ----------  ---------------  -------  -------  -----
+1A  PWR-C1-715WAC       LIT2330A1B2  OK              Good     Good     715
+1B  Not Present
+2A  PWR-C1-715WAC       LIT2330A1C7  No Input Power  Bad      Bad      715
+2B  Not Present
+`
+	profile.Responses["show ip route"] = `Codes: L - local, C - connected, S - static, R - RIP, M - mobile, B - BGP
+       D - EIGRP, EX - EIGRP external, O - OSPF, IA - OSPF inter area
+       N1 - OSPF NSSA external type 1, N2 - OSPF NSSA external type 2
+       E1 - OSPF external type 1, E2 - OSPF external type 2, m - OMP
+       n - NAT, Ni - NAT inside, No - NAT outside, Nd - NAT DIA
+       i - IS-IS, su - IS-IS summary, L1 - IS-IS level-1, L2 - IS-IS level-2
+       ia - IS-IS inter area, * - candidate default, U - per-user static route
+       H - NHRP, G - NHRP registered, g - NHRP registration summary
+       o - ODR, P - periodic downloaded static route, l - LISP
+       a - application route
+       + - replicated route, % - next hop override, p - overrides from PfR
+       & - replicated local route overrides by connected
+
+Gateway of last resort is 10.1.1.5 to network 0.0.0.0
+
+O*E2  0.0.0.0/0 [110/1] via 10.1.1.5, 2w3d, TenGigabitEthernet2/1/1
+                [110/1] via 10.1.1.1, 2w3d, TenGigabitEthernet1/1/1
+      10.0.0.0/8 is variably subnetted, 10 subnets, 4 masks
+C        10.1.1.0/30 is directly connected, TenGigabitEthernet1/1/1
+L        10.1.1.2/32 is directly connected, TenGigabitEthernet1/1/1
+C        10.1.1.4/30 is directly connected, TenGigabitEthernet2/1/1
+L        10.1.1.6/32 is directly connected, TenGigabitEthernet2/1/1
+C        10.20.0.0/24 is directly connected, Vlan20
+L        10.20.0.1/32 is directly connected, Vlan20
+O IA     10.30.0.0/16 [110/3] via 10.1.1.5, 2w3d, TenGigabitEthernet2/1/1
+                      [110/3] via 10.1.1.1, 2w3d, TenGigabitEthernet1/1/1
+O E2     10.40.128.0/17
+           [110/20] via 10.1.1.1, 00:41:07, TenGigabitEthernet1/1/1
+S        10.99.0.0/16 is directly connected, Null0
+      172.16.0.0/24 is subnetted, 1 subnets
+D EX     172.16.5.0 [170/3072] via 10.20.0.254, 1d02h, Vlan20
+B     192.0.2.0/24 [200/0] via 10.255.0.1, 3d11h
+`
+	return profile
+}
+
+// nexus9KProfile is a Nexus 93180YC-FX on NX-OS 10.3. IOS-only commands such as
+// "show power inline" and "show line" are not supported.
+func nexus9KProfile() Profile {
+	profile := Profile{Name: ProfileNexus9K, Hostname: "leaf01", Platform: cisco.PlatformNXOS, Responses: map[string]string{}}
+
+	profile.Responses["show version"] = `Cisco Nexus Operating System (NX-OS) Software
+TAC support: http://www.cisco.com/tac
+Documents: http://www.cisco.com/en/US/products/ps9372/tsd_products_support_serie
+s_home.html
+Copyright (c) 2002-2023, Cisco Systems, Inc. All rights reserved.
+
+Software
+  BIOS: version 05.47
+ NXOS: version 10.3(4a) [Maintenance Release]
+  BIOS compile time:  06/09/2022
+  NXOS image file is: bootflash:///nxos64-cs.10.3.4a.M.bin
+  NXOS compile time:  10/4/2023 12:00:00 [09/15/2023 20:11:20]
+
+Hardware
+  cisco Nexus9000 C93180YC-FX Chassis
+  Intel(R) Xeon(R) CPU  @ 1.80GHz with 24569856 kB of memory.
+  Processor Board ID FDO23456ABC
+
+  Device name: leaf01
+  bootflash: 115805708 kB
+Kernel uptime is 187 day(s), 4 hour(s), 12 minute(s), 9 second(s)
+
+Last reset
+  Reason: Reset due to upgrade
+  System version: 10.2(5)
+  Service:
+`
+	profile.Responses["show interface status"] = `
+--------------------------------------------------------------------------------
+Port          Name               Status    Vlan      Duplex  Speed   Type
+--------------------------------------------------------------------------------
+mgmt0         --                 connected routed    full    1000    --
+Eth1/1        server01-eth0      connected 100       full    25G     SFP-H25GB-CU2M
+Eth1/2        server02-eth0      connected 100       full    25G     SFP-H25GB-CU2M
+Eth1/3        --                 notconnec 1         auto    auto    10Gbase-SR
+Eth1/4        --                 sfpAbsent 1         full    auto    --
+Eth1/49       spine01 Eth1/1     connected routed    full    100G    QSFP-100G-SR4-S
+Eth1/50       spine02 Eth1/1     connected routed    full    100G    QSFP-100G-SR4-S
+Eth1/53       vpc peer-link      connected trunk     full    100G    QSFP-100G-CR4
+`
+	profile.Responses["show interface"] = `
+Ethernet1/1 is up
+admin state is up, Dedicated Interface
+  Hardware: 100/1000/10000/25000 Ethernet, address: 00de.fb12.3401 (bia 00de.fb12.3401)
+  Description: server01-eth0
+  MTU 1500 bytes, BW 25000000 Kbit , DLY 10 usec
+  reliability 255/255, txload 1/255, rxload 1/255
+  Encapsulation ARPA, medium is broadcast
+  Port mode is access
+  full-duplex, 25 Gb/s, media type is 25G
+  Beacon is turned off
+  Auto-Negotiation is turned on  FEC mode is Auto
+  Input flow-control is off, output flow-control is off
+  Auto-mdix is turned off
+  Rate mode is dedicated
+  Switchport monitor is off
+  EtherType is 0x8100
+  EEE (efficient-ethernet) : n/a
+  Last link flapped 4week(s) 2day(s)
+  Last clearing of "show interface" counters never
+  2 interface resets
+  Load-Interval #1: 30 seconds
+    30 seconds input rate 1523456 bits/sec, 210 packets/sec
+    30 seconds output rate 2845120 bits/sec, 305 packets/sec
+    input rate 1.52 Mbps, 210 pps; output rate 2.85 Mbps, 305 pps
+  Load-Interval #2: 5 minute (300 seconds)
+    300 seconds input rate 1498230 bits/sec, 205 packets/sec
+    300 seconds output rate 2790112 bits/sec, 300 packets/sec
+    input rate 1.50 Mbps, 205 pps; output rate 2.79 Mbps, 300 pps
+  RX
+    2345678901 unicast packets  12345 multicast packets  678 broadcast packets
+    2345691924 input packets  1876543210987 bytes
+    0 jumbo packets  0 storm suppression bytes
+    0 runts  0 giants  3 CRC  0 no buffer
+    3 input error  0 short frame  0 overrun   0 underrun  0 ignored
+    0 watchdog  0 bad etype drop  0 bad proto drop  0 if down drop
+    0 input with dribble  12 input discard
+    0 Rx pause
+  TX
+    3456789012 unicast packets  23456 multicast packets  789 broadcast packets
+    3456813257 output packets  2987654321098 bytes
+    0 jumbo packets
+    0 output error  0 collision  0 deferred  0 late collision
+    0 lost carrier  0 no carrier  0 babble  4 output discard
+    0 Tx pause
+
+Ethernet1/3 is down (Link not connected)
+admin state is up, Dedicated Interface
+  Hardware: 100/1000/10000/25000 Ethernet, address: 00de.fb12.3403 (bia 00de.fb12.3403)
+  MTU 1500 bytes, BW 10000000 Kbit , DLY 10 usec
+  reliability 255/255, txload 1/255, rxload 1/255
+  Encapsulation ARPA, medium is broadcast
+  Port mode is access
+  auto-duplex, auto-speed, media type is 10G
+  Beacon is turned off
+  Auto-Negotiation is turned on  FEC mode is Auto
+  Input flow-control is off, output flow-control is off
+  Auto-mdix is turned off
+  Switchport monitor is off
+  EtherType is 0x8100
+  EEE (efficient-ethernet) : n/a
+  Last link flapped never
+  Last clearing of "show interface" counters never
+  0 interface resets
+  Load-Interval #1: 30 seconds
+    30 seconds input rate 0 bits/sec, 0 packets/sec
+    30 seconds output rate 0 bits/sec, 0 packets/sec
+    input rate 0 bps, 0 pps; output rate 0 bps, 0 pps
+  RX
+    0 unicast packets  0 multicast packets  0 broadcast packets
+    0 input packets  0 bytes
+    0 jumbo packets  0 storm suppression bytes
+    0 runts  0 giants  0 CRC  0 no buffer
+    0 input error  0 short frame  0 overrun   0 underrun  0 ignored
+    0 watchdog  0 bad etype drop  0 bad proto drop  0 if down drop
+    0 input with dribble  0 input discard
+    0 Rx pause
+  TX
+    0 unicast packets  0 multicast packets  0 broadcast packets
+    0 output packets  0 bytes
+    0 jumbo packets
+    0 output error  0 collision  0 deferred  0 late collision
+    0 lost carrier  0 no carrier  0 babble  0 output discard
+    0 Tx pause
+
+Ethernet1/49 is up
+admin state is up, Dedicated Interface
+  Hardware: 40000/100000 Ethernet, address: 00de.fb12.3431 (bia 00de.fb12.3431)
+  Description: spine01 Eth1/1
+  Internet Address is 10.1.0.1/31
+  MTU 9216 bytes, BW 100000000 Kbit , DLY 10 usec
+  reliability 255/255, txload 2/255, rxload 3/255
+  Encapsulation ARPA, medium is broadcast
+  full-duplex, 100 Gb/s, media type is 100G
+  Beacon is turned off
+  Auto-Negotiation is turned on  FEC mode is Auto
+  Input flow-control is off, output flow-control is off
+  Auto-mdix is turned off
+  Rate mode is dedicated
+  Switchport monitor is off
+  EtherType is 0x8100
+  EEE (efficient-ethernet) : n/a
+  Last link flapped 4week(s) 2day(s)
+  Last clearing of "show interface" counters never
+  1 interface resets
+  Load-Interval #1: 30 seconds
+    30 seconds input rate 1204567890 bits/sec, 110230 packets/sec
+    30 seconds output rate 987654321 bits/sec, 95012 packets/sec
+    input rate 1.20 Gbps, 110.23 Kpps; output rate 987.65 Mbps, 95.01 Kpps
+  RX
+    98765432109 unicast packets  345678 multicast packets  12 broadcast packets
+    98765777799 input packets  87654321098765 bytes
+    1234567 jumbo packets  0 storm suppression bytes
+    0 runts  0 giants  0 CRC  0 no buffer
+    0 input error  0 short frame  0 overrun   0 underrun  0 ignored
+    0 watchdog  0 bad etype drop  0 bad proto drop  0 if down drop
+    0 input with dribble  0 input discard
+    0 Rx pause
+  TX
+    87654321098 unicast packets  456789 multicast packets  9 broadcast packets
+    87654777896 output packets  76543210987654 bytes
+    2345678 jumbo packets
+    0 output error  0 collision  0 deferred  0 late collision
+    0 lost carrier  0 no carrier  0 babble  0 output discard
+    0 Tx pause
+`
+	profile.Responses["show ip interface brief"] = `
+IP Interface Status for VRF "default"(1)
+Interface            IP Address      Interface Status
+Vlan100              10.100.0.2      protocol-up/link-up/admin-up
+Vlan200              10.200.0.2      protocol-down/link-down/admin-down
+Lo0                  10.255.1.1      protocol-up/link-up/admin-up
+Eth1/49              10.1.0.1        protocol-up/link-up/admin-up
+Eth1/50              10.1.0.5        protocol-up/link-up/admin-up
+`
+	profile.Responses["show inventory"] = `NAME: "Chassis",  DESCR: "Nexus9000 C93180YC-FX Chassis"
+PID: N9K-C93180YC-FX     ,  VID: V04  ,  SN: FDO23456ABC
+
+NAME: "Slot 1",  DESCR: "48x10/25G + 6x40/100G Ethernet Module"
+PID: N9K-C93180YC-FX     ,  VID: V04  ,  SN: FDO23456ABC
+
+NAME: "Power Supply 1",  DESCR: "Nexus9000 C93180YC-FX Chassis Power Supply"
+PID: NXA-PAC-650W-PE     ,  VID: V01  ,  SN: ART2312F0AB
+
+NAME: "Power Supply 2",  DESCR: "Nexus9000 C93180YC-FX Chassis Power Supply"
+PID: NXA-PAC-650W-PE     ,  VID: V01  ,  SN: ART2312F0AC
+
+NAME: "Fan 1",  DESCR: "Nexus9000 C93180YC-FX Chassis Fan Module"
+PID: NXA-FAN-30CFM-B     ,  VID: V01  ,  SN: N/A
+
+`
+	profile.Responses["show ip ospf neighbor"] = ` OSPF Process ID UNDERLAY VRF default
+ Total number of neighbors: 2
+ Neighbor ID     Pri State            Up Time  Address         Interface
+ 10.255.0.1        1 FULL/ -          4w2d     10.1.0.2        Eth1/49
+ 10.255.0.2        1 FULL/ -          4w2d     10.1.0.6        Eth1/50
+`
+	profile.Responses["show ip bgp summary"] = `BGP summary information for VRF default, address family IPv4 Unicast
+BGP router identifier 10.255.1.1, local AS number 65001
+BGP table version is 88, IPv4 Unicast config peers 3, capable peers 2
+24 network entries and 26 paths using 6480 bytes of memory
+BGP attribute entries [4/688], BGP AS path entries [1/6]
+BGP community entries [0/0], BGP clusterlist entries [0/0]
+
+Neighbor        V    AS    MsgRcvd    MsgSent   TblVer  InQ OutQ Up/Down  State/PfxRcd
+10.255.0.1      4 65001      52341      52330       88    0    0     1w0d 24
+10.255.0.2      4 65001      52298      52301       88    0    0     1w0d 2
+203.0.113.9     4 4200000001         0          0        0    0    0     4w2d Idle (Admin)
+`
+	profile.Responses["show logging logfile"] = `2024 Oct 16 09:12:40 nxos01 %ETHPORT-5-IF_DOWN_LINK_FAILURE: Interface Ethernet1/2 is down (Link failure)
+2024 Oct 16 09:12:44 nxos01 %ETHPORT-5-IF_UP: Interface Ethernet1/2 is up in mode access
+2024 Oct 16 09:40:02 nxos01 %VPC-6-PEER_VPC_UP: vPC 1 is up on peer
+2024 Oct 16 10:05:31 nxos01 %PLATFORM-2-PS_FAIL: Power supply 2 failed or shut down (Serial number ART2312F0AC)
+2024 Oct 16 10:14:52 nxos01 %VSHD-5-VSHD_SYSLOG_CONFIG_I: Configured from vty by admin on 10.0.0.5@pts/0
+`
+	profile.Responses["show interface trunk"] = `
+--------------------------------------------------------------------------------
+Port          Native  Status        Port
+              Vlan                  Channel
+--------------------------------------------------------------------------------
+Eth1/53       1       trnk-bndl     Po1
+Po1           1       trunking      --
+
+--------------------------------------------------------------------------------
+Port          Vlans Allowed on Trunk
+--------------------------------------------------------------------------------
+Eth1/53       100,200
+Po1           100,200
+
+--------------------------------------------------------------------------------
+Port          Vlans Err-disabled on Trunk
+--------------------------------------------------------------------------------
+Eth1/53       none
+Po1           none
+
+--------------------------------------------------------------------------------
+Port          STP Forwarding
+--------------------------------------------------------------------------------
+Eth1/53       none
+Po1           100,200
+
+--------------------------------------------------------------------------------
+Port          Vlans in spanning tree forwarding state and not pruned
+--------------------------------------------------------------------------------
+Eth1/53       Feature VTP is not enabled
+Po1           Feature VTP is not enabled
+`
+	profile.Responses["show environment"] = `Power Supply:
+Voltage: 12.0 Volts
+Power                              Actual             Actual        Total
+Supply    Model                    Output             Input      Capacity     Status
+                                   (Watts )           (Watts )     (Watts )
+-------  -------------------  -----------  -----------  -----------  ----------
+1        NXA-PAC-650W-PE           142 W        154 W        650 W     Ok
+2        NXA-PAC-650W-PE             0 W          0 W        650 W     Shutdown
+
+Fan:
+---------------------------------------------------------------------------
+Fan             Model                Hw     Direction       Status
+---------------------------------------------------------------------------
+Fan1(sys_fan1)  NXA-FAN-30CFM-B      --     back-to-front   Ok
+Fan2(sys_fan2)  NXA-FAN-30CFM-B      --     back-to-front   Ok
+Fan3(sys_fan3)  NXA-FAN-30CFM-B      --     back-to-front   Ok
+Fan4(sys_fan4)  NXA-FAN-30CFM-B      --     back-to-front   Ok
+Fan_in_PS1      --                   --     back-to-front   Ok
+Fan_in_PS2      --                   --     back-to-front   Shutdown
+Fan Zone Speed: Zone 1: 0x5f
+Fan Air Filter : NotSupported
+
+Temperature:
+--------------------------------------------------------------------
+Module   Sensor        MajorThresh   MinorThres   CurTemp     Status
+                       (Celsius)     (Celsius)    (Celsius)
+--------------------------------------------------------------------
+1        FRONT           80              70          31         Normal
+1        BACK            70              42          26         Normal
+1        CPU             90              80          41         Normal
+1        Homewood        110             90          48         Normal
+`
+	profile.Responses["show ip arp"] = `
+Flags: * - Adjacencies learnt on non-active FHRP router
+       + - Adjacencies synced via CFSoE
+       # - Adjacencies Throttled for Glean
+       CP - Added via L2RIB, Control plane Adjacencies
+       PS - Added via L2RIB, Peer Sync
+       RO - Re-Originated Peer Sync Entry
+       D - Static Adjacencies attached to down interface
+
+IP ARP Table for context default
+Total number of entries: 4
+Address         Age       MAC Address     Interface       Flags
+10.1.0.2        00:03:11  00a1.b2c3.e001  Ethernet1/49
+10.1.0.6        00:02:47  00a1.b2c3.e101  Ethernet1/50
+10.100.0.10     00:12:44  0050.56a1.0001  Vlan100
+10.100.0.11     00:00:05  INCOMPLETE      Vlan100
+`
+	profile.Responses["show ip route"] = `IP Route Table for VRF "default"
+'*' denotes best ucast next-hop
+'**' denotes best mcast next-hop
+'[x/y]' denotes [preference/metric]
+'%<string>' in via output denotes VRF <string>
+
+0.0.0.0/0, ubest/mbest: 2/0
+    *via 10.1.0.2, Eth1/49, [110/41], 4w2d, ospf-UNDERLAY, type-2
+    *via 10.1.0.6, Eth1/50, [110/41], 4w2d, ospf-UNDERLAY, type-2
+10.1.0.0/30, ubest/mbest: 1/0, attached
+    *via 10.1.0.1, Eth1/49, [0/0], 4w2d, direct
+10.1.0.1/32, ubest/mbest: 1/0, attached
+    *via 10.1.0.1, Eth1/49, [0/0], 4w2d, local
+10.1.0.4/30, ubest/mbest: 1/0, attached
+    *via 10.1.0.5, Eth1/50, [0/0], 4w2d, direct
+10.1.0.5/32, ubest/mbest: 1/0, attached
+    *via 10.1.0.5, Eth1/50, [0/0], 4w2d, local
+10.100.0.0/24, ubest/mbest: 1/0, attached
+    *via 10.100.0.2, Vlan100, [0/0], 4w2d, direct
+10.100.0.2/32, ubest/mbest: 1/0, attached
+    *via 10.100.0.2, Vlan100, [0/0], 4w2d, local
+10.255.0.1/32, ubest/mbest: 2/0
+    *via 10.1.0.2, Eth1/49, [110/41], 4w2d, ospf-UNDERLAY, intra
+    *via 10.1.0.6, Eth1/50, [110/41], 4w2d, ospf-UNDERLAY, intra
+198.51.100.0/24, ubest/mbest: 1/0
+    *via 10.255.0.1, [200/0], 1w0d, bgp-65001, internal, tag 65010
+`
+	profile.Responses["show vlan"] = `
+VLAN Name                             Status    Ports
+---- -------------------------------- --------- -------------------------------
+1    default                          active    Eth1/3, Eth1/4
+100  SERVERS                          active    Po1, Eth1/1, Eth1/2, Eth1/53
+200  STORAGE                          active    Po1, Eth1/53
+
+VLAN Type         Vlan-mode
+---- -----        ----------
+1    enet         CE
+100  enet         CE
+200  enet         CE
+`
+	profile.Responses["show vlan brief"] = `
+VLAN Name                             Status    Ports
+---- -------------------------------- --------- -------------------------------
+1    default                          active    Eth1/3, Eth1/4
+100  SERVERS                          active    Po1, Eth1/1, Eth1/2, Eth1/53
+200  STORAGE                          active    Po1, Eth1/53
+`
+	profile.Responses["show mac address-table"] = `Legend:
+        * - primary entry, G - Gateway MAC, (R) - Routed MAC, O - Overlay MAC
+        age - seconds since last seen,+ - primary entry using vPC Peer-Link,
+        (T) - True, (F) - False, C - ControlPlane MAC, ~ - vsan
+   VLAN     MAC Address      Type      age     Secure NTFY Ports
+---------+-----------------+--------+---------+------+----+------------------
+*  100     0050.56a1.1a01   dynamic  0         F      F    Eth1/1
+*  100     0050.56a1.1a02   dynamic  0         F      F    Eth1/2
++  200     0050.56a1.2b03   dynamic  0         F      F    vPC Peer-Link
+G    -     00de.fb12.3456   static   -         F      F    sup-eth1(R)
+`
+	profile.Responses["show cdp neighbors"] = `Capability Codes: R - Router, T - Trans-Bridge, B - Source-Route-Bridge
+                  S - Switch, H - Host, I - IGMP, r - Repeater,
+                  V - VoIP-Phone, D - Remotely-Managed-Device,
+                  s - Supports-STP-Dispute
+
+Device-ID          Local Intrfce  Hldtme Capability  Platform      Port ID
+spine01(FDO11111AAA)
+                   Eth1/49        171    R S I s     N9K-C9336C-FX Eth1/1
+spine02(FDO22222BBB)
+                   Eth1/50        165    R S I s     N9K-C9336C-FX Eth1/1
+leaf02(FDO33333CCC)
+                   Eth1/53        179    R S I s     N9K-C93180YC- Eth1/53
+
+Total entries displayed: 3
+`
+	profile.Responses["show lldp neighbors"] = `Capability codes:
+  (R) Router, (B) Bridge, (T) Telephone, (C) DOCSIS Cable Device
+  (W) WLAN Access Point, (P) Repeater, (S) Station, (O) Other
+Device ID            Local Intf      Hold-time  Capability  Port ID
+spine01              Eth1/49         120        BR          Ethernet1/1
+spine02              Eth1/50         120        BR          Ethernet1/1
+server01             Eth1/1          120        S           a0:36:9f:11:22:33
+Total entries displayed: 3
+`
+	profile.Responses["show running-config"] = `
+!Command: show running-config
+!Running configuration last done at: Tue Oct 15 09:12:01 2024
+!Time: Wed Oct 16 10:42:17 2024
+
+version 10.3(4a) Bios:version 05.47
+hostname leaf01
+feature lacp
+feature vpc
+feature lldp
+
+interface Ethernet1/1
+  description server01-eth0
+  switchport access vlan 100
+
+interface Ethernet1/2
+  description server02-eth0
+  switchport access vlan 100
+
+interface Ethernet1/49
+  description spine01 Eth1/1
+  no switchport
+  ip address 10.255.1.1/31
+  no shutdown
+`
+	profile.Responses["show clock detail"] = `10:42:17.381 EST Wed Oct 16 2024
+Time source is NTP
+`
+	return profile
+}
+
+// asr9KProfile is an ASR 9000 aggregation router on IOS-XR 7.9. Configuration is
+// commit-based and "show privilege" does not exist.
+func asr9KProfile() Profile {
+	profile := Profile{Name: ProfileASR9K, Hostname: "xr01", PromptPrefix: "RP/0/RSP0/CPU0:", Platform: cisco.PlatformIOSXR, Responses: map[string]string{}}
+
+	profile.Responses["show version"] = `Mon Oct 14 10:02:11.482 UTC
+Cisco IOS XR Software, Version 7.9.2
+Copyright (c) 2013-2023 by Cisco Systems, Inc.
+
+Build Information:
+ Built By     : ingunawa
+ Built On     : Thu Jul 20 03:14:59 PDT 2023
+ Build Host   : iox-ucs-060
+ Workspace    : /auto/srcarchive17/prod/7.9.2/asr9k-x64/ws
+ Version      : 7.9.2
+ Location     : /opt/cisco/XR/packages/
+ Label        : 7.9.2
+
+cisco ASR9K () processor
+System uptime is 12 weeks 3 days 4 hours 21 minutes
+`
+	profile.Responses["show interface"] = `Mon Oct 14 10:02:15.907 UTC
+TenGigE0/0/0/0 is up, line protocol is up
+  Interface state transitions: 1
+  Hardware is TenGigE, address is 6c9c.ed12.3400 (bia 6c9c.ed12.3400)
+  Description: to-core01 Te1/1/1
+  Internet address is 10.0.0.1/30
+  MTU 1514 bytes, BW 10000000 Kbit (Max: 10000000 Kbit)
+     reliability 255/255, txload 0/255, rxload 0/255
+  Encapsulation ARPA,
+  Full-duplex, 10000Mb/s, LR, link type is force-up
+  output flow control is off, input flow control is off
+  Carrier delay (up) is 10 msec
+  loopback not set,
+  Last link flapped 4w2d
+  ARP type ARPA, ARP timeout 04:00:00
+  Last input 00:00:00, output 00:00:00
+  Last clearing of "show interface" counters never
+  5 minute input rate 18241000 bits/sec, 2311 packets/sec
+  5 minute output rate 9120000 bits/sec, 1804 packets/sec
+     8123456789 packets input, 6543210987654 bytes, 12 total input drops
+     0 drops for unrecognized upper-level protocol
+     Received 10 broadcast packets, 203411 multicast packets
+              0 runts, 0 giants, 0 throttles, 0 parity
+     4 input errors, 1 CRC, 0 frame, 0 overrun, 0 ignored, 0 abort
+     7012345678 packets output, 5432109876543 bytes, 3 total output drops
+     Output 5 broadcast packets, 101020 multicast packets
+     0 output errors, 0 underruns, 0 applique, 0 resets
+     0 output buffer failures, 0 output buffers swapped out
+     1 carrier transitions
+
+Bundle-Ether1 is up, line protocol is up
+  Interface state transitions: 1
+  Hardware is Aggregated Ethernet interface(s), address is 6c9c.ed12.3401
+  Description: to-agg02
+  Internet address is 10.0.1.1/30
+  MTU 1514 bytes, BW 20000000 Kbit (Max: 20000000 Kbit)
+     reliability 255/255, txload 0/255, rxload 0/255
+  Encapsulation ARPA,
+  Full-duplex, 20000Mb/s
+  loopback not set,
+  ARP type ARPA, ARP timeout 04:00:00
+    No. of members in this bundle: 2
+      TenGigE0/0/0/1               Full-duplex  10000Mb/s    Active
+      TenGigE0/0/0/2               Full-duplex  10000Mb/s    Active
+  Last input 00:00:00, output 00:00:00
+  Last clearing of "show interface" counters never
+  5 minute input rate 2000 bits/sec, 2 packets/sec
+  5 minute output rate 3000 bits/sec, 3 packets/sec
+     1234567 packets input, 987654321 bytes, 0 total input drops
+     0 drops for unrecognized upper-level protocol
+     Received 0 broadcast packets, 4521 multicast packets
+              0 runts, 0 giants, 0 throttles, 0 parity
+     0 input errors, 0 CRC, 0 frame, 0 overrun, 0 ignored, 0 abort
+     2345678 packets output, 876543210 bytes, 0 total output drops
+     Output 0 broadcast packets, 4499 multicast packets
+     0 output errors, 0 underruns, 0 applique, 0 resets
+     0 output buffer failures, 0 output buffers swapped out
+     0 carrier transitions
+`
+	profile.Responses["show ip interface brief"] = `Mon Oct 14 10:02:17.113 UTC
+
+Interface                      IP-Address      Status          Protocol Vrf-Name
+Loopback0                      10.255.0.1      Up              Up       default
+MgmtEth0/RSP0/CPU0/0           192.0.2.10      Up              Up       mgmt
+TenGigE0/0/0/0                 10.0.0.1        Up              Up       default
+TenGigE0/0/0/1                 unassigned      Up              Up       default
+TenGigE0/0/0/2                 unassigned      Up              Up       default
+TenGigE0/0/0/3                 unassigned      Shutdown        Down     default
+Bundle-Ether1                  10.0.1.1        Up              Up       default
+`
+	return profile
+}
+
+// cbs350Profile is a CBS350 Small Business branch switch. It pages until "terminal
+// datadump" and ignores "terminal length 0".
+func cbs350Profile() Profile {
+	profile := Profile{Name: ProfileCBS350, Hostname: "branch-sw01", Platform: cisco.PlatformSmallBusiness, Responses: map[string]string{}}
+
+	profile.Responses["show version"] = `Active-image: flash://system/images/image_cbs_ros_3.0.0.69_release_cisco_signed.bin
+  Version: 3.0.0.69
+  MD5 Digest: 0f3e6ae12bbd1d7ec4a1c6fc1e4d36a4
+  Date: 12-Jan-2020
+  Time: 19:37:12
+Inactive-image: flash://system/images/image_cbs_ros_3.0.0.61_release_cisco_signed.bin
+  Version: 3.0.0.61
+  MD5 Digest: 7b3a8d1e2c9f4a6b5d0e1f2a3b4c5d6e
+  Date: 22-Sep-2019
+  Time: 11:04:51
+`
+	profile.Responses["show interface status"] = `                                             Flow Link          Back   Mdix
+Port     Type         Duplex  Speed Neg      ctrl State       Pressure Mode
+-------- ------------ ------  ----- -------- ---- ----------- -------- -------
+gi1/0/1  1G-Copper    Full    1000  Enabled  Off  Up          Disabled On
+gi1/0/2  1G-Copper      --      --     --     --  Down           --     --
+gi1/0/3  1G-Copper    Full    100   Enabled  Off  Up          Disabled Off
+te1/0/1  10G-Fiber    Full    10000 Disabled Off  Up          Disabled --
+
+                                          Flow    Link
+Ch       Type    Duplex  Speed  Neg      control  State
+-------- ------- ------  -----  -------- -------  -----------
+Po1         --     --      --      --       --    Not Present
+`
+	profile.Responses["show interfaces status"] = profile.Responses["show interface status"]
+	return profile
+}
+
+// asa5516Profile is the active unit of an ASA 5516-X failover pair on ASA 9.16. Sessions
+// start in user EXEC and page until "terminal pager 0".
+func asa5516Profile() Profile {
+	profile := Profile{Name: ProfileASA5516, Hostname: "fw01", Platform: cisco.PlatformASA, Responses: map[string]string{}}
+
+	profile.Responses["show version"] = `Cisco Adaptive Security Appliance Software Version 9.16(4)
+SSP Operating System Version 2.10(1.162)
+Device Manager Version 7.18(1)
+
+Compiled on Thu 11-May-23 14:57 GMT by builders
+System image file is "disk0:/asa9-16-4-lfbff-k8.SPA"
+Config file at boot was "startup-config"
+
+fw01 up 229 days 10 hours
+failover cluster up 1 year 12 days
+
+Hardware:   ASA5516, 8192 MB RAM, CPU Atom C2000 series 2416 MHz, 1 CPU (8 cores)
+Serial Number: JAD2235ABCD
+Configuration register is 0x1
+Configuration last modified by admin at 09:12:44.117 UTC Mon Oct 12 2026
+`
+	profile.Responses["show interface"] = `Interface GigabitEthernet1/1 "outside", is up, line protocol is up
+  Hardware is Accelerator rev01, BW 1000 Mbps, DLY 10 usec
+	Auto-Duplex(Full-duplex), Auto-Speed(1000 Mbps)
+	Input flow control is unsupported, output flow control is off
+	MAC address 00a6.cafe.1001, MTU 1500
+	IP address 203.0.113.2, subnet mask 255.255.255.248
+	81234567 packets input, 65432109876 bytes, 0 no buffer
+	Received 1204 broadcasts, 0 runts, 0 giants
+	3 input errors, 2 CRC, 0 frame, 1 overrun, 0 ignored, 0 abort
+	0 pause input, 0 resume input
+	0 L2 decode drops
+	72345678 packets output, 54321098765 bytes, 0 underruns
+	0 pause output, 0 resume output
+	0 output errors, 0 collisions, 0 interface resets
+	0 late collisions, 0 deferred
+	0 input reset drops, 0 output reset drops
+	input queue (blocks free curr/low): hardware (2028/1894)
+	output queue (blocks free curr/low): hardware (2047/1782)
+  Traffic Statistics for "outside":
+	81230000 packets input, 64000000000 bytes
+	72340000 packets output, 53000000000 bytes
+	18211 packets dropped
+      1 minute input rate 2411 pkts/sec,  1930000 bytes/sec
+      1 minute output rate 2210 pkts/sec,  1650000 bytes/sec
+      1 minute drop rate, 1 pkts/sec
+      5 minute input rate 2300 pkts/sec,  1850000 bytes/sec
+      5 minute output rate 2100 pkts/sec,  1600000 bytes/sec
+      5 minute drop rate, 0 pkts/sec
+Interface GigabitEthernet1/2 "inside", is up, line protocol is up
+  Hardware is Accelerator rev01, BW 1000 Mbps, DLY 10 usec
+	Auto-Duplex(Full-duplex), Auto-Speed(1000 Mbps)
+	Input flow control is unsupported, output flow control is off
+	MAC address 00a6.cafe.1002, MTU 1500
+	IP address 10.1.0.1, subnet mask 255.255.255.0
+	72340000 packets input, 53000000000 bytes, 0 no buffer
+	Received 20311 broadcasts, 0 runts, 0 giants
+	0 input errors, 0 CRC, 0 frame, 0 overrun, 0 ignored, 0 abort
+	81230000 packets output, 64000000000 bytes, 0 underruns
+	0 output errors, 0 collisions, 0 interface resets
+  Traffic Statistics for "inside":
+	72330000 packets input, 52900000000 bytes
+	81220000 packets output, 63900000000 bytes
+	0 packets dropped
+      5 minute input rate 2100 pkts/sec,  1600000 bytes/sec
+      5 minute output rate 2300 pkts/sec,  1850000 bytes/sec
+Interface Management1/1 "", is administratively down, line protocol is down
+  Hardware is en_vtun rev00, BW 1000 Mbps, DLY 10 usec
+	Auto-Duplex, Auto-Speed
+	MAC address 00a6.cafe.10ff, MTU not set
+	IP address unassigned
+	0 packets input, 0 bytes, 0 no buffer
+	0 packets output, 0 bytes, 0 underruns
+`
+	profile.Responses["show failover"] = `Failover On
+Failover unit Primary
+Failover LAN Interface: folink GigabitEthernet0/3 (up)
+Reconnect timeout 0:00:00
+Unit Poll frequency 1 seconds, holdtime 15 seconds
+Interface Poll frequency 5 seconds, holdtime 25 seconds
+Interface Policy 1
+Monitored Interfaces 3 of 1049 maximum
+MAC Address Move Notification Interval not set
+failover replication http
+Version: Ours 9.16(4), Mate 9.16(4)
+Serial Number: Ours JAD2235ABCD, Mate JAD2235WXYZ
+Last Failover at: 14:22:08 UTC Mar 3 2026
+        This host: Primary - Active
+                Active time: 19823451 (sec)
+                slot 0: ASA5516 hw/sw rev (3.0/9.16(4)) status (Up Sys)
+                  Interface outside (203.0.113.2): Normal (Monitored)
+                  Interface inside (10.1.0.1): Normal (Monitored)
+                  Interface management (192.168.1.1): Normal (Waiting)
+        Other host: Secondary - Standby Ready
+                Active time: 0 (sec)
+                slot 0: ASA5516 hw/sw rev (3.0/9.16(4)) status (Up Sys)
+                  Interface outside (203.0.113.3): Normal (Monitored)
+                  Interface inside (10.1.0.2): Normal (Monitored)
+                  Interface management (192.168.1.2): Normal (Waiting)
+
+Stateful Failover Logical Update Statistics
+        Link : folink GigabitEthernet0/3 (up)
+        Stateful Obj    xmit       xerr       rcv        rerr
+        General         2718734    0          104523     0
+`
+	profile.Responses["show conn count"] = "54 in use, 2310 most used\n"
+	profile.Responses["show arp"] = `	outside 203.0.113.1 0022.bd11.7f01 14
+	inside 10.1.0.20 0050.56a1.2201 1203
+	inside 10.1.0.21 0050.56a1.2202 52
+	inside 10.1.0.254 00a1.b2c3.f0fe 7
+`
+	return profile
+}
@@ -0,0 +1,512 @@
+// Package ciscotest provides an in-process SSH server that emulates the shell of a
+// Cisco switch, so code using the cisco package can be tested without hardware.
+package ciscotest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xtokio/cisco"
+	"golang.org/x/crypto/ssh"
+)
+
+// DefaultPageLength is the number of lines printed before a "--More--" prompt until
+// the client sends "terminal length 0", matching the IOS default of 24.
+const DefaultPageLength = 24
+
+// InvalidInput is printed for commands that have no canned response.
+const InvalidInput = "                   ^\n% Invalid input detected at '^' marker.\n"
+
+const morePrompt = " --More-- "
+
+// Server is a fake switch listening on 127.0.0.1. It answers commands with canned
+// responses, tracks configuration mode in its prompt, pages output like IOS until
+// paging is disabled, and records every command it receives.
+type Server struct {
+	Hostname string
+
+	// PromptPrefix is printed before the hostname in the prompt, e.g. "RP/0/RSP0/CPU0:"
+	// on IOS-XR.
+	PromptPrefix string
+
+	// Platform is set on the clients returned by Client. On PlatformIOSXR the server
+	// also leaves configuration mode on "abort".
+	Platform cisco.Platform
+
+	// Username and Password are the accepted credentials. If Password is empty any
+	// credentials are accepted.
+	Username string
+	Password string
+
+	// AuthorizedKeys are the public keys accepted for the Username. PublicKeyOnly
+	// refuses password logins, as on switches configured for public keys only.
+	AuthorizedKeys []ssh.PublicKey
+	PublicKeyOnly  bool
+
+	// KeyboardInteractive asks for the password with a keyboard-interactive
+	// "Password:" challenge instead of accepting password authentication.
+	KeyboardInteractive bool
+
+	// UserExec starts sessions in user EXEC (">") until "enable", like logins below
+	// privilege 15; ASA sessions always do. EnablePassword, when set, is what
+	// "enable" accepts, with three attempts as on IOS.
+	UserExec       bool
+	EnablePassword string
+
+	// AllowForwarding lets clients open TCP connections through the server, so it can
+	// stand in for a jump host.
+	AllowForwarding bool
+
+	mu         sync.Mutex
+	responses  map[string]string
+	delays     map[string]time.Duration
+	pageLength int
+	received   []string
+
+	listener net.Listener
+	config   *ssh.ServerConfig
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a fake switch named hostname answering with the given responses,
+// keyed by the exact command, e.g. cisco.Fixtures. Responses are trimmed as in
+// SetResponse. The caller must Close the server.
+func NewServer(hostname string, responses map[string]string) (*Server, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate host key: %w", err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create host key signer: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s := &Server{
+		Hostname:   hostname,
+		responses:  make(map[string]string),
+		delays:     make(map[string]time.Duration),
+		pageLength: DefaultPageLength,
+		listener:   listener,
+	}
+	for command, output := range responses {
+		s.responses[command] = trimCapture(command, output)
+	}
+
+	s.config = &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.PublicKeyOnly || s.KeyboardInteractive {
+				return nil, fmt.Errorf("password authentication disabled")
+			}
+			return nil, s.checkPassword(conn.User(), string(password))
+		},
+		KeyboardInteractiveCallback: func(conn ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			s.mu.Lock()
+			enabled := s.KeyboardInteractive && !s.PublicKeyOnly
+			s.mu.Unlock()
+			if !enabled {
+				// The method is always offered, so refuse it the way a client expects:
+				// after a challenge, here an empty one.
+				challenge(conn.User(), "", nil, nil)
+				return nil, fmt.Errorf("keyboard-interactive authentication disabled")
+			}
+			answers, err := challenge(conn.User(), "", []string{"Password: "}, []bool{false})
+			if err != nil || len(answers) != 1 {
+				return nil, fmt.Errorf("authentication failed for %s", conn.User())
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return nil, s.checkPassword(conn.User(), answers[0])
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, authorized := range s.AuthorizedKeys {
+				if conn.User() == s.Username && bytes.Equal(authorized.Marshal(), key.Marshal()) {
+					return nil, nil
+				}
+			}
+			return nil, fmt.Errorf("public key not authorized for %s", conn.User())
+		},
+	}
+	s.config.AddHostKey(signer)
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// checkPassword accepts any credentials when no Password is set. s.mu must be held.
+func (s *Server) checkPassword(username string, password string) error {
+	if s.Password != "" && (username != s.Username || password != s.Password) {
+		return fmt.Errorf("authentication failed for %s", username)
+	}
+	return nil
+}
+
+// Addr returns the host:port the server listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Port returns the TCP port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Client dials the server and returns a cisco.Client for it.
+func (s *Server) Client() (*cisco.Client, error) {
+	s.mu.Lock()
+	password := s.Password
+	sshConfig := &ssh.ClientConfig{
+		User: s.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(s.Password),
+			ssh.KeyboardInteractive(func(name string, instruction string, questions []string, echos []bool) ([]string, error) {
+				return []string{password}, nil
+			}),
+		},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         time.Second,
+	}
+	s.mu.Unlock()
+
+	sshClient, err := ssh.Dial("tcp", s.Addr(), sshConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial SSH to %s: %w", s.Addr(), err)
+	}
+	return &cisco.Client{Client: sshClient, SwitchHostname: s.Hostname, Platform: s.Platform}, nil
+}
+
+// SetResponse sets the output printed for command. Captured output that still
+// contains the echoed command and the final prompt, as in cisco.Fixtures, is trimmed.
+func (s *Server) SetResponse(command string, output string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[command] = trimCapture(command, output)
+}
+
+// SetDelay makes the server wait d before answering command, e.g. to exercise timeouts.
+// Use "" to delay every command.
+func (s *Server) SetDelay(command string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delays[command] = d
+}
+
+// SetPageLength sets the number of lines per page for new sessions, until they send
+// "terminal length". Zero disables paging altogether.
+func (s *Server) SetPageLength(lines int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageLength = lines
+}
+
+// Received returns every command received so far, across all sessions, in order.
+func (s *Server) Received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+// Close stops the server and waits for the accept loop to exit. Open sessions are
+// closed by the clients' own teardown.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() == "direct-tcpip" && s.AllowForwarding {
+			go forward(newChannel)
+			continue
+		}
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go s.handleSession(channel, channelRequests)
+	}
+}
+
+func (s *Server) handleSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	for request := range requests {
+		switch request.Type {
+		case "pty-req", "env", "window-change":
+			request.Reply(true, nil)
+		case "shell":
+			request.Reply(true, nil)
+			go s.shell(channel)
+		default:
+			request.Reply(false, nil)
+		}
+	}
+}
+
+// shell runs one interactive session until the client exits from exec mode.
+func (s *Server) shell(channel ssh.Channel) {
+	defer channel.Close()
+
+	s.mu.Lock()
+	session := &shellSession{server: s, channel: channel, reader: bufio.NewReader(channel), pageLength: s.pageLength}
+	session.userExec = s.Platform == cisco.PlatformASA || s.UserExec
+	s.mu.Unlock()
+
+	for {
+		promptChar := "#"
+		if session.userExec {
+			promptChar = ">"
+		}
+		io.WriteString(channel, s.PromptPrefix+s.Hostname+session.mode+promptChar)
+
+		line, err := session.reader.ReadString('\n')
+		command := strings.TrimSpace(line)
+		if err != nil && command == "" {
+			return
+		}
+
+		io.WriteString(channel, command+"\r\n")
+		if command == "" {
+			continue
+		}
+		if done := session.execute(command); done {
+			channel.SendRequest("exit-status", false, []byte{0, 0, 0, 0})
+			return
+		}
+	}
+}
+
+// shellSession holds the per-session state of the emulated shell.
+type shellSession struct {
+	server  *Server
+	channel ssh.Channel
+	reader  *bufio.Reader
+	mode    string // Prompt suffix, e.g., "(config-if)"
+
+	pageLength int  // Zero once the client sent "terminal length 0" or "terminal datadump"
+	userExec   bool // Sessions in user EXEC until "enable", see Server.UserExec
+}
+
+// execute runs one command and reports whether the session should end.
+func (ss *shellSession) execute(command string) bool {
+	s := ss.server
+
+	s.mu.Lock()
+	s.received = append(s.received, command)
+	delay, ok := s.delays[command]
+	if !ok {
+		delay = s.delays[""]
+	}
+	output, known := s.responses[command]
+	s.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+
+	fields := strings.Fields(command)
+	switch {
+	case command == "exit" && ss.mode == "":
+		return true
+	case command == "exit" && ss.mode == "(config)", command == "end", command == "abort" && ss.mode != "":
+		ss.mode = ""
+		return false
+	case command == "exit":
+		ss.mode = "(config)"
+		return false
+	case len(fields) == 3 && fields[0] == "terminal" && fields[1] == "length" && s.Platform != cisco.PlatformSmallBusiness:
+		length, err := strconv.Atoi(fields[2])
+		if err != nil {
+			ss.write(InvalidInput)
+			return false
+		}
+		ss.pageLength = length
+		return false
+	case len(fields) == 3 && fields[0] == "terminal" && fields[1] == "width" && s.Platform != cisco.PlatformSmallBusiness:
+		if _, err := strconv.Atoi(fields[2]); err != nil {
+			ss.write(InvalidInput)
+		}
+		return false
+	case command == "terminal dont-ask" && s.Platform == cisco.PlatformNXOS:
+		return false
+	case command == "terminal datadump": // Small Business switches
+		ss.pageLength = 0
+		return false
+	case command == "terminal pager 0": // ASA
+		ss.pageLength = 0
+		return false
+	case command == "enable" && ss.userExec:
+		// The password is read without echo.
+		s.mu.Lock()
+		enablePassword := s.EnablePassword
+		s.mu.Unlock()
+		for attempt := 0; attempt < 3; attempt++ {
+			io.WriteString(ss.channel, "Password: ")
+			password, _ := ss.reader.ReadString('\n')
+			io.WriteString(ss.channel, "\r\n")
+			if enablePassword == "" || strings.TrimSpace(password) == enablePassword {
+				ss.userExec = false
+				return false
+			}
+		}
+		ss.write("% Bad passwords\n")
+		return false
+	case command == "enable":
+		return false
+	case command == "disable":
+		ss.userExec = true
+		return false
+	case strings.HasPrefix(command, "conf") && ss.userExec:
+		ss.write(InvalidInput)
+		return false
+	case strings.HasPrefix(command, "clear port-security ") && ss.mode == "" && !known:
+		// Clearing secure addresses prints nothing on success.
+		return false
+	case strings.HasPrefix(command, "conf") && ss.mode == "":
+		ss.mode = "(config)"
+		return false
+	case ss.mode != "" && len(fields) > 0 && fields[0] == "interface":
+		ss.mode = "(config-if)"
+		return false
+	case ss.mode != "" && !known:
+		// Configuration commands are accepted silently.
+		return false
+	case !known:
+		ss.write(InvalidInput)
+		return false
+	}
+
+	if ss.pageLength > 0 {
+		ss.writePaged(output, ss.pageLength)
+	} else {
+		ss.write(output)
+	}
+	return false
+}
+
+func (ss *shellSession) write(output string) {
+	io.WriteString(ss.channel, strings.ReplaceAll(output, "\n", "\r\n"))
+}
+
+// writePaged prints output pageLength lines at a time, waiting at a "--More--" prompt
+// like IOS: space shows the next page, return the next line, anything else stops.
+func (ss *shellSession) writePaged(output string, pageLength int) {
+	lines := strings.SplitAfter(output, "\n")
+	remaining := pageLength
+
+	for i, line := range lines {
+		if remaining == 0 && i < len(lines)-1 {
+			io.WriteString(ss.channel, morePrompt)
+			key, err := ss.reader.ReadByte()
+			erase := strings.Repeat("\b", len(morePrompt))
+			io.WriteString(ss.channel, erase+strings.Repeat(" ", len(morePrompt))+erase)
+			switch {
+			case err != nil:
+				return
+			case key == ' ':
+				remaining = pageLength
+			case key == '\r' || key == '\n':
+				remaining = 1
+			default:
+				return
+			}
+		}
+		ss.write(line)
+		remaining--
+	}
+}
+
+// trimCapture removes the "switch#command" echo at the start of a captured output
+// and the prompt lines after it, since the server prints its own.
+func trimCapture(command string, output string) string {
+	lines := strings.Split(output, "\n")
+	if len(lines) > 0 && strings.HasSuffix(strings.TrimRight(lines[0], "\r"), "#"+command) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 {
+		last := strings.TrimSpace(lines[len(lines)-1])
+		if last != "" && !strings.HasSuffix(last, "#exit") && !strings.HasSuffix(last, "#") {
+			break
+		}
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// forward connects a direct-tcpip channel to the address it asks for and copies data
+// both ways until either side closes.
+func forward(newChannel ssh.NewChannel) {
+	var target struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, "malformed forwarding request")
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+	if err != nil {
+		newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	go func() {
+		io.Copy(channel, conn)
+		channel.Close()
+	}()
+	io.Copy(conn, channel)
+	conn.Close()
+}
@@ -0,0 +1,298 @@
+package ciscotest
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// shell is an interactive session on a Server, driven byte by byte like a terminal.
+type shell struct {
+	t      *testing.T
+	stdin  io.Writer
+	output chan byte
+}
+
+// openShell starts a shell session on server, closed when the test ends.
+func openShell(t *testing.T, server *Server) *shell {
+	t.Helper()
+	client, err := ssh.Dial("tcp", server.Addr(), &ssh.ClientConfig{
+		User:            "admin",
+		Auth:            []ssh.AuthMethod{ssh.Password("admin")},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Shell(); err != nil {
+		t.Fatal(err)
+	}
+
+	output := make(chan byte, 4096)
+	go func() {
+		defer close(output)
+		buf := make([]byte, 1024)
+		for {
+			n, err := stdout.Read(buf)
+			for _, b := range buf[:n] {
+				output <- b
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return &shell{t: t, stdin: stdin, output: output}
+}
+
+// send types text into the session.
+func (sh *shell) send(text string) {
+	sh.t.Helper()
+	if _, err := io.WriteString(sh.stdin, text); err != nil {
+		sh.t.Fatal(err)
+	}
+}
+
+// expect reads until the output ends with suffix and returns everything read.
+func (sh *shell) expect(suffix string) string {
+	sh.t.Helper()
+	var read strings.Builder
+	timeout := time.After(2 * time.Second)
+	for !strings.HasSuffix(read.String(), suffix) {
+		select {
+		case b, ok := <-sh.output:
+			if !ok {
+				sh.t.Fatalf("session ended waiting for %q, got %q", suffix, read.String())
+			}
+			read.WriteByte(b)
+		case <-timeout:
+			sh.t.Fatalf("timed out waiting for %q, got %q", suffix, read.String())
+		}
+	}
+	return read.String()
+}
+
+func newServer(t *testing.T, profileName string) *Server {
+	t.Helper()
+	server, err := NewSimulator(profileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func TestPromptTracksConfigurationMode(t *testing.T) {
+	sh := openShell(t, newServer(t, Profile2960X))
+
+	sh.expect("switch01#")
+	sh.send("configure terminal\n")
+	sh.expect("switch01(config)#")
+	sh.send("interface Gi1/0/1\n")
+	sh.expect("switch01(config-if)#")
+	sh.send("exit\n")
+	sh.expect("switch01(config)#")
+	sh.send("end\n")
+	sh.expect("switch01#")
+}
+
+func TestPromptPrefix(t *testing.T) {
+	sh := openShell(t, newServer(t, ProfileASR9K))
+	sh.expect("RP/0/RSP0/CPU0:xr01#")
+}
+
+func TestUnknownCommandIsRejected(t *testing.T) {
+	sh := openShell(t, newServer(t, Profile2960X))
+
+	sh.expect("switch01#")
+	sh.send("show bogus\n")
+	if output := sh.expect("switch01#"); !strings.Contains(output, "% Invalid input detected") {
+		t.Errorf("output = %q, want the invalid input error", output)
+	}
+}
+
+func TestPagingUntilTerminalLength(t *testing.T) {
+	server := newServer(t, Profile2960X)
+	server.SetResponse("show numbers", "one\ntwo\nthree\nfour\nfive\n")
+	server.SetPageLength(2)
+	sh := openShell(t, server)
+
+	sh.expect("switch01#")
+	sh.send("show numbers\n")
+	if output := sh.expect(morePrompt); !strings.Contains(output, "two") || strings.Contains(output, "three") {
+		t.Fatalf("first page = %q, want two lines", output)
+	}
+	sh.send(" ") // Next page
+	if output := sh.expect(morePrompt); !strings.Contains(output, "four") || strings.Contains(output, "five") {
+		t.Fatalf("second page = %q, want two more lines", output)
+	}
+	sh.send("\r") // Next line
+	if output := sh.expect("switch01#"); !strings.Contains(output, "five") {
+		t.Fatalf("last line = %q, want five", output)
+	}
+
+	sh.send("terminal length 0\n")
+	sh.expect("switch01#")
+	sh.send("show numbers\n")
+	if output := sh.expect("switch01#"); strings.Contains(output, "More") || !strings.Contains(output, "five") {
+		t.Errorf("output after terminal length 0 = %q, want all of it unpaged", output)
+	}
+}
+
+func TestPagingStopsOnOtherKeys(t *testing.T) {
+	server := newServer(t, Profile2960X)
+	server.SetResponse("show numbers", "one\ntwo\nthree\nfour\n")
+	server.SetPageLength(2)
+	sh := openShell(t, server)
+
+	sh.expect("switch01#")
+	sh.send("show numbers\n")
+	sh.expect(morePrompt)
+	sh.send("q")
+	if output := sh.expect("switch01#"); strings.Contains(output, "three") {
+		t.Errorf("output after q = %q, want the rest dropped", output)
+	}
+}
+
+func TestEnable(t *testing.T) {
+	server := newServer(t, Profile2960X)
+	server.UserExec = true
+	server.EnablePassword = "secret"
+	sh := openShell(t, server)
+
+	sh.expect("switch01>")
+	sh.send("configure terminal\n")
+	if output := sh.expect("switch01>"); !strings.Contains(output, "% Invalid input detected") {
+		t.Errorf("configure terminal in user EXEC = %q, want it rejected", output)
+	}
+
+	sh.send("enable\n")
+	sh.expect("Password: ")
+	sh.send("wrong\n")
+	sh.expect("Password: ")
+	sh.send("secret\n")
+	sh.expect("switch01#")
+
+	sh.send("disable\n")
+	sh.expect("switch01>")
+}
+
+func TestEnableGivesUpAfterThreeAttempts(t *testing.T) {
+	server := newServer(t, Profile2960X)
+	server.UserExec = true
+	server.EnablePassword = "secret"
+	sh := openShell(t, server)
+
+	sh.expect("switch01>")
+	sh.send("enable\n")
+	for range 3 {
+		sh.expect("Password: ")
+		sh.send("wrong\n")
+	}
+	if output := sh.expect("switch01>"); !strings.Contains(output, "% Bad passwords") {
+		t.Errorf("output = %q, want %q", output, "% Bad passwords")
+	}
+}
+
+func TestDelay(t *testing.T) {
+	server := newServer(t, Profile2960X)
+	server.SetDelay("show version", 300*time.Millisecond)
+	sh := openShell(t, server)
+
+	sh.expect("switch01#")
+	start := time.Now()
+	sh.send("show version\n")
+	sh.expect("switch01#")
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("show version answered after %s, want at least 300ms", elapsed)
+	}
+
+	start = time.Now()
+	sh.send("show cdp neighbors\n")
+	sh.expect("switch01#")
+	if elapsed := time.Since(start); elapsed >= 300*time.Millisecond {
+		t.Errorf("show cdp neighbors answered after %s, want no delay", elapsed)
+	}
+}
+
+func TestDelayTimesOutClient(t *testing.T) {
+	server := newServer(t, Profile2960X)
+	server.SetDelay("show version", time.Second)
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.CommandTimeout = 200 * time.Millisecond
+
+	if _, err := client.RunCommand("show version"); err == nil {
+		t.Fatal("RunCommand succeeded, want a timeout")
+	}
+}
+
+func TestClientSessionSetup(t *testing.T) {
+	for _, test := range []struct {
+		profile string
+		setup   []string
+	}{
+		{Profile2960X, []string{"terminal length 0", "terminal width 511"}},
+		{ProfileNexus9K, []string{"terminal length 0", "terminal width 511", "terminal dont-ask"}},
+	} {
+		t.Run(test.profile, func(t *testing.T) {
+			server := newServer(t, test.profile)
+			client, err := server.Client()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			if _, err := client.RunCommand("show version"); err != nil {
+				t.Fatal(err)
+			}
+			received := server.Received()
+			want := append(test.setup, "show version")
+			if len(received) < len(want) || strings.Join(received[:len(want)], "\n") != strings.Join(want, "\n") {
+				t.Errorf("received %q, want %q first", received, want)
+			}
+		})
+	}
+}
+
+func TestProfilesReportTheirPlatform(t *testing.T) {
+	for _, name := range ProfileNames() {
+		t.Run(name, func(t *testing.T) {
+			server := newServer(t, name)
+			client, err := server.Client()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			platform, _, err := client.DetectPlatform()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if profile, _ := LookupProfile(name); profile.Platform != "" && platform != profile.Platform {
+				t.Errorf("platform = %q, want %q", platform, profile.Platform)
+			}
+		})
+	}
+}
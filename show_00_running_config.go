@@ -1,10 +1,13 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"regexp"
 	"strings"
+	"time"
 )
 
 type InterfaceConfig struct {
@@ -12,23 +15,51 @@ type InterfaceConfig struct {
 	ConfigLines []string
 }
 
+// Regex to match the start of an interface block: "interface <name>"
+// It captures the interface name group (e.g., FastEthernet0/1, Vlan1, Port-channel1)
+var interfaceStartRegex = regexp.MustCompile(`^interface\s+(\S+)$`)
+
 // Show_running_config executes the command, parses the interface configs, and saves them to the DB.
 func Show_running_config(switch_hostname string) ([]InterfaceConfig, error) {
+	return Show_running_config_context(context.Background(), switch_hostname)
+}
+
+// Show_running_config_context is Show_running_config with a context that cancels the command.
+func Show_running_config_context(ctx context.Context, switch_hostname string) ([]InterfaceConfig, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowRunningConfigContext(ctx)
+}
+
+// ShowRunningConfig is Show_running_config over the connection of the client.
+func (c *Client) ShowRunningConfig() ([]InterfaceConfig, error) {
+	return c.ShowRunningConfigContext(context.Background())
+}
+
+// ShowRunningConfigContext is ShowRunningConfig with a context that cancels the command.
+func (c *Client) ShowRunningConfigContext(ctx context.Context) ([]InterfaceConfig, error) {
+	switch_hostname := c.SwitchHostname
 	// 1. Run the command
-	outputString, err := RunCommand(switch_hostname, "show running-config")
+	outputString, err := c.RunCommandContext(ctx, "show running-config")
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. Parse the output
-	interfaceConfigs, err := parseInterfaceConfig(outputString)
+	parseStart := time.Now()
+	interfaceConfigs, err := parseWith(c.platform(), "show running-config", outputString, ParseInterfaceConfig)
+	err = reportParse(switch_hostname, "show running-config", outputString, len(interfaceConfigs), parseStart, err)
 	if err != nil {
-		log.Printf("%s :: Show Running-Config :: Error during parsing: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "%s :: Show Running-Config :: Error during parsing: %v", switch_hostname, err)
 		return nil, err
 	}
 
 	if len(interfaceConfigs) == 0 {
-		log.Printf("Show Running-Config :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Show Running-Config :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
 		return nil, nil
 	}
 
@@ -41,32 +72,49 @@ func Show_running_config(switch_hostname string) ([]InterfaceConfig, error) {
 
 // --- PARSING FUNCTION ---
 
-// parseInterfaceConfig processes the raw CLI output from "show running-config"
+// ParseInterfaceConfig processes the raw CLI output from "show running-config"
 // to extract the configuration block for each interface.
-func parseInterfaceConfig(rawOutput string) ([]InterfaceConfig, error) {
+func ParseInterfaceConfig(rawOutput string) ([]InterfaceConfig, error) {
 	var configs []InterfaceConfig
-	lines := strings.Split(rawOutput, "\n")
 
-	// Regex to match the start of an interface block: "interface <name>"
-	// It captures the interface name group (e.g., FastEthernet0/1, Vlan1, Port-channel1)
-	interfaceStartRegex := regexp.MustCompile(`^interface\s+(\S+)$`)
+	err := ParseRunningConfigStream(strings.NewReader(rawOutput), func(config InterfaceConfig) error {
+		configs = append(configs, config)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no interface configurations found")
+	}
+
+	return configs, nil
+}
+
+// ParseRunningConfigStream reads "show running-config" output line by line from r
+// and calls fn once per interface block as soon as the block is complete, so
+// multi-megabyte configurations never have to be held in memory as one string.
+// Parsing stops at the first error returned by fn.
+func ParseRunningConfigStream(r io.Reader, fn func(InterfaceConfig) error) error {
 	var currentConfig *InterfaceConfig = nil
 
-	for _, line := range lines {
+	err := scanLines(r, func(line string) error {
 		line = strings.TrimSpace(line) // Remove leading/trailing whitespace
 
 		if line == "" || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "version") || strings.HasPrefix(line, "hostname") {
 			// Skip empty lines, '!', and global configuration lines for simplicity.
 			// A more robust parser might track indentation to properly skip non-interface blocks.
-			continue
+			return nil
 		}
 
 		// Check for the start of a new interface block
 		if matches := interfaceStartRegex.FindStringSubmatch(line); len(matches) > 1 {
-			// 1. If we were already in an interface block, save the previous one.
+			// 1. If we were already in an interface block, emit the previous one.
 			if currentConfig != nil {
-				configs = append(configs, *currentConfig)
+				if err := fn(*currentConfig); err != nil {
+					return err
+				}
 			}
 
 			// 2. Start a new interface block
@@ -84,16 +132,16 @@ func parseInterfaceConfig(rawOutput string) ([]InterfaceConfig, error) {
 			currentConfig.ConfigLines = append(currentConfig.ConfigLines, line)
 		}
 		// If currentConfig is nil, we are in the global config block, so we ignore the line (due to the initial 'continue' checks).
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Append the *last* collected configuration block, if one exists.
+	// Emit the *last* collected configuration block, if one exists.
 	if currentConfig != nil {
-		configs = append(configs, *currentConfig)
-	}
-
-	if len(configs) == 0 {
-		return nil, fmt.Errorf("no interface configurations found")
+		return fn(*currentConfig)
 	}
 
-	return configs, nil
+	return nil
 }
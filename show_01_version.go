@@ -1,15 +1,18 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // VersionInfo defines the structure for the parsed "show version" output.
-// It's used as an intermediate struct within the parsing function.
+// Show_version returns its string fields as a map; Show_version_struct returns it as is.
 type VersionInfo struct {
 	Hardware      string
 	Version       string
@@ -20,62 +23,180 @@ type VersionInfo struct {
 	Restarted     string
 	ReloadReason  string
 	Rommon        string
+
+	UptimeDuration time.Duration // Uptime parsed into a Duration (years count as 365 days)
+	Platform       Platform
+	StackMembers   []StackMember // One entry per switch in the "Switch Ports Model" table
+}
+
+// StackMember defines a single switch of a stack as listed by "show version".
+type StackMember struct {
+	Switch          string
+	Active          bool // Marked with "*" (the switch the session is connected to)
+	Ports           string
+	Model           string
+	SoftwareVersion string
+	SoftwareImage   string
+	Mode            string // INSTALL or BUNDLE (IOS-XE only)
+	SerialNumber    string
+}
+
+// versionRegexes holds the regular expressions for each piece of data we want
+// to capture from "show version", keyed by VersionInfo field name.
+var versionRegexes = map[string]*regexp.Regexp{
+	// Hardware: (IOS/IE1000) | (ASA) | (Nexus: Chassis name)
+	"Hardware": regexp.MustCompile(`(?i)cisco ([\w-]+[a-z\d\-]+) .* processor|^Hardware:\s*([^,]+),|Board Type\s*:\s*(\S+)|Product\s*:\s*Cisco ([\w\s]+) Switch|cisco (Nexus\S+ [\w-]+ Chassis)|cisco ([\w-]+ Chassis)`),
+
+	// Version: (IOS-XR) | (ASA) | (IOS) | (IE1000) | (Nexus: system version) | (Small Business: active image)
+	"Version": regexp.MustCompile(`(?i)IOS XR Software, Version (\S+)|Security Appliance Software Version (\S+)|Version ([^,]+),|NXOS:\s*version\s*(\S+).*|Active Image\s*:\s*.*?\nVersion\s*:\s*(\S+)|Software Version\s*:\s*(\S+)|system:\s*version\s*(\S+)|^Version:\s*(\S+)$`),
+
+	// Release: (IOS only, not easily mapped for NX-OS/IE1000)
+	"Release": regexp.MustCompile(`(?i)Version [^,]+, (RELEASE SOFTWARE .*)`),
+
+	// SoftwareImage: (IOS) | (IE1000) | (Nexus: system image file) | (Small Business)
+	"SoftwareImage": regexp.MustCompile(`(?i)System image file is "([^"]+)"|NXOS image file is:\s*(\S+)|Active Image\s*:\s*([^\s(]+)|system image file is:\s*(\S+)|Active-image:\s*(\S+)`),
+
+	// SerialNumber: (IOS: System/Processor ID) | (IE1000: MAC Address) | (Nexus: Processor Board ID) | (ASA)
+	"SerialNumber": regexp.MustCompile(`(?i)(?:System serial number\s*:\s*(\S+)|Processor board ID\s*(\S+)|MAC Address\s*:\s*(\S+)|Processor Board ID\s*(\S+)|Serial Number:\s*(\S+))`),
+
+	// Uptime: (IOS) | (ASA: "fw01 up 229 days") | (IE1000) | (Nexus: Kernel uptime)
+	"Uptime": regexp.MustCompile(`(?i)uptime is (.+)|^[\w.-]+ up (\d.+)|System Uptime\s*:\s*(\S+)|Kernel uptime is (.+)`),
+
+	// Restarted: (IOS) | (IE1000) | (Nexus: Last reset reason) - Nexus uses Last Reset/Reason instead of 'Restarted At'
+	// We'll capture the time-like string from the IOS/IE1000, or the Reason/System Version from Nexus.
+	"Restarted": regexp.MustCompile(`(?i)System restarted at (.*)|Previous Restart\s*:\s*(.*)|Last reset\s*\n\s*Reason:\s*(\S+)`),
+
+	// ReloadReason: (IOS) | (Nexus: Last reset reason)
+	"ReloadReason": regexp.MustCompile(`(?i)(?:Last reload reason: (.*)|System returned to ROM by (.*)|Last reset\s*\n\s*Reason:\s*(.*))`),
+
+	// Rommon: (IOS: ROM) | (IE1000: Bootloader) | (Nexus: BIOS)
+	"Rommon": regexp.MustCompile(`(?i)ROM: (.*)|Bootloader\s*:\s*(\S+)|BIOS:\s*version\s*(\S+)`),
 }
 
+// reUptimePart matches one "<n> <unit>" component of an uptime string, e.g. "12 weeks" or "3 day(s)".
+var reUptimePart = regexp.MustCompile(`(\d+)\s+(year|week|day|hour|minute|second)`)
+
+// reStackMemberSection matches the "Switch 02" heading of a stack member's details.
+var reStackMemberSection = regexp.MustCompile(`^Switch\s+0*(\d+)$`)
+
 // Show_version connects to a switch, runs "show version", and returns the parsed data as a map.
 func Show_version(switch_hostname string) (map[string]string, error) {
-	outputString, err := RunCommand(switch_hostname, "show version")
+	return Show_version_context(context.Background(), switch_hostname)
+}
+
+// Show_version_context is Show_version with a context that cancels the command.
+func Show_version_context(ctx context.Context, switch_hostname string) (map[string]string, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowVersionContext(ctx)
+}
+
+// ShowVersion is Show_version over the connection of the client.
+func (c *Client) ShowVersion() (map[string]string, error) {
+	return c.ShowVersionContext(context.Background())
+}
+
+// ShowVersionContext is ShowVersion with a context that cancels the command.
+func (c *Client) ShowVersionContext(ctx context.Context) (map[string]string, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show version")
 	if err != nil {
 		return nil, err
 	}
 
 	// --- PARSE OUTPUT ---
-	show_version_data, err := parseVersionInfo(outputString)
+	parseStart := time.Now()
+	show_version_data, err := ParseVersionInfo(outputString)
+	err = reportParse(switch_hostname, "show version", outputString, len(show_version_data), parseStart, err)
 	if err != nil {
-		log.Printf("Error parsing 'show version' output for %s: %v", switch_hostname, err)
-		return nil, fmt.Errorf("error parsing 'show version' output for %s: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "Error parsing 'show version' output for %s: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error parsing 'show version' output for %s: %w", switch_hostname, err)
 	}
 
 	return show_version_data, nil
 }
 
-// parseVersionInfo processes the raw CLI output from "show version".
-// It returns a map of string keys to string values.
-func parseVersionInfo(rawOutput string) (map[string]string, error) {
-	var info VersionInfo
-	result := make(map[string]string) // Initialize the map to be returned
+// Show_version_struct connects to a switch, runs "show version", and returns the parsed data
+// as a VersionInfo, including stack members, uptime as a Duration and the detected platform.
+func Show_version_struct(switch_hostname string) (VersionInfo, error) {
+	return Show_version_struct_context(context.Background(), switch_hostname)
+}
 
-	// Define regular expressions for each piece of data we want to capture.
-	regexes := map[string]*regexp.Regexp{
-		// Hardware: (IOS/IE1000) | (Nexus: Chassis name)
-		"Hardware": regexp.MustCompile(`(?i)cisco ([\w-]+[a-z\d\-]+) .* processor|Board Type\s*:\s*(\S+)|Product\s*:\s*Cisco ([\w\s]+) Switch|cisco (Nexus\S+ [\w-]+ Chassis)|cisco ([\w-]+ Chassis)`),
+// Show_version_struct_context is Show_version_struct with a context that cancels the command.
+func Show_version_struct_context(ctx context.Context, switch_hostname string) (VersionInfo, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return VersionInfo{}, err
+	}
+	defer client.Close()
+
+	return client.ShowVersionStructContext(ctx)
+}
 
-		// Version: (IOS) | (IE1000) | (Nexus: system version)
-		"Version": regexp.MustCompile(`(?i)Version ([^,]+),|NXOS:\s*version\s*(\S+).*|Active Image\s*:\s*.*?\nVersion\s*:\s*(\S+)|Software Version\s*:\s*(\S+)|system:\s*version\s*(\S+)`),
+// ShowVersionStruct is Show_version_struct over the connection of the client.
+func (c *Client) ShowVersionStruct() (VersionInfo, error) {
+	return c.ShowVersionStructContext(context.Background())
+}
 
-		// Release: (IOS only, not easily mapped for NX-OS/IE1000)
-		"Release": regexp.MustCompile(`(?i)Version [^,]+, (RELEASE SOFTWARE .*)`),
+// ShowVersionStructContext is ShowVersionStruct with a context that cancels the command.
+func (c *Client) ShowVersionStructContext(ctx context.Context) (VersionInfo, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show version")
+	if err != nil {
+		return VersionInfo{}, err
+	}
 
-		// SoftwareImage: (IOS) | (IE1000) | (Nexus: system image file)
-		"SoftwareImage": regexp.MustCompile(`(?i)System image file is "([^"]+)"|NXOS image file is:\s*(\S+)|Active Image\s*:\s*([^\s(]+)|system image file is:\s*(\S+)`),
+	parseStart := time.Now()
+	show_version_data, err := ParseVersionStruct(outputString)
+	err = reportParse(switch_hostname, "show version", outputString, 1, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "Error parsing 'show version' output for %s: %v", switch_hostname, err)
+		return VersionInfo{}, fmt.Errorf("error parsing 'show version' output for %s: %w", switch_hostname, err)
+	}
 
-		// SerialNumber: (IOS: System/Processor ID) | (IE1000: MAC Address) | (Nexus: Processor Board ID)
-		"SerialNumber": regexp.MustCompile(`(?i)(?:System serial number\s*:\s*(\S+)|Processor board ID\s*(\S+)|MAC Address\s*:\s*(\S+)|Processor Board ID\s*(\S+))`),
+	return show_version_data, nil
+}
 
-		// Uptime: (IOS) | (IE1000) | (Nexus: Kernel uptime)
-		"Uptime": regexp.MustCompile(`(?i)uptime is (.+)|System Uptime\s*:\s*(\S+)|Kernel uptime is (.+)`),
+// ParseVersionInfo processes the raw CLI output from "show version".
+// It returns a map of string keys to string values.
+func ParseVersionInfo(rawOutput string) (map[string]string, error) {
+	info, err := ParseVersionStruct(rawOutput)
+	if err != nil {
+		return nil, err
+	}
 
-		// Restarted: (IOS) | (IE1000) | (Nexus: Last reset reason) - Nexus uses Last Reset/Reason instead of 'Restarted At'
-		// We'll capture the time-like string from the IOS/IE1000, or the Reason/System Version from Nexus.
-		"Restarted": regexp.MustCompile(`(?i)System restarted at (.*)|Previous Restart\s*:\s*(.*)|Last reset\s*\n\s*Reason:\s*(\S+)`),
+	return info.Map(), nil
+}
 
-		// ReloadReason: (IOS) | (Nexus: Last reset reason)
-		"ReloadReason": regexp.MustCompile(`(?i)(?:Last reload reason: (.*)|System returned to ROM by (.*)|Last reset\s*\n\s*Reason:\s*(.*))`),
+// Map returns the regex-backed string fields of the VersionInfo keyed by field name,
+// the form returned by Show_version.
+func (info VersionInfo) Map() map[string]string {
+	result := make(map[string]string) // Initialize the map to be returned
 
-		// Rommon: (IOS: ROM) | (IE1000: Bootloader) | (Nexus: BIOS)
-		"Rommon": regexp.MustCompile(`(?i)ROM: (.*)|Bootloader\s*:\s*(\S+)|BIOS:\s*version\s*(\S+)`),
+	// Convert the regex-backed string fields of the struct to a map
+	v := reflect.ValueOf(info)
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		if _, ok := versionRegexes[t.Field(i).Name]; !ok {
+			continue
+		}
+		value := v.Field(i).String()
+		if value != "" { // Only add keys for values that were found
+			result[t.Field(i).Name] = value
+		}
 	}
 
+	return result
+}
+
+// ParseVersionStruct processes the raw CLI output from "show version" into a VersionInfo.
+func ParseVersionStruct(rawOutput string) (VersionInfo, error) {
+	var info VersionInfo
+
 	// Use reflection to dynamically match regexes to struct fields
 	v := reflect.ValueOf(&info).Elem()
 	t := v.Type()
@@ -86,36 +207,124 @@ func parseVersionInfo(rawOutput string) (map[string]string, error) {
 			fieldName := t.Field(i).Name
 			fieldValue := v.Field(i)
 
-			if fieldValue.String() == "" { // Only parse if not already found
-				if re, ok := regexes[fieldName]; ok {
-					if matches := re.FindStringSubmatch(cleanLine); len(matches) > 1 {
-						// Iterate over all subgroups to find the first non-empty match
-						for j := 1; j < len(matches); j++ {
-							match := strings.TrimSpace(matches[j])
-							if match != "" {
-								fieldValue.SetString(match)
-								break // Found the value for this field, move to next field
-							}
-						}
+			re, ok := versionRegexes[fieldName]
+			if !ok || fieldValue.String() != "" { // Only parse if not already found
+				continue
+			}
+			if matches := re.FindStringSubmatch(cleanLine); len(matches) > 1 {
+				// Iterate over all subgroups to find the first non-empty match
+				for j := 1; j < len(matches); j++ {
+					match := strings.TrimSpace(matches[j])
+					if match != "" {
+						fieldValue.SetString(match)
+						break // Found the value for this field, move to next field
 					}
 				}
 			}
 		}
 	}
 
-	// Check if we found at least some data
-	if info.Version == "" || info.SerialNumber == "" {
-		return nil, fmt.Errorf("could not parse essential version info from output")
+	info.Platform = detectPlatform(rawOutput)
+
+	// Check if we found at least some data. IOS-XR and Small Business switches only
+	// report the serial number in "show inventory".
+	if info.Version == "" || (info.SerialNumber == "" && info.Platform != PlatformIOSXR && info.Platform != PlatformSmallBusiness) {
+		return VersionInfo{}, fmt.Errorf("could not parse essential version info from output")
 	}
 
-	// Convert the populated struct to a map
-	for i := 0; i < v.NumField(); i++ {
-		value := v.Field(i).String()
-		if value != "" { // Only add keys for values that were found
-			key := t.Field(i).Name
-			result[key] = value
+	info.UptimeDuration = parseUptime(info.Uptime)
+	info.StackMembers = parseStackMembers(rawOutput)
+
+	// The first member's serial is the main "System serial number" line.
+	if len(info.StackMembers) > 0 && info.StackMembers[0].SerialNumber == "" {
+		info.StackMembers[0].SerialNumber = info.SerialNumber
+	}
+
+	return info, nil
+}
+
+// parseUptime converts an uptime such as "1 year, 12 weeks, 3 days, 4 hours, 10 minutes"
+// or "12 day(s), 3 hour(s), 4 minute(s)" into a Duration.
+func parseUptime(uptime string) time.Duration {
+	units := map[string]time.Duration{
+		"year":   365 * 24 * time.Hour,
+		"week":   7 * 24 * time.Hour,
+		"day":    24 * time.Hour,
+		"hour":   time.Hour,
+		"minute": time.Minute,
+		"second": time.Second,
+	}
+
+	var total time.Duration
+	for _, matches := range reUptimePart.FindAllStringSubmatch(uptime, -1) {
+		n, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+		total += time.Duration(n) * units[matches[2]]
+	}
+	return total
+}
+
+// parseStackMembers extracts the "Switch Ports Model SW Version SW Image" table and the
+// per-member serial numbers from the "Switch 0N" sections that follow it on stacks.
+func parseStackMembers(rawOutput string) []StackMember {
+	var members []StackMember
+	inTable := false
+	currentSection := ""
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) >= 3 && fields[0] == "Switch" && fields[1] == "Ports" && fields[2] == "Model" {
+			inTable = true
+			continue
+		}
+
+		if inTable {
+			if len(fields) == 0 {
+				inTable = false
+				continue
+			}
+			if strings.HasPrefix(fields[0], "---") {
+				continue
+			}
+			member := StackMember{}
+			if fields[0] == "*" {
+				member.Active = true
+				fields = fields[1:]
+			}
+			if len(fields) < 5 {
+				inTable = false
+				continue
+			}
+			member.Switch = fields[0]
+			member.Ports = fields[1]
+			member.Model = fields[2]
+			member.SoftwareVersion = fields[3]
+			member.SoftwareImage = fields[4]
+			if len(fields) > 5 {
+				member.Mode = fields[5]
+			}
+			members = append(members, member)
+			continue
+		}
+
+		trimmedLine := strings.TrimSpace(line)
+		if matches := reStackMemberSection.FindStringSubmatch(trimmedLine); len(matches) > 1 {
+			currentSection = matches[1]
+			continue
+		}
+		if currentSection != "" && strings.HasPrefix(strings.ToLower(trimmedLine), "system serial number") {
+			if _, serial, ok := strings.Cut(trimmedLine, ":"); ok {
+				for i := range members {
+					if members[i].Switch == currentSection {
+						members[i].SerialNumber = strings.TrimSpace(serial)
+					}
+				}
+			}
 		}
 	}
 
-	return result, nil
+	return members
 }
@@ -1,21 +1,27 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // InterfaceDetails defines the structure for the detailed information of a single interface.
 type InterfaceDetails struct {
 	Interface      string
+	Nameif         string // ASA only: the name security policy refers to, e.g., outside
 	Description    string
 	Hardware       string
 	MacAddress     string
 	IPAddress      string
 	LinkStatus     string
 	ProtocolStatus string
+	PortStatus     string // Keyword after the protocol status, e.g., connected, notconnect, disabled, err-disabled, monitoring
 	Duplex         string
 	Speed          string
 	MediaType      string
@@ -43,24 +49,136 @@ type InterfaceDetails struct {
 	OutputErrors   string
 	CrcErrors      string
 	Collisions     string
+	InputDrops     string
+	OutputDrops    string
 }
 
+// Regexes used by the "show interfaces" parser. They are compiled once at
+// package init instead of once per interface block, which dominated parse
+// time on large switches.
+var (
+	// We require the first word to contain at least one digit.
+	// This matches "GigabitEthernet1/0/13" and "Ethernet101/1/23"
+	// but will NOT match "admin state is up...".
+	reInterfaceStart = regexp.MustCompile(`^(\S+\d+\S*)\s+is\s+.*`)
+
+	// rePrompt matches a bare device prompt such as "switch#" or "switch>".
+	rePrompt = regexp.MustCompile(`^\S+[>#]\s*$`)
+
+	// Status: Made "line protocol" optional to handle both "is up, line protocol is up" (IOS)
+	// and just "is up" (Nexus). The keyword in parentheses ("(connected)", and on IOS-XE 17.x
+	// also "(monitoring)", "(suspended)", "(inactive)") is captured separately.
+	reStatus = regexp.MustCompile(`^(\S+)\s+is\s+(administratively down|down|up|err-disabled|deleted)(?:,\s+line\s+protocol\s+is\s+(down|up)(?:\s+\(([\w-]+)\))?)?`)
+
+	// Hardware: Allows "Hardware is" (IOS) or "Hardware:" (Nexus)
+	reHardware = regexp.MustCompile(`Hardware(?::| is) ([^,]+), address is ([\w\.]+)`)
+
+	reDescription = regexp.MustCompile(`Description:\s*(.*)`)
+	reAddress     = regexp.MustCompile(`Internet address is ([\d\.]+\/\d+)`)
+
+	// Mtu/Bw/Dly: Made "/sec" and trailing comma optional. IOS-XR prints no delay and
+	// adds the maximum bandwidth: "MTU 1514 bytes, BW 1000000 Kbit (Max: 1000000 Kbit)"
+	reMtuBwDly = regexp.MustCompile(`MTU (\d+) bytes, BW (\d+) Kbit(?:/sec)?(?: \(Max: \d+ Kbit\))?(?:, DLY (\d+) usec)?(?:,)?`)
+
+	// Duplex/Speed/Media: Made "media type" optional (present in IOS, absent in Nexus).
+	// IOS-XE 17.x routing platforms print "Full Duplex, 1000Mbps, link type is auto, media type is RJ45".
+	reDuplexSpeedMedia   = regexp.MustCompile(`\s*(\S+-duplex|\S+ Duplex),\s*([^,\n]+)(?:,\s*link type is [^,]+)?(?:,\s*media type is (.*))?`)
+	reDuplexSpeedMediaXR = regexp.MustCompile(`\s*(\S+-duplex),\s*([^,]+),\s*([^,]+),\s*link type is`) // IOS-XR: "Full-duplex, 1000Mb/s, SX, link type is force-up"
+
+	// Encapsulation: Made trailing comma optional
+	reEncapsulation = regexp.MustCompile(`\s*Encapsulation ([^,]+),?`)
+
+	reReliabilityLoad = regexp.MustCompile(`reliability\s+(\d+\/\d+),\s+txload\s+(\d+\/\d+),\s+rxload\s+(\d+\/\d+)`)
+
+	// Rates: Looks for "5 minute" (IOS) or "30 seconds" (Nexus) which both use "bits/sec"
+	reInputRate  = regexp.MustCompile(`(?:5 minute|30 seconds) input rate (\d+) bits/sec`)
+	reOutputRate = regexp.MustCompile(`(?:5 minute|30 seconds) output rate (\d+) bits/sec`)
+
+	// Counters: Allows "packets input" (IOS) or "input packets" (Nexus) and an optional comma
+	reInputCounters = regexp.MustCompile(`(\d+)\s+(?:packets\s+input|input\s+packets)(?:,)?\s+(\d+)\s+bytes`)
+
+	// --- Split Input/CRC Errors for Nexus ---
+	reInputErrors      = regexp.MustCompile(`(\d+)\s+input\s+errors,\s+(\d+)\s+CRC`) // IOS
+	reInputErrorsNexus = regexp.MustCompile(`(\d+)\s+input\s+error(?:s)?`)           // Nexus Input Errors
+	reCrcErrorsNexus   = regexp.MustCompile(`(\d+)\s+CRC`)                           // Nexus CRC (found elsewhere in block)
+
+	// Counters: Allows "packets output" (IOS) or "output packets" (Nexus) and an optional comma
+	reOutputCounters = regexp.MustCompile(`(\d+)\s+(?:packets\s+output|output\s+packets)(?:,)?\s+(\d+)\s+bytes`)
+
+	// Output Errors: Allows optional comma and "collision" or "collisions"
+	reOutputErrors = regexp.MustCompile(`(\d+)\s+output\s+errors(?:,)?\s+(\d+)\s+collision(?:s)?`)
+
+	// IOS-XR reports no collisions: "0 output errors, 0 underruns, 0 applique, 0 resets",
+	// nor does IOS on SVIs: "0 output errors, 0 interface resets"
+	reOutputErrorsXR = regexp.MustCompile(`(\d+)\s+output\s+errors`)
+
+	reLastIO        = regexp.MustCompile(`\s*Last input\s+(.*?),` + `\s+output\s+(.*?),` + `\s+output hang\s+(.*)`)
+	reLastIOXR      = regexp.MustCompile(`(?m)^\s*Last input\s+(.*?),\s+output\s+(\S+)\s*$`) // IOS-XR has no output hang
+	reQueueStrategy = regexp.MustCompile(`Queueing strategy:\s*(.*)`)
+
+	// --- Split Runts/Giants/Throttles for Nexus ---
+	reRuntsGiantsThrottles = regexp.MustCompile(`\s*(\d+)\s+runts,\s+(\d+)\s+giants,\s+(\d+)\s+throttles`) // IOS
+	reRuntsGiantsNexus     = regexp.MustCompile(`\s*(\d+)\s+runts\s+(\d+)\s+giants`)                       // Nexus (no throttles here, and no commas)
+
+	// --- Drops: IOS reports them in the queue lines, Nexus as "discard" counters ---
+	reInputQueueDrops    = regexp.MustCompile(`Input queue:\s*\d+/\d+/(\d+)/\d+`) // IOS (size/max/drops/flushes)
+	reTotalOutputDrops   = regexp.MustCompile(`Total output drops:\s*(\d+)`)      // IOS
+	reInputDiscardNexus  = regexp.MustCompile(`(\d+)\s+input\s+discard`)          // Nexus
+	reOutputDiscardNexus = regexp.MustCompile(`(\d+)\s+output\s+discard`)         // Nexus
+	reInputDropsXR       = regexp.MustCompile(`(\d+)\s+total input drops`)        // IOS-XR
+	reOutputDropsXR      = regexp.MustCompile(`(\d+)\s+total output drops`)       // IOS-XR
+
+	// --- ASA: the header names the nameif, and several lines have their own layout ---
+	reAsaInterfaceStart = regexp.MustCompile(`^Interface (\S+) "([^"]*)", is (administratively down|down|up), line protocol is (down|up)`)
+	reAsaHardware       = regexp.MustCompile(`Hardware is ([^,]+), BW (\d+) Mbps, DLY (\d+) usec`)
+	reAsaDuplexSpeed    = regexp.MustCompile(`Duplex\(([\w-]+)\),\s*(?:Auto-Speed\(|\d+ Mbps\()(\d+) Mbps\)`) // "Auto-Duplex(Full-duplex), Auto-Speed(1000 Mbps)"
+	reAsaMacMtu         = regexp.MustCompile(`MAC address ([\w.]+), MTU (\d+)`)
+	reAsaAddress        = regexp.MustCompile(`IP address ([\d.]+), subnet mask ([\d.]+)`)
+	reAsaRuntsGiants    = regexp.MustCompile(`(\d+) runts, (\d+) giants`)
+	reAsaRates          = regexp.MustCompile(`(?s)5 minute input rate \d+ pkts/sec,\s+(\d+) bytes/sec.*5 minute output rate \d+ pkts/sec,\s+(\d+) bytes/sec`)
+	reAsaDrops          = regexp.MustCompile(`(\d+) packets dropped`)
+)
+
 // Show_interfaces connects to a switch, gets interface data, and returns it as a map.
 func Show_interfaces(switch_hostname string) ([]InterfaceDetails, error) {
-	outputString, err := RunCommand(switch_hostname, "show interface")
+	return Show_interfaces_context(context.Background(), switch_hostname)
+}
+
+// Show_interfaces_context is Show_interfaces with a context that cancels the command.
+func Show_interfaces_context(ctx context.Context, switch_hostname string) ([]InterfaceDetails, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
 	if err != nil {
 		return nil, err
 	}
+	defer client.Close()
+
+	return client.ShowInterfacesContext(ctx)
+}
 
-	show_interface_data, err := parseInterfaces(outputString)
+// ShowInterfaces is Show_interfaces over the connection of the client.
+func (c *Client) ShowInterfaces() ([]InterfaceDetails, error) {
+	return c.ShowInterfacesContext(context.Background())
+}
+
+// ShowInterfacesContext is ShowInterfaces with a context that cancels the command.
+func (c *Client) ShowInterfacesContext(ctx context.Context) ([]InterfaceDetails, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show interface")
 	if err != nil {
-		log.Printf("Error during parsing 'show interfaces' output for %s: %v", switch_hostname, err)
-		return nil, fmt.Errorf("error during parsing 'show interfaces' output for %s: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	show_interface_data, err := parseWith(c.platform(), "show interface", outputString, ParseInterfaces)
+	err = reportParse(switch_hostname, "show interface", outputString, len(show_interface_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "Error during parsing 'show interfaces' output for %s: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show interfaces' output for %s: %w", switch_hostname, err)
 	}
 
 	// Check the length of the slice, not the map.
 	if len(show_interface_data) == 0 {
-		log.Printf("Show Interfaces ::Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Show Interfaces ::Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
 		return nil, nil
 	}
 
@@ -83,29 +201,41 @@ func findString(re *regexp.Regexp, s string) string {
 	return ""
 }
 
-// parseInterfaces is updated with a highly specific reInterfaceStart regex.
-func parseInterfaces(rawOutput string) ([]InterfaceDetails, error) {
+// ParseInterfaces processes the raw CLI output from "show interface" into one
+// InterfaceDetails per interface block, using a highly specific reInterfaceStart regex.
+// It tries the IOS, NX-OS and IOS-XR formats of every counter in turn; use
+// ParseInterfacesFor when the platform is known.
+func ParseInterfaces(rawOutput string) ([]InterfaceDetails, error) {
+	return ParseInterfacesFor(PlatformUnknown, rawOutput)
+}
+
+// ParseInterfacesFor is ParseInterfaces for output of the given platform, e.g. from
+// DetectPlatform, which only tries that platform's format of every counter, so a
+// counter missing from a block is left empty instead of being matched by another
+// platform's looser pattern. PlatformUnknown tries them all.
+func ParseInterfacesFor(platform Platform, rawOutput string) ([]InterfaceDetails, error) {
+	dialect := interfaceDialectOf(platform)
 	var interfaces []InterfaceDetails
 	var currentBlock []string
 
-	// THIS IS THE CRITICAL CHANGE:
-	// We now require the first word to contain at least one digit.
-	// This matches "GigabitEthernet1/0/13" and "Ethernet101/1/23"
-	// but will NOT match "admin state is up...".
-	reInterfaceStart := regexp.MustCompile(`^(\S+\d+\S*)\s+is\s+.*`)
-
 	// --- Cleaning Logic ---
 	var cleanLines []string
 	parsingActive := false
-	rePrompt := regexp.MustCompile(`^\S+[>#]\s*$`)
+	started := false
 
 	lines := strings.Split(rawOutput, "\n")
 	for _, line := range lines {
 		line = strings.TrimRight(line, "\r")
 		if !parsingActive && strings.Contains(line, "show interface") {
 			parsingActive = true
+			started = true
 			continue
 		}
+		// Output captured without the command echo starts at the first interface.
+		if !started && (reInterfaceStart.MatchString(line) || reAsaInterfaceStart.MatchString(line)) {
+			parsingActive = true
+			started = true
+		}
 		if parsingActive && rePrompt.MatchString(line) {
 			parsingActive = false
 		}
@@ -115,9 +245,9 @@ func parseInterfaces(rawOutput string) ([]InterfaceDetails, error) {
 	}
 
 	for _, line := range cleanLines {
-		if reInterfaceStart.MatchString(line) {
+		if reInterfaceStart.MatchString(line) || reAsaInterfaceStart.MatchString(line) {
 			if len(currentBlock) > 0 {
-				iface := parseSingleInterface(strings.Join(currentBlock, "\n"))
+				iface := parseSingleInterface(strings.Join(currentBlock, "\n"), dialect)
 				if iface.Interface != "" {
 					interfaces = append(interfaces, iface)
 				}
@@ -131,7 +261,7 @@ func parseInterfaces(rawOutput string) ([]InterfaceDetails, error) {
 
 	// Process the last block
 	if len(currentBlock) > 0 {
-		iface := parseSingleInterface(strings.Join(currentBlock, "\n"))
+		iface := parseSingleInterface(strings.Join(currentBlock, "\n"), dialect)
 		if iface.Interface != "" {
 			interfaces = append(interfaces, iface)
 		}
@@ -140,90 +270,119 @@ func parseInterfaces(rawOutput string) ([]InterfaceDetails, error) {
 	return interfaces, nil
 }
 
-// parseSingleInterface is updated to handle both IOS and Nexus-style output.
-func parseSingleInterface(block string) InterfaceDetails {
-	iface := InterfaceDetails{}
-
-	// --- Regex definitions updated for flexibility ---
-
-	// Status: Made "line protocol" optional to handle both "is up, line protocol is up" (IOS)
-	// and just "is up" (Nexus)
-	reStatus := regexp.MustCompile(`^(\S+)\s+is\s+(administratively down|down|up|err-disabled|deleted)(?:,\s+line\s+protocol\s+is\s+(down|up|down \(disabled\)))?`)
-
-	// Hardware: Allows "Hardware is" (IOS) or "Hardware:" (Nexus)
-	reHardware := regexp.MustCompile(`Hardware(?::| is) ([^,]+), address is ([\w\.]+)`)
-
-	reDescription := regexp.MustCompile(`Description:\s*(.*)`)
-	reAddress := regexp.MustCompile(`Internet address is ([\d\.]+\/\d+)`)
-
-	// Mtu/Bw/Dly: Made "/sec" and trailing comma optional
-	reMtuBwDly := regexp.MustCompile(`MTU (\d+) bytes, BW (\d+) Kbit(?:/sec)?, DLY (\d+) usec(?:,)?`)
-
-	// Duplex/Speed/Media: Made "media type" optional (present in IOS, absent in Nexus)
-	reDuplexSpeedMedia := regexp.MustCompile(`\s*(\S+-duplex),\s*([^,]+)(?:,\s*media type is (.*))?`)
-
-	// Encapsulation: Made trailing comma optional
-	reEncapsulation := regexp.MustCompile(`\s*Encapsulation ([^,]+),?`)
+// interfaceDialect selects the formats of "show interface" counters a block is
+// parsed with; IOS-XR shares several lines with IOS.
+type interfaceDialect struct {
+	ios, nexus, xr bool
+}
 
-	reReliabilityLoad := regexp.MustCompile(`reliability\s+(\d+\/\d+),\s+txload\s+(\d+\/\d+),\s+rxload\s+(\d+\/\d+)`)
+func interfaceDialectOf(platform Platform) interfaceDialect {
+	switch platform {
+	case PlatformUnknown:
+		return interfaceDialect{ios: true, nexus: true, xr: true}
+	case PlatformNXOS:
+		return interfaceDialect{nexus: true}
+	case PlatformIOSXR:
+		return interfaceDialect{xr: true}
+	}
+	return interfaceDialect{ios: true}
+}
 
-	// Rates: Looks for "5 minute" (IOS) or "30 seconds" (Nexus) which both use "bits/sec"
-	reRates := regexp.MustCompile(`(?s)(?:5 minute|30 seconds) input rate (\d+) bits/sec,.*(?:5 minute|30 seconds) output rate (\d+) bits/sec`)
+// interfaceLines holds the lines of one interface block. Every field regex matches
+// within a single line, so it is only tried on the lines containing a keyword all of
+// its matches contain: run over the whole block, the regex engine tried each of its
+// positions in turn, which dominated parse time.
+type interfaceLines []string
 
-	// Counters: Allows "packets input" (IOS) or "input packets" (Nexus) and an optional comma
-	reInputCounters := regexp.MustCompile(`(\d+)\s+(?:packets\s+input|input\s+packets)(?:,)?\s+(\d+)\s+bytes`)
+// match returns the submatches of re in the first line containing keyword that re
+// matches, or nil.
+func (lines interfaceLines) match(keyword string, re *regexp.Regexp) []string {
+	for _, line := range lines {
+		if !strings.Contains(line, keyword) {
+			continue
+		}
+		if matches := re.FindStringSubmatch(line); matches != nil {
+			return matches
+		}
+	}
+	return nil
+}
 
-	// --- Split Input/CRC Errors for Nexus ---
-	reInputErrors := regexp.MustCompile(`(\d+)\s+input\s+errors,\s+(\d+)\s+CRC`) // IOS
-	reInputErrorsNexus := regexp.MustCompile(`(\d+)\s+input\s+error(?:s)?`)      // Nexus Input Errors
-	reCrcErrorsNexus := regexp.MustCompile(`(\d+)\s+CRC`)                        // Nexus CRC (found elsewhere in block)
+// find is findString over the lines containing keyword.
+func (lines interfaceLines) find(keyword string, re *regexp.Regexp) string {
+	if matches := lines.match(keyword, re); len(matches) > 1 {
+		return strings.TrimSpace(matches[1])
+	}
+	return ""
+}
 
-	// Counters: Allows "packets output" (IOS) or "output packets" (Nexus) and an optional comma
-	reOutputCounters := regexp.MustCompile(`(\d+)\s+(?:packets\s+output|output\s+packets)(?:,)?\s+(\d+)\s+bytes`)
+// matchIf is lines.match when use is true, and nil otherwise.
+func matchIf(use bool, lines interfaceLines, keyword string, re *regexp.Regexp) []string {
+	if !use {
+		return nil
+	}
+	return lines.match(keyword, re)
+}
 
-	// Output Errors: Allows optional comma and "collision" or "collisions"
-	reOutputErrors := regexp.MustCompile(`(\d+)\s+output\s+errors(?:,)?\s+(\d+)\s+collision(?:s)?`)
+// findStringIf is lines.find when use is true, and "" otherwise.
+func findStringIf(use bool, lines interfaceLines, keyword string, re *regexp.Regexp) string {
+	if !use {
+		return ""
+	}
+	return lines.find(keyword, re)
+}
 
-	reLastIO := regexp.MustCompile(`\s*Last input\s+(.*?),` + `\s+output\s+(.*?),` + `\s+output hang\s+(.*)`)
-	reQueueStrategy := regexp.MustCompile(`Queueing strategy:\s*(.*)`)
+// parseSingleInterface parses one interface block with the formats of dialect.
+func parseSingleInterface(block string, dialect interfaceDialect) InterfaceDetails {
+	if strings.HasPrefix(block, "Interface ") {
+		return parseAsaInterface(block, dialect)
+	}
 
-	// --- Split Runts/Giants/Throttles for Nexus ---
-	reRuntsGiantsThrottles := regexp.MustCompile(`\s*(\d+)\s+runts,\s+(\d+)\s+giants,\s+(\d+)\s+throttles`) // IOS
-	reRuntsGiantsNexus := regexp.MustCompile(`\s*(\d+)\s+runts\s+(\d+)\s+giants`)                           // Nexus (no throttles here, and no commas)
+	iface := InterfaceDetails{}
+	lines := interfaceLines(strings.Split(block, "\n"))
 
 	// --- Logic to assign values ---
 
-	if matches := reStatus.FindStringSubmatch(block); len(matches) > 2 {
+	if matches := reStatus.FindStringSubmatch(lines[0]); len(matches) > 2 {
 		iface.Interface = matches[1]
 		iface.LinkStatus = matches[2]
 		// Check if the optional 3rd capture group (protocol status) was captured
 		if len(matches) > 3 && matches[3] != "" {
-			iface.ProtocolStatus = matches[3] // IOS: "up" or "down"
+			iface.ProtocolStatus = matches[3] // IOS: "up", "down" or "down (disabled)"
+			iface.PortStatus = matches[4]
+			if iface.PortStatus == "disabled" {
+				// ProtocolStatus has always carried this one keyword.
+				iface.ProtocolStatus += " (disabled)"
+			}
 		} else {
 			// If not (e.g., Nexus output), set protocol status to be the same as link status
 			iface.ProtocolStatus = matches[2]
 		}
 	} else {
 		// This log should no longer be hit by "admin state"
-		log.Printf("Failed to parse block with reStatus regex. Block content:\n---\n%s\n---", block)
+		logf(slog.LevelDebug, "Failed to parse block with reStatus regex. Block content:\n---\n%s\n---", block)
 		return InterfaceDetails{}
 	}
 
-	if matches := reHardware.FindStringSubmatch(block); len(matches) > 2 {
+	if matches := lines.match("Hardware", reHardware); len(matches) > 2 {
 		iface.Hardware = strings.TrimSpace(matches[1])
 		iface.MacAddress = strings.TrimSpace(matches[2])
 	}
 
-	iface.Description = strings.TrimSpace(findString(reDescription, block))
-	iface.IPAddress = findString(reAddress, block)
+	iface.Description = strings.TrimSpace(lines.find("Description:", reDescription))
+	iface.IPAddress = lines.find("Internet address is", reAddress)
 
-	if matches := reMtuBwDly.FindStringSubmatch(block); len(matches) > 3 {
+	if matches := lines.match("MTU ", reMtuBwDly); len(matches) > 3 {
 		iface.Mtu = matches[1]
 		iface.Bandwidth = matches[2]
 		iface.Delay = matches[3]
 	}
 
-	if matches := reDuplexSpeedMedia.FindStringSubmatch(block); len(matches) > 2 {
+	if matches := matchIf(dialect.xr, lines, "link type is", reDuplexSpeedMediaXR); len(matches) > 3 {
+		iface.Duplex = strings.TrimSpace(matches[1])
+		iface.Speed = strings.TrimSpace(matches[2])
+		iface.MediaType = strings.TrimSpace(matches[3])
+	} else if matches := lines.match("uplex", reDuplexSpeedMedia); len(matches) > 2 {
 		iface.Duplex = strings.TrimSpace(matches[1])
 		iface.Speed = strings.TrimSpace(matches[2])
 		// Check if optional "media type" (group 3) was captured
@@ -232,65 +391,138 @@ func parseSingleInterface(block string) InterfaceDetails {
 		}
 	}
 
-	iface.Encapsulation = findString(reEncapsulation, block)
+	iface.Encapsulation = lines.find("Encapsulation ", reEncapsulation)
 
-	if matches := reReliabilityLoad.FindStringSubmatch(block); len(matches) > 3 {
+	if matches := lines.match("reliability", reReliabilityLoad); len(matches) > 3 {
 		iface.Reliability = matches[1]
 		iface.TxLoad = matches[2]
 		iface.RxLoad = matches[3]
 	}
 
-	if matches := reRates.FindStringSubmatch(block); len(matches) > 2 {
-		iface.InputRateBps = matches[1]
-		iface.OutputRateBps = matches[2]
+	if matches := lines.match("input rate", reInputRate); len(matches) > 1 {
+		if outMatches := lines.match("output rate", reOutputRate); len(outMatches) > 1 {
+			iface.InputRateBps = matches[1]
+			iface.OutputRateBps = outMatches[1]
+		}
 	}
 
-	if matches := reInputCounters.FindStringSubmatch(block); len(matches) > 2 {
+	if matches := lines.match(" bytes", reInputCounters); len(matches) > 2 {
 		iface.PacketsInput = matches[1]
 		iface.BytesInput = matches[2]
 	}
 
 	// Use conditional logic for errors, as formats differ significantly
-	if matches := reInputErrors.FindStringSubmatch(block); len(matches) > 2 {
-		// IOS style
+	if matches := matchIf(dialect.ios || dialect.xr, lines, "input errors", reInputErrors); len(matches) > 2 {
+		// IOS style, also printed by IOS-XR
 		iface.InputErrors = matches[1]
 		iface.CrcErrors = matches[2]
-	} else {
+	} else if dialect.nexus {
 		// Try Nexus style (errors and CRC are on different lines)
-		iface.InputErrors = findString(reInputErrorsNexus, block)
-		iface.CrcErrors = findString(reCrcErrorsNexus, block) // findString will get the CRC value
+		iface.InputErrors = lines.find("input error", reInputErrorsNexus)
+		iface.CrcErrors = lines.find("CRC", reCrcErrorsNexus) // findString will get the CRC value
 	}
 
-	if matches := reOutputCounters.FindStringSubmatch(block); len(matches) > 2 {
+	if matches := lines.match(" bytes", reOutputCounters); len(matches) > 2 {
 		iface.PacketsOutput = matches[1]
 		iface.BytesOutput = matches[2]
 	}
 
-	if matches := reOutputErrors.FindStringSubmatch(block); len(matches) > 2 {
+	if matches := matchIf(dialect.ios, lines, "output errors", reOutputErrors); len(matches) > 2 {
 		iface.OutputErrors = matches[1]
 		iface.Collisions = matches[2]
+	} else {
+		// IOS-XR, and IOS on SVIs, print no collisions
+		iface.OutputErrors = lines.find("output errors", reOutputErrorsXR)
 	}
 
-	if matches := reLastIO.FindStringSubmatch(block); len(matches) > 3 {
+	if matches := matchIf(dialect.ios, lines, "Last input", reLastIO); len(matches) > 3 {
 		iface.LastInput = strings.TrimSpace(matches[1])
 		iface.LastOutput = strings.TrimSpace(matches[2])
 		iface.OutputHang = strings.TrimSpace(matches[3])
+	} else if matches := matchIf(dialect.xr, lines, "Last input", reLastIOXR); len(matches) > 2 {
+		iface.LastInput = strings.TrimSpace(matches[1])
+		iface.LastOutput = strings.TrimSpace(matches[2])
 	}
 
-	iface.QueueStrategy = findString(reQueueStrategy, block)
+	iface.QueueStrategy = lines.find("Queueing strategy:", reQueueStrategy)
 
 	// Use conditional logic for runts/giants, as formats differ
-	if matches := reRuntsGiantsThrottles.FindStringSubmatch(block); len(matches) > 3 {
-		// IOS style
+	if matches := matchIf(dialect.ios || dialect.xr, lines, "runts", reRuntsGiantsThrottles); len(matches) > 3 {
+		// IOS style, also printed by IOS-XR
 		iface.Runts = matches[1]
 		iface.Giants = matches[2]
 		iface.Throttles = matches[3]
-	} else if matches := reRuntsGiantsNexus.FindStringSubmatch(block); len(matches) > 2 {
+	} else if matches := matchIf(dialect.nexus, lines, "runts", reRuntsGiantsNexus); len(matches) > 2 {
 		// Try Nexus style (no throttles on this line)
 		iface.Runts = matches[1]
 		iface.Giants = matches[2]
 		// Throttles will remain empty, which is correct
 	}
 
+	if iface.InputDrops = findStringIf(dialect.ios, lines, "Input queue:", reInputQueueDrops); iface.InputDrops == "" {
+		iface.InputDrops = findStringIf(dialect.nexus, lines, "input discard", reInputDiscardNexus)
+	}
+	if iface.InputDrops == "" {
+		iface.InputDrops = findStringIf(dialect.xr, lines, "total input drops", reInputDropsXR)
+	}
+	if iface.OutputDrops = findStringIf(dialect.ios, lines, "Total output drops:", reTotalOutputDrops); iface.OutputDrops == "" {
+		iface.OutputDrops = findStringIf(dialect.nexus, lines, "output discard", reOutputDiscardNexus)
+	}
+	if iface.OutputDrops == "" {
+		iface.OutputDrops = findStringIf(dialect.xr, lines, "total output drops", reOutputDropsXR)
+	}
+
+	return iface
+}
+
+// parseAsaInterface handles an ASA interface block. Its header is rewritten in the IOS
+// form so the counter lines the two share are parsed by parseSingleInterface; bandwidth
+// is converted to Kbit and the byte rates to bits/sec to match IOS.
+func parseAsaInterface(block string, dialect interfaceDialect) InterfaceDetails {
+	matches := reAsaInterfaceStart.FindStringSubmatch(block)
+	if len(matches) < 5 {
+		logf(slog.LevelDebug, "Failed to parse block with reAsaInterfaceStart regex. Block content:\n---\n%s\n---", block)
+		return InterfaceDetails{}
+	}
+	_, rest, _ := strings.Cut(block, "\n")
+	iface := parseSingleInterface(fmt.Sprintf("%s is %s, line protocol is %s\n%s", matches[1], matches[3], matches[4], rest), dialect)
+	iface.Nameif = matches[2]
+
+	if matches := reAsaHardware.FindStringSubmatch(block); len(matches) > 3 {
+		iface.Hardware = strings.TrimSpace(matches[1])
+		if mbps, err := strconv.Atoi(matches[2]); err == nil {
+			iface.Bandwidth = strconv.Itoa(mbps * 1000)
+		}
+		iface.Delay = matches[3]
+	}
+	if matches := reAsaDuplexSpeed.FindStringSubmatch(block); len(matches) > 2 {
+		iface.Duplex = matches[1]
+		iface.Speed = matches[2] + "Mb/s"
+	}
+	if matches := reAsaMacMtu.FindStringSubmatch(block); len(matches) > 2 {
+		iface.MacAddress = matches[1]
+		iface.Mtu = matches[2]
+	}
+	if matches := reAsaAddress.FindStringSubmatch(block); len(matches) > 2 {
+		iface.IPAddress = matches[1]
+		if mask := net.ParseIP(matches[2]).To4(); mask != nil {
+			ones, _ := net.IPMask(mask).Size()
+			iface.IPAddress = fmt.Sprintf("%s/%d", matches[1], ones)
+		}
+	}
+	if matches := reAsaRuntsGiants.FindStringSubmatch(block); len(matches) > 2 {
+		iface.Runts = matches[1]
+		iface.Giants = matches[2]
+	}
+	if matches := reAsaRates.FindStringSubmatch(block); len(matches) > 2 {
+		for i, rate := range []*string{&iface.InputRateBps, &iface.OutputRateBps} {
+			if bytes, err := strconv.Atoi(matches[i+1]); err == nil {
+				*rate = strconv.Itoa(bytes * 8)
+			}
+		}
+	}
+	// The ASA counts drops for the interface as a whole, mostly on ingress.
+	iface.InputDrops = findString(reAsaDrops, block)
+
 	return iface
 }
@@ -1,9 +1,11 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
+	"time"
 )
 
 // InterfaceStatus defines the structure for a single network interface entry.
@@ -18,31 +20,59 @@ type InterfaceStatus struct {
 }
 
 func Show_interfaces_status(switch_hostname string) ([]InterfaceStatus, error) {
-	outputString, err := RunCommand(switch_hostname, "show interface status")
+	return Show_interfaces_status_context(context.Background(), switch_hostname)
+}
+
+// Show_interfaces_status_context is Show_interfaces_status with a context that cancels the command.
+func Show_interfaces_status_context(ctx context.Context, switch_hostname string) ([]InterfaceStatus, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowInterfacesStatusContext(ctx)
+}
+
+// ShowInterfacesStatus is Show_interfaces_status over the connection of the client.
+func (c *Client) ShowInterfacesStatus() ([]InterfaceStatus, error) {
+	return c.ShowInterfacesStatusContext(context.Background())
+}
+
+// ShowInterfacesStatusContext is ShowInterfacesStatus with a context that cancels the command.
+func (c *Client) ShowInterfacesStatusContext(ctx context.Context) ([]InterfaceStatus, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show interface status")
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Parse the output and convert to JSON
-	interfaceStatusList, err := parseInterfaceStatus(outputString)
+	parseStart := time.Now()
+	interfaceStatusList, err := parseWith(c.platform(), "show interface status", outputString, ParseInterfaceStatus)
+	err = reportParse(switch_hostname, "show interface status", outputString, len(interfaceStatusList), parseStart, err)
 	if err != nil {
-		log.Printf("%s :: Show Interface Status ::Error during parsing: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "%s :: Show Interface Status ::Error during parsing: %v", switch_hostname, err)
 		return nil, err
 	}
 
+	for i := range interfaceStatusList {
+		interfaceStatusList[i].Interface = normalizeInterfaceName(interfaceStatusList[i].Interface)
+	}
+
 	// Check the length of the slice, not the map.
 	if len(interfaceStatusList) == 0 {
-		log.Printf("Show Interface Status :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Show Interface Status :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
 		return nil, nil
 	}
 
 	return interfaceStatusList, nil
 }
 
-// parseInterfaceStatus processes the raw CLI output and converts it into a list of InterfaceStatus structs.
+// ParseInterfaceStatus processes the raw CLI output and converts it into a list of InterfaceStatus structs.
 // It locates the 'Status' field first, which correctly handles variable-length
 // Description and Type fields.
-func parseInterfaceStatus(rawOutput string) ([]InterfaceStatus, error) {
+func ParseInterfaceStatus(rawOutput string) ([]InterfaceStatus, error) {
 	var interfaces []InterfaceStatus
 	lines := strings.Split(rawOutput, "\n")
 
@@ -55,6 +85,11 @@ func parseInterfaceStatus(rawOutput string) ([]InterfaceStatus, error) {
 		}
 	}
 
+	if dataStartIndex == -1 {
+		if interfaces, ok := parseSmallBusinessInterfaceStatus(lines); ok {
+			return interfaces, nil
+		}
+	}
 	if dataStartIndex == -1 || dataStartIndex >= len(lines) {
 		return nil, fmt.Errorf("could not find interface status header in output")
 	}
@@ -119,3 +154,50 @@ func parseInterfaceStatus(rawOutput string) ([]InterfaceStatus, error) {
 
 	return interfaces, nil
 }
+
+// smallBusinessLinkStates maps the link states of Small Business switches to the
+// status keywords IOS prints.
+var smallBusinessLinkStates = map[string]string{
+	"Up":          "connected",
+	"Down":        "notconnect",
+	"Not Present": "notconnect",
+}
+
+// parseSmallBusinessInterfaceStatus processes "show interfaces status" from Small
+// Business switches, which print no description or VLAN and list port-channels in a
+// second table headed "Ch". It reports false when the output has neither header.
+func parseSmallBusinessInterfaceStatus(lines []string) ([]InterfaceStatus, bool) {
+	var interfaces []InterfaceStatus
+	headerFound := false
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 2 && (fields[0] == "Port" || fields[0] == "Ch") && fields[1] == "Type" {
+			headerFound = true
+			continue
+		}
+		// Rows: Port, Type, Duplex, Speed, Neg, Flow ctrl, Link State, and for
+		// Ethernet ports Back Pressure and Mdix Mode.
+		if !headerFound || len(fields) < 7 || strings.HasPrefix(fields[0], "--") {
+			continue
+		}
+
+		state := fields[6]
+		if len(fields) != 9 {
+			state = strings.Join(fields[6:], " ")
+		}
+		if mapped, ok := smallBusinessLinkStates[state]; ok {
+			state = mapped
+		}
+
+		interfaces = append(interfaces, InterfaceStatus{
+			Interface: fields[0],
+			Status:    state,
+			Duplex:    strings.ToLower(fields[2]),
+			Speed:     fields[3],
+			Type:      fields[1],
+		})
+	}
+
+	return interfaces, headerFound
+}
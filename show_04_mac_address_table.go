@@ -1,10 +1,13 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // MacAddressEntry defines the structure for a single entry in the MAC address table.
@@ -13,37 +16,83 @@ type MacAddressEntry struct {
 	MacAddress string
 	VlanID     string
 	Type       string // e.g., DYNAMIC, STATIC, SECURE
+	Vendor     string // From the OUI database set with SetOUIDatabase
 }
 
+// reMacEntry matches a single "Vlan  Mac Address  Type  Ports" data row.
+var reMacEntry = regexp.MustCompile(`^\s*\*?\s*(\d+)\s+([\w\.]+)\s+([\w]+)(?:\s+[\w\-])*\s+(\S+)`)
+
 // Show_mac_address_table constructs the command, runs it, and processes the output.
 func Show_mac_address_table(switch_hostname string) ([]MacAddressEntry, error) {
-	outputString, err := RunCommand(switch_hostname, "show mac address-table")
+	return Show_mac_address_table_context(context.Background(), switch_hostname)
+}
+
+// Show_mac_address_table_context is Show_mac_address_table with a context that cancels the command.
+func Show_mac_address_table_context(ctx context.Context, switch_hostname string) ([]MacAddressEntry, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowMacAddressTableContext(ctx)
+}
+
+// ShowMacAddressTable is Show_mac_address_table over the connection of the client.
+func (c *Client) ShowMacAddressTable() ([]MacAddressEntry, error) {
+	return c.ShowMacAddressTableContext(context.Background())
+}
+
+// ShowMacAddressTableContext is ShowMacAddressTable with a context that cancels the command.
+func (c *Client) ShowMacAddressTableContext(ctx context.Context) ([]MacAddressEntry, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show mac address-table")
 	if err != nil {
 		return nil, err
 	}
 
 	// 2. Parse the output
-	mac_table_data, err := parseMacAddressTable(outputString)
+	parseStart := time.Now()
+	mac_table_data, err := parseWith(c.platform(), "show mac address-table", outputString, ParseMacAddressTable)
+	err = reportParse(switch_hostname, "show mac address-table", outputString, len(mac_table_data), parseStart, err)
 	if err != nil {
-		log.Printf("Error during parsing 'show mac address-table' output for %s: %v", switch_hostname, err)
-		return nil, fmt.Errorf("error during parsing 'show mac address-table' output for %s: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "Error during parsing 'show mac address-table' output for %s: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show mac address-table' output for %s: %w", switch_hostname, err)
 	}
 
 	if len(mac_table_data) == 0 {
-		log.Printf("Show MAC Address Table :: Warning: Parsing completed for %s, but no MAC entries were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Show MAC Address Table :: Warning: Parsing completed for %s, but no MAC entries were found.", switch_hostname)
 		return nil, nil
 	}
 
 	return mac_table_data, nil
 }
 
-// parseMacAddressTable takes the raw output and extracts MacAddressEntry structs.
-func parseMacAddressTable(rawOutput string) ([]MacAddressEntry, error) {
+// ParseMacAddressTable takes the raw output and extracts MacAddressEntry structs.
+func ParseMacAddressTable(rawOutput string) ([]MacAddressEntry, error) {
 	var macEntries []MacAddressEntry
-	reEntry := regexp.MustCompile(`^\s*\*?\s*(\d+)\s+([\w\.]+)\s+([\w]+)(?:\s+[\w\-])*\s+(\S+)`)
 
-	lines := strings.Split(rawOutput, "\n")
-	for _, line := range lines {
+	err := ParseMacAddressTableStream(strings.NewReader(rawOutput), func(entry MacAddressEntry) error {
+		macEntries = append(macEntries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return macEntries, nil
+}
+
+// ParseMacAddressTableStream reads "show mac address-table" output line by line
+// from r and calls fn for every entry, so tables with tens of thousands of entries
+// can be processed with bounded memory. VLAN, type and port strings repeat heavily
+// on core switches and are interned, so entries retained by fn share their storage.
+// Parsing stops at the first error returned by fn.
+func ParseMacAddressTableStream(r io.Reader, fn func(MacAddressEntry) error) error {
+	interned := make(stringInterner)
+	vendors := activeOUIDatabase()
+
+	return scanLines(r, func(line string) error {
 		line = strings.TrimSpace(line)
 
 		// Skip header, separator lines, and summary lines
@@ -53,20 +102,34 @@ func parseMacAddressTable(rawOutput string) ([]MacAddressEntry, error) {
 			strings.Contains(line, "----") ||
 			strings.Contains(line, "Total Mac Addresses") ||
 			strings.Contains(line, "CPU") { // Often the 'CPU' entries are less relevant for port checks
-			continue
+			return nil
 		}
 
-		if matches := reEntry.FindStringSubmatch(line); len(matches) == 5 {
+		if matches := reMacEntry.FindStringSubmatch(line); len(matches) == 5 {
 			entry := MacAddressEntry{
 				// Clean up the VLAN ID in case the '*' was captured with it
-				VlanID:     strings.TrimSpace(matches[1]),
-				MacAddress: matches[2],
-				Type:       matches[3],
-				Interface:  matches[4],
+				VlanID:     interned.intern(strings.TrimSpace(matches[1])),
+				MacAddress: strings.Clone(matches[2]),
+				Type:       interned.intern(matches[3]),
+				Interface:  interned.intern(matches[4]),
+				Vendor:     vendors.Vendor(matches[2]),
 			}
-			macEntries = append(macEntries, entry)
+			return fn(entry)
 		}
-	}
+		return nil
+	})
+}
 
-	return macEntries, nil
+// stringInterner deduplicates repeated strings (VLAN IDs, entry types, port names)
+// so large result sets share a single copy of each distinct value.
+type stringInterner map[string]string
+
+// intern returns the canonical copy of s, storing a detached clone on first use.
+func (in stringInterner) intern(s string) string {
+	if v, ok := in[s]; ok {
+		return v
+	}
+	v := strings.Clone(s)
+	in[v] = v
+	return v
 }
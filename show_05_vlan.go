@@ -1,10 +1,13 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // VlanInfo defines the structure for a single VLAN entry.
@@ -15,37 +18,166 @@ type VlanInfo struct {
 	Ports    []string
 }
 
+// VlanDetail is a VLAN of "show vlan" with the settings of its second table, which
+// "show vlan brief" leaves out.
+type VlanDetail struct {
+	VlanInfo
+	Type       string // e.g. enet, fddi or tr
+	SAID       string // The 802.10 security association ID; empty on NX-OS
+	MTU        int    // Zero on NX-OS
+	Parent     string // The Token Ring and FDDI settings, empty where IOS prints "-"
+	RingNo     string
+	BridgeNo   string
+	STP        string
+	BridgeMode string
+	Trans1     int // The translational bridge VLANs, zero for none
+	Trans2     int
+	Mode       string // The vlan-mode on NX-OS, e.g. CE; empty on IOS
+}
+
+// Regex to identify a line that starts a new VLAN entry (begins with a number).
+var isNewVlanLine = regexp.MustCompile(`^\d`)
+
+// reVlanPrompt matches the prompt after the table, alone or with the next command, e.g.
+// "switch01#" or "switch01#exit". "show vlan brief" has no second table to stop at.
+var reVlanPrompt = regexp.MustCompile(`^\S+[>#]`)
+
 func Show_vlan(switch_hostname string) ([]VlanInfo, error) {
-	outputString, err := RunCommand(switch_hostname, "show vlan")
+	return Show_vlan_context(context.Background(), switch_hostname)
+}
+
+// Show_vlan_context is Show_vlan with a context that cancels the command.
+func Show_vlan_context(ctx context.Context, switch_hostname string) ([]VlanInfo, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowVlanContext(ctx)
+}
+
+// ShowVlan is Show_vlan over the connection of the client.
+func (c *Client) ShowVlan() ([]VlanInfo, error) {
+	return c.ShowVlanContext(context.Background())
+}
+
+// ShowVlanContext is ShowVlan with a context that cancels the command.
+func (c *Client) ShowVlanContext(ctx context.Context) ([]VlanInfo, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show vlan")
 	if err != nil {
 		return nil, err
 	}
 
 	// --- PARSE OUTPUT ---
-	vlan_data, err := parseVlanInfo(outputString)
+	parseStart := time.Now()
+	vlan_data, err := parseWith(c.platform(), "show vlan", outputString, ParseVlanInfo)
+	err = reportParse(switch_hostname, "show vlan", outputString, len(vlan_data), parseStart, err)
 	if err != nil {
-		log.Printf("%s :: Show Vlans :: Error during parsing: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "%s :: Show Vlans :: Error during parsing: %v", switch_hostname, err)
 		return nil, err
 	}
 
 	// Check the length of the slice, not the map.
 	if len(vlan_data) == 0 {
-		log.Printf("Show VLAN :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Show VLAN :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
 		return nil, nil
 	}
 
 	return vlan_data, nil
 }
 
-// parseVlanInfo processes the raw CLI output from "show vlan" and converts it into a list of VlanInfo structs.
+// Show_vlan_brief runs "show vlan brief", for devices without the full "show vlan",
+// and returns the same VLANs as Show_vlan.
+func Show_vlan_brief(switch_hostname string) ([]VlanInfo, error) {
+	return Show_vlan_brief_context(context.Background(), switch_hostname)
+}
+
+// Show_vlan_brief_context is Show_vlan_brief with a context that cancels the command.
+func Show_vlan_brief_context(ctx context.Context, switch_hostname string) ([]VlanInfo, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowVlanBriefContext(ctx)
+}
+
+// ShowVlanBrief is Show_vlan_brief over the connection of the client.
+func (c *Client) ShowVlanBrief() ([]VlanInfo, error) {
+	return c.ShowVlanBriefContext(context.Background())
+}
+
+// ShowVlanBriefContext is ShowVlanBrief with a context that cancels the command.
+func (c *Client) ShowVlanBriefContext(ctx context.Context) ([]VlanInfo, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show vlan brief")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	vlan_data, err := parseWith(c.platform(), "show vlan brief", outputString, ParseVlanInfo)
+	err = reportParse(switch_hostname, "show vlan brief", outputString, len(vlan_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Vlan Brief :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show vlan brief' output for %s: %w", switch_hostname, err)
+	}
+
+	return vlan_data, nil
+}
+
+// Show_vlan_detail runs "show vlan" and returns its VLANs with the type, SAID, MTU and
+// bridging settings of the second table.
+func Show_vlan_detail(switch_hostname string) ([]VlanDetail, error) {
+	return Show_vlan_detail_context(context.Background(), switch_hostname)
+}
+
+// Show_vlan_detail_context is Show_vlan_detail with a context that cancels the command.
+func Show_vlan_detail_context(ctx context.Context, switch_hostname string) ([]VlanDetail, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowVlanDetailContext(ctx)
+}
+
+// ShowVlanDetail is Show_vlan_detail over the connection of the client.
+func (c *Client) ShowVlanDetail() ([]VlanDetail, error) {
+	return c.ShowVlanDetailContext(context.Background())
+}
+
+// ShowVlanDetailContext is ShowVlanDetail with a context that cancels the command.
+// Parsers registered for "show vlan" return []VlanInfo, so the output is always parsed
+// with ParseVlanDetail.
+func (c *Client) ShowVlanDetailContext(ctx context.Context) ([]VlanDetail, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show vlan")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	vlan_data, err := ParseVlanDetail(outputString)
+	err = reportParse(switch_hostname, "show vlan", outputString, len(vlan_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Vlan Detail :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show vlan' output for %s: %w", switch_hostname, err)
+	}
+
+	return vlan_data, nil
+}
+
+// ParseVlanInfo processes the raw CLI output from "show vlan" and converts it into a list of VlanInfo structs.
 // This corrected version knows when to stop parsing and properly handles empty port lists.
-func parseVlanInfo(rawOutput string) ([]VlanInfo, error) {
+func ParseVlanInfo(rawOutput string) ([]VlanInfo, error) {
 	var vlans []VlanInfo
 	lines := strings.Split(rawOutput, "\n")
 
-	// Regex to identify a line that starts a new VLAN entry (begins with a number).
-	isNewVlanLine := regexp.MustCompile(`^\d`)
-
 	dataStartIndex := -1
 	// Find the start of the data, which is 2 lines after the header "VLAN Name..."
 	for i, line := range lines {
@@ -63,14 +195,11 @@ func parseVlanInfo(rawOutput string) ([]VlanInfo, error) {
 		line := strings.TrimRight(lines[i], "\r")
 
 		// *** FIX #1: Stop parsing before the second, unrelated table begins. ***
-		if strings.HasPrefix(line, "VLAN Type") {
+		// The table ends at a blank line or, in "show vlan brief", at the prompt.
+		if strings.HasPrefix(line, "VLAN Type") || strings.TrimSpace(line) == "" || reVlanPrompt.MatchString(line) {
 			break
 		}
 
-		if line == "" {
-			continue
-		}
-
 		if isNewVlanLine.MatchString(line) {
 			// This is a new VLAN entry
 			fields := strings.Fields(line)
@@ -96,9 +225,11 @@ func parseVlanInfo(rawOutput string) ([]VlanInfo, error) {
 		} else if len(vlans) > 0 {
 			// This is a continuation of the previous VLAN's port list
 			lastVlan := &vlans[len(vlans)-1]
-			portStr := strings.TrimSpace(line)
-			ports := strings.Split(portStr, ",")
-			lastVlan.Ports = append(lastVlan.Ports, ports...)
+			for _, port := range strings.Split(strings.TrimSpace(line), ",") {
+				if port = strings.TrimSpace(port); reInterfaceParameter.MatchString(port) {
+					lastVlan.Ports = append(lastVlan.Ports, port)
+				}
+			}
 		}
 	}
 
@@ -115,3 +246,68 @@ func parseVlanInfo(rawOutput string) ([]VlanInfo, error) {
 
 	return vlans, nil
 }
+
+// ParseVlanDetail processes the raw CLI output from "show vlan" like ParseVlanInfo,
+// then reads the "VLAN Type" table that follows the ports into the same VLANs: on IOS
+// the SAID, MTU and bridging settings, on NX-OS the vlan-mode. Output without the
+// table, such as that of "show vlan brief", yields VLANs without these settings.
+func ParseVlanDetail(rawOutput string) ([]VlanDetail, error) {
+	vlans, err := ParseVlanInfo(rawOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]VlanDetail, len(vlans))
+	byID := make(map[string]*VlanDetail, len(vlans))
+	for i := range vlans {
+		details[i].VlanInfo = vlans[i]
+		byID[vlans[i].VLANID] = &details[i]
+	}
+
+	// dash returns the setting, or empty where IOS prints "-" for none.
+	dash := func(value string) string {
+		if value == "-" {
+			return ""
+		}
+		return value
+	}
+
+	inTable := false
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+		fields := strings.Fields(line)
+
+		if strings.HasPrefix(line, "VLAN ") || strings.HasPrefix(line, "Remote SPAN") || strings.HasPrefix(line, "Primary") {
+			// Other tables follow on IOS, e.g. the Token Ring hops of VLAN 1003
+			inTable = strings.HasPrefix(line, "VLAN Type")
+			continue
+		}
+		if !inTable || len(fields) < 3 || !isNewVlanLine.MatchString(line) {
+			continue
+		}
+
+		detail := byID[fields[0]]
+		if detail == nil {
+			continue
+		}
+		detail.Type = fields[1]
+		if len(fields) == 3 {
+			detail.Mode = fields[2] // NX-OS: "VLAN Type  Vlan-mode"
+			continue
+		}
+		if len(fields) < 11 {
+			continue
+		}
+		detail.SAID = fields[2]
+		detail.MTU, _ = strconv.Atoi(fields[3])
+		detail.Parent = dash(fields[4])
+		detail.RingNo = dash(fields[5])
+		detail.BridgeNo = dash(fields[6])
+		detail.STP = dash(fields[7])
+		detail.BridgeMode = dash(fields[8])
+		detail.Trans1, _ = strconv.Atoi(fields[9])
+		detail.Trans2, _ = strconv.Atoi(fields[10])
+	}
+
+	return details, nil
+}
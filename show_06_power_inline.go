@@ -1,9 +1,11 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
+	"time"
 )
 
 // PowerModuleInfo defines the structure for a power supply module.
@@ -23,19 +25,55 @@ type PowerInterfaceInfo struct {
 	Device    string
 	Class     string
 	Max       string // (Watts)
+
+	// Four-pair (UPOE/UPOE+) output on IOS-XE 17.x reports the signature type and the
+	// per-pairset state; Power is then the allocated power and Oper is "on" if any pairset is.
+	Type        string // SS (single signature), DS (dual signature) or SP (single pairset)
+	PairsetOper string // Alt-A,B state, e.g., "on,on"
+	Utilized    string // (Watts)
+}
+
+// PowerInline holds both tables of "show power inline", as returned by SafeParse.
+type PowerInline struct {
+	Modules    []PowerModuleInfo
+	Interfaces []PowerInterfaceInfo
 }
 
 // Show_power_inline fetches and processes "show power inline" output.
 func Show_power_inline(switch_hostname string) ([]PowerModuleInfo, []PowerInterfaceInfo, error) {
-	outputString, err := RunCommand(switch_hostname, "show power inline")
+	return Show_power_inline_context(context.Background(), switch_hostname)
+}
+
+// Show_power_inline_context is Show_power_inline with a context that cancels the command.
+func Show_power_inline_context(ctx context.Context, switch_hostname string) ([]PowerModuleInfo, []PowerInterfaceInfo, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	return client.ShowPowerInlineContext(ctx)
+}
+
+// ShowPowerInline is Show_power_inline over the connection of the client.
+func (c *Client) ShowPowerInline() ([]PowerModuleInfo, []PowerInterfaceInfo, error) {
+	return c.ShowPowerInlineContext(context.Background())
+}
+
+// ShowPowerInlineContext is ShowPowerInline with a context that cancels the command.
+func (c *Client) ShowPowerInlineContext(ctx context.Context) ([]PowerModuleInfo, []PowerInterfaceInfo, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show power inline")
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// --- PARSE OUTPUT ---
-	power_inline_modules_data, power_inline_interfaces_data, err := parsePowerInline(outputString)
+	parseStart := time.Now()
+	power_inline_modules_data, power_inline_interfaces_data, err := ParsePowerInline(outputString)
+	err = reportParse(switch_hostname, "show power inline", outputString, len(power_inline_modules_data)+len(power_inline_interfaces_data), parseStart, err)
 	if err != nil {
-		log.Printf("Show power inline :: Warning :: Parsing completed for %s: %v", switch_hostname, err)
+		c.logf(slog.LevelWarn, "Show power inline :: Warning :: Parsing completed for %s: %v", switch_hostname, err)
 		// We can continue if one part failed, but not if both are empty.
 		return nil, nil, nil
 	}
@@ -43,9 +81,9 @@ func Show_power_inline(switch_hostname string) ([]PowerModuleInfo, []PowerInterf
 	return power_inline_modules_data, power_inline_interfaces_data, nil
 }
 
-// parsePowerInline processes the raw CLI output from "show power inline".
+// ParsePowerInline processes the raw CLI output from "show power inline".
 // It splits parsing into two sections and returns two different slices.
-func parsePowerInline(rawOutput string) ([]PowerModuleInfo, []PowerInterfaceInfo, error) {
+func ParsePowerInline(rawOutput string) ([]PowerModuleInfo, []PowerInterfaceInfo, error) {
 	var modules []PowerModuleInfo
 	var interfaces []PowerInterfaceInfo
 	lines := strings.Split(rawOutput, "\n")
@@ -58,6 +96,7 @@ func parsePowerInline(rawOutput string) ([]PowerModuleInfo, []PowerInterfaceInfo
 		Interface
 	)
 	currentSection := None
+	fourPair := false // The interface table has the IOS-XE 17.x "Oper-State" layout
 
 	for _, line := range lines {
 		// Get fields *first* to handle all whitespace types.
@@ -80,6 +119,7 @@ func parsePowerInline(rawOutput string) ([]PowerModuleInfo, []PowerInterfaceInfo
 			continue // Skip this header line
 		case "Interface":
 			currentSection = Interface
+			fourPair = strings.Contains(line, "Oper-State")
 			continue // Skip this header line
 		}
 
@@ -107,7 +147,21 @@ func parsePowerInline(rawOutput string) ([]PowerModuleInfo, []PowerInterfaceInfo
 		case Interface:
 			// A valid data line has >= 6 fields and the first field
 			// must look like an interface name (e.g., contains '/')
-			if len(fields) >= 6 && strings.Contains(fields[0], "/") {
+			if fourPair && len(fields) >= 7 && strings.Contains(fields[0], "/") {
+				// Interface Admin Type Oper-State(Alt-A,B) Allocated Utilized Class(Alt-A,B) Device Name
+				iface := PowerInterfaceInfo{
+					Interface:   fields[0],
+					Admin:       fields[1],
+					Type:        fields[2],
+					PairsetOper: fields[3],
+					Oper:        pairsetOper(fields[3]),
+					Power:       fields[4],
+					Utilized:    fields[5],
+					Class:       fields[6],
+					Device:      strings.Join(fields[7:], " "),
+				}
+				interfaces = append(interfaces, iface)
+			} else if !fourPair && len(fields) >= 6 && strings.Contains(fields[0], "/") {
 				iface := PowerInterfaceInfo{
 					Interface: fields[0],
 					Admin:     fields[1],
@@ -131,3 +185,15 @@ func parsePowerInline(rawOutput string) ([]PowerModuleInfo, []PowerInterfaceInfo
 
 	return modules, interfaces, nil
 }
+
+// pairsetOper reduces a per-pairset state such as "on,off" to "on" if any pairset
+// delivers power, and to the first state otherwise.
+func pairsetOper(states string) string {
+	parts := strings.Split(states, ",")
+	for _, state := range parts {
+		if state == "on" {
+			return "on"
+		}
+	}
+	return parts[0]
+}
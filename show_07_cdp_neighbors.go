@@ -1,9 +1,11 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
+	"time"
 )
 
 // CdpNeighbor defines the structure for a single CDP neighbor entry.
@@ -14,17 +16,42 @@ type CdpNeighbor struct {
 	Capability        string
 	Platform          string
 	NeighborInterface string
+	Kind              DeviceKind // See ClassifyCdpNeighbor
 }
 
 func Show_cdp_neighbors(switch_hostname string) ([]CdpNeighbor, error) {
-	outputString, err := RunCommand(switch_hostname, "show cdp neighbors")
+	return Show_cdp_neighbors_context(context.Background(), switch_hostname)
+}
+
+// Show_cdp_neighbors_context is Show_cdp_neighbors with a context that cancels the command.
+func Show_cdp_neighbors_context(ctx context.Context, switch_hostname string) ([]CdpNeighbor, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
 	if err != nil {
 		return nil, err
 	}
+	defer client.Close()
+
+	return client.ShowCdpNeighborsContext(ctx)
+}
 
-	cdp_neighbors_data, err := parseCdpNeighbors(outputString)
+// ShowCdpNeighbors is Show_cdp_neighbors over the connection of the client.
+func (c *Client) ShowCdpNeighbors() ([]CdpNeighbor, error) {
+	return c.ShowCdpNeighborsContext(context.Background())
+}
+
+// ShowCdpNeighborsContext is ShowCdpNeighbors with a context that cancels the command.
+func (c *Client) ShowCdpNeighborsContext(ctx context.Context) ([]CdpNeighbor, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show cdp neighbors")
 	if err != nil {
-		log.Printf("%s ::Show CDP Neighbors :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	cdp_neighbors_data, err := parseWith(c.platform(), "show cdp neighbors", outputString, ParseCdpNeighbors)
+	err = reportParse(switch_hostname, "show cdp neighbors", outputString, len(cdp_neighbors_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s ::Show CDP Neighbors :: Error during parsing: %v", switch_hostname, err)
 	}
 
 	for i := range cdp_neighbors_data {
@@ -34,17 +61,17 @@ func Show_cdp_neighbors(switch_hostname string) ([]CdpNeighbor, error) {
 
 	// Check the length of the slice, not the map.
 	if len(cdp_neighbors_data) == 0 {
-		log.Printf("Warning: Parsing completed for %s, but no cdp_neighbors were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Warning: Parsing completed for %s, but no cdp_neighbors were found.", switch_hostname)
 		return nil, nil
 	}
 
 	return cdp_neighbors_data, nil
 }
 
-// parseCdpNeighbors processes the raw CLI output from "show cdp neighbors" and converts it into a list of CdpNeighbor structs.
+// ParseCdpNeighbors processes the raw CLI output from "show cdp neighbors" and converts it into a list of CdpNeighbor structs.
 // This parser is robust because it finds column positions from the header and handles entries that span multiple lines,
-// parseCdpNeighbors processes the raw CLI output from "show cdp neighbors" and converts it into a list of CdpNeighbor structs.
-func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
+// ParseCdpNeighbors processes the raw CLI output from "show cdp neighbors" and converts it into a list of CdpNeighbor structs.
+func ParseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 	var neighbors []CdpNeighbor
 	lines := strings.Split(rawOutput, "\n")
 
@@ -61,7 +88,7 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 	}
 
 	if headerIndex == -1 {
-		log.Println("CDP neighbors header not found, returning empty list.")
+		logf(slog.LevelDebug, "CDP neighbors header not found, returning empty list.")
 		return neighbors, nil
 	}
 
@@ -81,6 +108,9 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 	if localIntfIndex == -1 || holdtmeIndex == -1 || capabilityIndex == -1 || platformIndex == -1 || portIDIndex == -1 {
 		return nil, fmt.Errorf("could not parse CDP neighbors header columns correctly (check alignment)")
 	}
+	if !(localIntfIndex < holdtmeIndex && holdtmeIndex < capabilityIndex && capabilityIndex < platformIndex && platformIndex < portIDIndex) {
+		return nil, fmt.Errorf("CDP neighbors header columns are out of order")
+	}
 
 	var lastDeviceID string
 
@@ -111,7 +141,7 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 		if isDetailLine {
 			// *** TYPE A: DETAIL LINE (Second line of a split entry) ***
 			if lastDeviceID == "" {
-				log.Printf("Warning: Found detail line without preceding Device ID: %s", line)
+				logf(slog.LevelDebug, "Warning: Found detail line without preceding Device ID: %s", line)
 				continue
 			}
 
@@ -119,7 +149,7 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 			if len(line) < portIDIndex {
 				// Try to salvage what we can, or skip if critical data is missing
 				if len(line) < platformIndex {
-					log.Printf("Warning: Detail line too short to parse: %s", line)
+					logf(slog.LevelDebug, "Warning: Detail line too short to parse: %s", line)
 					continue
 				}
 			}
@@ -127,11 +157,11 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 			// For the detail line, we extract assuming the standard column headers apply
 			neighbor := CdpNeighbor{
 				Neighbor:          lastDeviceID,
-				Interface:         strings.TrimSpace(line[localIntfIndex:holdtmeIndex]),
-				HoldTime:          strings.TrimSpace(line[holdtmeIndex:capabilityIndex]),
-				Capability:        strings.TrimSpace(line[capabilityIndex : platformIndex]),
-				Platform:          strings.TrimSpace(line[platformIndex : portIDIndex]),
-				NeighborInterface: strings.TrimSpace(line[portIDIndex:]),
+				Interface:         columnField(line, localIntfIndex, holdtmeIndex),
+				HoldTime:          columnField(line, holdtmeIndex, capabilityIndex),
+				Capability:        columnField(line, capabilityIndex, platformIndex),
+				Platform:          columnField(line, platformIndex, portIDIndex),
+				NeighborInterface: columnField(line, portIDIndex, -1),
 			}
 			neighbors = append(neighbors, neighbor)
 			lastDeviceID = ""
@@ -157,11 +187,11 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 
 				neighbor := CdpNeighbor{
 					Neighbor:          deviceID,
-					Interface:         strings.TrimSpace(line[localIntfIndex:holdtmeIndex]),
-					HoldTime:          strings.TrimSpace(line[holdtmeIndex:capabilityIndex]),
-					Capability:        strings.TrimSpace(line[capabilityIndex : platformIndex]),
-					Platform:          strings.TrimSpace(line[platformIndex : portIDIndex]),
-					NeighborInterface: strings.TrimSpace(line[portIDIndex:]),
+					Interface:         columnField(line, localIntfIndex, holdtmeIndex),
+					HoldTime:          columnField(line, holdtmeIndex, capabilityIndex),
+					Capability:        columnField(line, capabilityIndex, platformIndex),
+					Platform:          columnField(line, platformIndex, portIDIndex),
+					NeighborInterface: columnField(line, portIDIndex, -1),
 				}
 				neighbors = append(neighbors, neighbor)
 				lastDeviceID = ""
@@ -174,5 +204,9 @@ func parseCdpNeighbors(rawOutput string) ([]CdpNeighbor, error) {
 		}
 	}
 
+	for i := range neighbors {
+		neighbors[i].Kind = ClassifyCdpNeighbor(neighbors[i])
+	}
+
 	return neighbors, nil
 }
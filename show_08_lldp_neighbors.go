@@ -1,9 +1,11 @@
 package cisco
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
+	"time"
 )
 
 // LldpNeighbor defines the structure for a single LLDP neighbor entry.
@@ -13,17 +15,46 @@ type LldpNeighbor struct {
 	NeighborInterface string
 	HoldTime          string
 	Capability        string
+	Kind              DeviceKind // See ClassifyLldpNeighbor
+
+	// Only filled by ParseLldpNeighborsDetail
+	SystemDescription string
+	MedDeviceType     string // LLDP-MED device type, e.g., Endpoint Class III
 }
 
 func Show_lldp_neighbors(switch_hostname string) ([]LldpNeighbor, error) {
-	outputString, err := RunCommand(switch_hostname, "show lldp neighbors")
+	return Show_lldp_neighbors_context(context.Background(), switch_hostname)
+}
+
+// Show_lldp_neighbors_context is Show_lldp_neighbors with a context that cancels the command.
+func Show_lldp_neighbors_context(ctx context.Context, switch_hostname string) ([]LldpNeighbor, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowLldpNeighborsContext(ctx)
+}
+
+// ShowLldpNeighbors is Show_lldp_neighbors over the connection of the client.
+func (c *Client) ShowLldpNeighbors() ([]LldpNeighbor, error) {
+	return c.ShowLldpNeighborsContext(context.Background())
+}
+
+// ShowLldpNeighborsContext is ShowLldpNeighbors with a context that cancels the command.
+func (c *Client) ShowLldpNeighborsContext(ctx context.Context) ([]LldpNeighbor, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show lldp neighbors")
 	if err != nil {
 		return nil, err
 	}
 
-	lldp_neighbors_data, err := parseLldpNeighbors(outputString)
+	parseStart := time.Now()
+	lldp_neighbors_data, err := parseWith(c.platform(), "show lldp neighbors", outputString, ParseLldpNeighbors)
+	err = reportParse(switch_hostname, "show lldp neighbors", outputString, len(lldp_neighbors_data), parseStart, err)
 	if err != nil {
-		log.Printf("%s ::Show LLDP Neighbors :: Error during parsing: %v", switch_hostname, err)
+		c.logf(slog.LevelError, "%s ::Show LLDP Neighbors :: Error during parsing: %v", switch_hostname, err)
 	}
 
 	for i := range lldp_neighbors_data {
@@ -33,7 +64,7 @@ func Show_lldp_neighbors(switch_hostname string) ([]LldpNeighbor, error) {
 
 	// Check the length of the slice, not the map.
 	if len(lldp_neighbors_data) == 0 {
-		log.Printf("Show LLDP Neighbors :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
+		c.logf(slog.LevelWarn, "Show LLDP Neighbors :: Warning: Parsing completed for %s, but no interfaces were found.", switch_hostname)
 		return nil, nil
 	}
 
@@ -41,8 +72,8 @@ func Show_lldp_neighbors(switch_hostname string) ([]LldpNeighbor, error) {
 
 }
 
-// parseLldpNeighbors processes the raw CLI output from "show lldp neighbors".
-func parseLldpNeighbors(rawOutput string) ([]LldpNeighbor, error) {
+// ParseLldpNeighbors processes the raw CLI output from "show lldp neighbors".
+func ParseLldpNeighbors(rawOutput string) ([]LldpNeighbor, error) {
 	var neighbors []LldpNeighbor
 	lines := strings.Split(rawOutput, "\n")
 
@@ -59,7 +90,7 @@ func parseLldpNeighbors(rawOutput string) ([]LldpNeighbor, error) {
 	}
 
 	if dataStartIndex == -1 {
-		log.Println("LLDP neighbors header not found, returning empty list.")
+		logf(slog.LevelDebug, "LLDP neighbors header not found, returning empty list.")
 		return neighbors, nil
 	}
 
@@ -73,6 +104,9 @@ func parseLldpNeighbors(rawOutput string) ([]LldpNeighbor, error) {
 	if deviceIDIndex == -1 || localIntfIndex == -1 || holdtmeIndex == -1 || capabilityIndex == -1 || portIDIndex == -1 {
 		return nil, fmt.Errorf("could not parse LLDP neighbors header columns")
 	}
+	if !(deviceIDIndex < localIntfIndex && localIntfIndex < holdtmeIndex && holdtmeIndex < capabilityIndex && capabilityIndex < portIDIndex) {
+		return nil, fmt.Errorf("LLDP neighbors header columns are out of order")
+	}
 
 	for i := dataStartIndex; i < len(lines); i++ {
 		line := strings.TrimRight(lines[i], "\r")
@@ -84,12 +118,13 @@ func parseLldpNeighbors(rawOutput string) ([]LldpNeighbor, error) {
 		}
 
 		neighbor := LldpNeighbor{
-			Interface:         strings.TrimSpace(line[localIntfIndex:holdtmeIndex]),
-			Neighbor:          strings.TrimSpace(line[deviceIDIndex:localIntfIndex]),
-			NeighborInterface: strings.TrimSpace(line[portIDIndex:]),
-			HoldTime:          strings.TrimSpace(line[holdtmeIndex:capabilityIndex]),
-			Capability:        strings.TrimSpace(line[capabilityIndex:portIDIndex]),
+			Interface:         columnField(line, localIntfIndex, holdtmeIndex),
+			Neighbor:          columnField(line, deviceIDIndex, localIntfIndex),
+			NeighborInterface: columnField(line, portIDIndex, -1),
+			HoldTime:          columnField(line, holdtmeIndex, capabilityIndex),
+			Capability:        columnField(line, capabilityIndex, portIDIndex),
 		}
+		neighbor.Kind = ClassifyLldpNeighbor(neighbor)
 
 		neighbors = append(neighbors, neighbor)
 	}
@@ -0,0 +1,118 @@
+package cisco
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TechSupportTimeout bounds Collect_tech_support. "show tech-support" on a large
+// stack runs for tens of minutes, far longer than the timeout of other commands.
+const TechSupportTimeout = time.Hour
+
+// TechSupportReport describes a "show tech-support" collected by Collect_tech_support.
+type TechSupportReport struct {
+	Switch   string
+	Path     string // File written by Collect_tech_support_to_file
+	Bytes    int64  // Output size before compression
+	Duration time.Duration
+}
+
+// reTechSectionHeader matches the banner that "show tech-support" prints before
+// each embedded command, e.g. "------------------ show version ------------------".
+var reTechSectionHeader = regexp.MustCompile(`^-{5,}\s+(show .+?)\s+-{5,}\s*$`)
+
+// ParseTechSupportStream reads "show tech-support" output line by line from r and
+// calls fn for every output line together with the embedded command it belongs to
+// (e.g. "show version"). Lines before the first section banner are reported with
+// an empty command. Parsing stops at the first error returned by fn.
+func ParseTechSupportStream(r io.Reader, fn func(command string, line string) error) error {
+	currentCommand := ""
+
+	return scanLines(r, func(line string) error {
+		if matches := reTechSectionHeader.FindStringSubmatch(line); len(matches) > 1 {
+			currentCommand = matches[1]
+			return nil
+		}
+		return fn(currentCommand, line)
+	})
+}
+
+// Collect_tech_support runs "show tech-support" and streams the output to w as it
+// arrives, so the tens of megabytes a large switch prints are never held in memory.
+// The output is written as the switch printed it, e.g. for a TAC case attachment,
+// and can be read back with ParseTechSupportStream.
+func Collect_tech_support(switch_hostname string, w io.Writer) (TechSupportReport, error) {
+	return Collect_tech_support_context(context.Background(), switch_hostname, w)
+}
+
+// Collect_tech_support_context is Collect_tech_support with a context that stops the
+// collection; TechSupportTimeout still applies.
+func Collect_tech_support_context(ctx context.Context, switch_hostname string, w io.Writer) (TechSupportReport, error) {
+	report := TechSupportReport{Switch: switch_hostname}
+
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return report, err
+	}
+	defer client.Close()
+
+	release, err := client.acquireSessionContext(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer release()
+
+	start := time.Now()
+	report.Bytes, err = client.streamShell(ctx, "show tech-support", []string{"show tech-support"}, TechSupportTimeout, w)
+	report.Duration = time.Since(start)
+	if err != nil {
+		return report, err
+	}
+
+	client.logf(slog.LevelInfo, "%s :: Show Tech-Support :: Collected %d bytes in %s", switch_hostname, report.Bytes, report.Duration.Round(time.Second))
+	return report, nil
+}
+
+// Collect_tech_support_to_file runs Collect_tech_support into a file, gzip
+// compressed when path ends in ".gz". A partial file is removed on failure.
+func Collect_tech_support_to_file(switch_hostname string, path string) (TechSupportReport, error) {
+	return Collect_tech_support_to_file_context(context.Background(), switch_hostname, path)
+}
+
+// Collect_tech_support_to_file_context is Collect_tech_support_to_file with a context
+// that stops the collection, see Collect_tech_support_context.
+func Collect_tech_support_to_file_context(ctx context.Context, switch_hostname string, path string) (TechSupportReport, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return TechSupportReport{Switch: switch_hostname}, fmt.Errorf("writing tech-support: %w", err)
+	}
+
+	var w io.Writer = file
+	var zw *gzip.Writer
+	if strings.HasSuffix(path, ".gz") {
+		zw = gzip.NewWriter(file)
+		w = zw
+	}
+
+	report, err := Collect_tech_support_context(ctx, switch_hostname, w)
+	report.Path = path
+	if err == nil && zw != nil {
+		err = zw.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return report, err
+	}
+
+	return report, nil
+}
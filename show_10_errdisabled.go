@@ -0,0 +1,144 @@
+package cisco
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrDisabledPort describes an interface in err-disabled state and why.
+type ErrDisabledPort struct {
+	Interface        string
+	Description      string
+	Cause            string // e.g., psecure-violation, bpduguard, link-flap
+	Since            string // Timestamp of the latest ERR_DISABLE log message, if still in the log buffer
+	RecoveryTimeLeft string // Seconds until errdisable recovery re-enables the port, if recovery is enabled for the cause
+}
+
+// reErrDisableLog matches the syslog message IOS emits when a port is err-disabled, e.g.
+// "000123: Oct 16 10:02:03.123: %PM-4-ERR_DISABLE: psecure-violation error detected on Gi1/0/4, putting Gi1/0/4 in err-disable state"
+var reErrDisableLog = regexp.MustCompile(`^(?:\d+:\s+)?\*?(.+?):\s+%[A-Z_]+-\d-ERR_DISABLE:\s+(\S+) error detected on ([^,\s]+)`)
+
+// Find_errdisabled_ports lists every err-disabled interface on the switch with its
+// cause, combining "show interfaces status err-disabled", "show errdisable recovery"
+// and the ERR_DISABLE messages in the logging buffer over a single connection.
+func Find_errdisabled_ports(switch_hostname string) ([]ErrDisabledPort, error) {
+	return Find_errdisabled_ports_context(context.Background(), switch_hostname)
+}
+
+// Find_errdisabled_ports_context is Find_errdisabled_ports with a context that cancels the commands.
+func Find_errdisabled_ports_context(ctx context.Context, switch_hostname string) ([]ErrDisabledPort, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	outputString, err := client.RunCommandContext(ctx, "show interfaces status err-disabled")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	ports := ParseErrDisabledStatus(outputString)
+	reportParse(switch_hostname, "show interfaces status err-disabled", outputString, len(ports), parseStart, nil)
+
+	if len(ports) == 0 {
+		return nil, nil
+	}
+
+	// Recovery timers and log timestamps are best effort: not every platform
+	// supports the commands and the log buffer may have wrapped.
+	if recoveryOutput, err := client.RunCommandContext(ctx, "show errdisable recovery"); err != nil {
+		client.logf(slog.LevelWarn, "%s :: Find errdisabled ports :: Unable to read errdisable recovery: %v", switch_hostname, err)
+	} else {
+		timeLeft := ParseErrDisableRecovery(recoveryOutput)
+		for i := range ports {
+			ports[i].RecoveryTimeLeft = timeLeft[ports[i].Interface]
+		}
+	}
+
+	if loggingOutput, err := client.RunCommandContext(ctx, "show logging | include ERR_DISABLE"); err != nil {
+		client.logf(slog.LevelWarn, "%s :: Find errdisabled ports :: Unable to read logging buffer: %v", switch_hostname, err)
+	} else {
+		since := ParseErrDisableLog(loggingOutput)
+		for i := range ports {
+			ports[i].Since = since[ports[i].Interface]
+		}
+	}
+
+	return ports, nil
+}
+
+// ParseErrDisabledStatus processes the raw CLI output from "show interfaces status err-disabled".
+// The Name column is free text, so like ParseInterfaceStatus it anchors on the status keyword.
+func ParseErrDisabledStatus(rawOutput string) []ErrDisabledPort {
+	var ports []ErrDisabledPort
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Fields(line)
+
+		statusIndex := -1
+		for j := 1; j < len(fields); j++ {
+			if fields[j] == "err-disabled" {
+				statusIndex = j
+				break
+			}
+		}
+
+		// A data line needs at least Port, Status and Reason.
+		if statusIndex == -1 || statusIndex+1 >= len(fields) {
+			continue
+		}
+
+		ports = append(ports, ErrDisabledPort{
+			Interface:   normalizeInterfaceName(fields[0]),
+			Description: strings.Join(fields[1:statusIndex], " "),
+			Cause:       fields[statusIndex+1],
+		})
+	}
+
+	return ports
+}
+
+// ParseErrDisableRecovery extracts the "Interfaces that will be enabled at the next timeout"
+// table from "show errdisable recovery" and returns the time left keyed by interface.
+func ParseErrDisableRecovery(rawOutput string) map[string]string {
+	timeLeft := make(map[string]string)
+	inTable := false
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "Interface" {
+			inTable = true
+			continue
+		}
+		if !inTable || strings.HasPrefix(fields[0], "---") || len(fields) != 3 {
+			continue
+		}
+		timeLeft[normalizeInterfaceName(fields[0])] = fields[2]
+	}
+
+	return timeLeft
+}
+
+// ParseErrDisableLog returns the timestamp of the most recent ERR_DISABLE message per interface.
+func ParseErrDisableLog(rawOutput string) map[string]string {
+	since := make(map[string]string)
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		matches := reErrDisableLog.FindStringSubmatch(strings.TrimSpace(line))
+		if len(matches) < 4 {
+			continue
+		}
+		// The buffer is oldest first, so later matches overwrite earlier ones.
+		since[normalizeInterfaceName(matches[3])] = strings.TrimSpace(matches[1])
+	}
+
+	return since
+}
@@ -0,0 +1,298 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// SpanningTreeBlockedPort defines a single port blocked by spanning-tree in a VLAN/instance.
+type SpanningTreeBlockedPort struct {
+	Vlan      string // e.g., VLAN0010, MST0
+	Interface string
+}
+
+// SpanningTreeInconsistentPort defines a port spanning-tree has put in an inconsistent state.
+type SpanningTreeInconsistentPort struct {
+	Vlan          string
+	Interface     string
+	Inconsistency string // e.g., Root Inconsistent, Loop Inconsistent, Port Type Inconsistent
+}
+
+// SpanningTreeRoot defines the root bridge a switch sees for a single VLAN/instance.
+type SpanningTreeRoot struct {
+	Vlan         string // e.g., VLAN0010
+	RootPriority string
+	RootMac      string
+	RootCost     string
+	HelloTime    string
+	MaxAge       string
+	ForwardDelay string
+	RootPort     string // Empty when this switch is the root bridge
+}
+
+// Show_spanning_tree_root runs "show spanning-tree root" and returns the root bridge per VLAN.
+func Show_spanning_tree_root(switch_hostname string) ([]SpanningTreeRoot, error) {
+	return Show_spanning_tree_root_context(context.Background(), switch_hostname)
+}
+
+// Show_spanning_tree_root_context is Show_spanning_tree_root with a context that cancels the command.
+func Show_spanning_tree_root_context(ctx context.Context, switch_hostname string) ([]SpanningTreeRoot, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowSpanningTreeRootContext(ctx)
+}
+
+// ShowSpanningTreeRoot is Show_spanning_tree_root over the connection of the client.
+func (c *Client) ShowSpanningTreeRoot() ([]SpanningTreeRoot, error) {
+	return c.ShowSpanningTreeRootContext(context.Background())
+}
+
+// ShowSpanningTreeRootContext is ShowSpanningTreeRoot with a context that cancels the command.
+func (c *Client) ShowSpanningTreeRootContext(ctx context.Context) ([]SpanningTreeRoot, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show spanning-tree root")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	spanning_tree_root_data, err := parseWith(c.platform(), "show spanning-tree root", outputString, ParseSpanningTreeRoot)
+	err = reportParse(switch_hostname, "show spanning-tree root", outputString, len(spanning_tree_root_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Spanning-Tree Root :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	for i := range spanning_tree_root_data {
+		spanning_tree_root_data[i].RootPort = normalizeInterfaceName(spanning_tree_root_data[i].RootPort)
+	}
+
+	return spanning_tree_root_data, nil
+}
+
+// Show_spanning_tree_blockedports runs "show spanning-tree blockedports" and returns one entry per blocked port and VLAN.
+func Show_spanning_tree_blockedports(switch_hostname string) ([]SpanningTreeBlockedPort, error) {
+	return Show_spanning_tree_blockedports_context(context.Background(), switch_hostname)
+}
+
+// Show_spanning_tree_blockedports_context is Show_spanning_tree_blockedports with a context that cancels the command.
+func Show_spanning_tree_blockedports_context(ctx context.Context, switch_hostname string) ([]SpanningTreeBlockedPort, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowSpanningTreeBlockedportsContext(ctx)
+}
+
+// ShowSpanningTreeBlockedports is Show_spanning_tree_blockedports over the connection of the client.
+func (c *Client) ShowSpanningTreeBlockedports() ([]SpanningTreeBlockedPort, error) {
+	return c.ShowSpanningTreeBlockedportsContext(context.Background())
+}
+
+// ShowSpanningTreeBlockedportsContext is ShowSpanningTreeBlockedports with a context that cancels the command.
+func (c *Client) ShowSpanningTreeBlockedportsContext(ctx context.Context) ([]SpanningTreeBlockedPort, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show spanning-tree blockedports")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	blocked_ports_data, err := parseWith(c.platform(), "show spanning-tree blockedports", outputString, ParseSpanningTreeBlockedPorts)
+	err = reportParse(switch_hostname, "show spanning-tree blockedports", outputString, len(blocked_ports_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Spanning-Tree Blockedports :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	for i := range blocked_ports_data {
+		blocked_ports_data[i].Interface = normalizeInterfaceName(blocked_ports_data[i].Interface)
+	}
+
+	return blocked_ports_data, nil
+}
+
+// Show_spanning_tree_inconsistentports runs "show spanning-tree inconsistentports", listing ports in
+// root-, loop- or type-inconsistent state separately from general interface status.
+func Show_spanning_tree_inconsistentports(switch_hostname string) ([]SpanningTreeInconsistentPort, error) {
+	return Show_spanning_tree_inconsistentports_context(context.Background(), switch_hostname)
+}
+
+// Show_spanning_tree_inconsistentports_context is Show_spanning_tree_inconsistentports with a context that cancels the command.
+func Show_spanning_tree_inconsistentports_context(ctx context.Context, switch_hostname string) ([]SpanningTreeInconsistentPort, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowSpanningTreeInconsistentportsContext(ctx)
+}
+
+// ShowSpanningTreeInconsistentports is Show_spanning_tree_inconsistentports over the connection of the client.
+func (c *Client) ShowSpanningTreeInconsistentports() ([]SpanningTreeInconsistentPort, error) {
+	return c.ShowSpanningTreeInconsistentportsContext(context.Background())
+}
+
+// ShowSpanningTreeInconsistentportsContext is ShowSpanningTreeInconsistentports with a context that cancels the command.
+func (c *Client) ShowSpanningTreeInconsistentportsContext(ctx context.Context) ([]SpanningTreeInconsistentPort, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show spanning-tree inconsistentports")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	inconsistent_ports_data, err := parseWith(c.platform(), "show spanning-tree inconsistentports", outputString, ParseSpanningTreeInconsistentPorts)
+	err = reportParse(switch_hostname, "show spanning-tree inconsistentports", outputString, len(inconsistent_ports_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Spanning-Tree Inconsistentports :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	for i := range inconsistent_ports_data {
+		inconsistent_ports_data[i].Interface = normalizeInterfaceName(inconsistent_ports_data[i].Interface)
+	}
+
+	return inconsistent_ports_data, nil
+}
+
+// ParseSpanningTreeBlockedPorts processes the raw CLI output from "show spanning-tree blockedports".
+// Long interface lists wrap onto indented continuation lines, which belong to the previous VLAN.
+func ParseSpanningTreeBlockedPorts(rawOutput string) ([]SpanningTreeBlockedPort, error) {
+	var ports []SpanningTreeBlockedPort
+	lines := strings.Split(rawOutput, "\n")
+
+	dataStartIndex := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Name") && strings.Contains(line, "Blocked Interfaces List") {
+			dataStartIndex = i + 1
+			break
+		}
+	}
+
+	if dataStartIndex == -1 {
+		return nil, fmt.Errorf("could not find blocked ports header in output")
+	}
+
+	currentVlan := ""
+	for i := dataStartIndex; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		trimmedLine := strings.TrimSpace(line)
+
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "---") {
+			continue
+		}
+		if strings.HasPrefix(trimmedLine, "Number of blocked ports") {
+			break
+		}
+
+		interfaceList := trimmedLine
+		if !strings.HasPrefix(line, " ") {
+			// New VLAN line: "VLAN0010             Gi1/0/24, Po1"
+			fields := strings.Fields(line)
+			currentVlan = fields[0]
+			interfaceList = strings.Join(fields[1:], " ")
+		}
+		if currentVlan == "" {
+			continue
+		}
+
+		for _, iface := range strings.Split(interfaceList, ",") {
+			if iface = strings.TrimSpace(iface); iface != "" {
+				ports = append(ports, SpanningTreeBlockedPort{Vlan: currentVlan, Interface: iface})
+			}
+		}
+	}
+
+	return ports, nil
+}
+
+// ParseSpanningTreeInconsistentPorts processes the raw CLI output from "show spanning-tree inconsistentports".
+func ParseSpanningTreeInconsistentPorts(rawOutput string) ([]SpanningTreeInconsistentPort, error) {
+	var ports []SpanningTreeInconsistentPort
+	lines := strings.Split(rawOutput, "\n")
+
+	dataStartIndex := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Name") && strings.Contains(line, "Inconsistency") {
+			dataStartIndex = i + 1
+			break
+		}
+	}
+
+	if dataStartIndex == -1 {
+		return nil, fmt.Errorf("could not find inconsistent ports header in output")
+	}
+
+	for i := dataStartIndex; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "---") {
+			continue
+		}
+		if fields[0] == "Number" {
+			break
+		}
+		// A data line has Name, Interface and a (multi-word) Inconsistency.
+		if len(fields) < 3 {
+			continue
+		}
+
+		ports = append(ports, SpanningTreeInconsistentPort{
+			Vlan:          fields[0],
+			Interface:     fields[1],
+			Inconsistency: strings.Join(fields[2:], " "),
+		})
+	}
+
+	return ports, nil
+}
+
+// ParseSpanningTreeRoot processes the raw CLI output from "show spanning-tree root".
+// The Root Port column is blank on VLANs for which the switch itself is the root.
+func ParseSpanningTreeRoot(rawOutput string) ([]SpanningTreeRoot, error) {
+	var roots []SpanningTreeRoot
+	headerFound := false
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) >= 3 && fields[0] == "Vlan" && fields[1] == "Root" && fields[2] == "ID" {
+			headerFound = true
+			continue
+		}
+		if !headerFound || len(fields) < 7 || strings.HasPrefix(fields[0], "---") {
+			continue
+		}
+
+		root := SpanningTreeRoot{
+			Vlan:         fields[0],
+			RootPriority: fields[1],
+			RootMac:      fields[2],
+			RootCost:     fields[3],
+			HelloTime:    fields[4],
+			MaxAge:       fields[5],
+			ForwardDelay: fields[6],
+		}
+		if len(fields) > 7 {
+			root.RootPort = strings.Join(fields[7:], " ")
+		}
+		roots = append(roots, root)
+	}
+
+	if !headerFound {
+		return nil, fmt.Errorf("could not find spanning-tree root header in output")
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,135 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+)
+
+// DhcpBinding defines a single lease handed out by the switch's own DHCP server.
+type DhcpBinding struct {
+	IPAddress       string
+	ClientID        string // Raw Client-ID/Hardware address column, e.g., 0100.5056.a10b.02
+	MacAddress      string // MAC derived from an Ethernet Client-ID, in Cisco dotted format
+	LeaseExpiration string // e.g., "Oct 17 2026 10:15 AM" or "Infinite"
+	Type            string // e.g., Automatic, Manual
+	State           string // Not shown by older IOS releases
+	Interface       string // Not shown by older IOS releases
+}
+
+// Show_dhcp_binding runs "show ip dhcp binding" on switches acting as DHCP server.
+func Show_dhcp_binding(switch_hostname string) ([]DhcpBinding, error) {
+	return Show_dhcp_binding_context(context.Background(), switch_hostname)
+}
+
+// Show_dhcp_binding_context is Show_dhcp_binding with a context that cancels the command.
+func Show_dhcp_binding_context(ctx context.Context, switch_hostname string) ([]DhcpBinding, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowDhcpBindingContext(ctx)
+}
+
+// ShowDhcpBinding is Show_dhcp_binding over the connection of the client.
+func (c *Client) ShowDhcpBinding() ([]DhcpBinding, error) {
+	return c.ShowDhcpBindingContext(context.Background())
+}
+
+// ShowDhcpBindingContext is ShowDhcpBinding with a context that cancels the command.
+func (c *Client) ShowDhcpBindingContext(ctx context.Context) ([]DhcpBinding, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip dhcp binding")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	dhcp_binding_data, err := parseWith(c.platform(), "show ip dhcp binding", outputString, ParseDhcpBinding)
+	err = reportParse(switch_hostname, "show ip dhcp binding", outputString, len(dhcp_binding_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show IP DHCP Binding :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	if len(dhcp_binding_data) == 0 {
+		c.logf(slog.LevelWarn, "Show IP DHCP Binding :: Warning: Parsing completed for %s, but no bindings were found.", switch_hostname)
+		return nil, nil
+	}
+
+	return dhcp_binding_data, nil
+}
+
+// ParseDhcpBinding processes the raw CLI output from "show ip dhcp binding".
+// The lease expiration is a multi-word date, so the Type keyword is used as the anchor.
+func ParseDhcpBinding(rawOutput string) ([]DhcpBinding, error) {
+	var bindings []DhcpBinding
+	lines := strings.Split(rawOutput, "\n")
+
+	headerFound := false
+	for _, line := range lines {
+		fields := strings.Fields(line)
+
+		if len(fields) >= 2 && fields[0] == "IP" && fields[1] == "address" {
+			headerFound = true
+			continue
+		}
+		if !headerFound || len(fields) < 4 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+
+		typeIndex := -1
+		for j := 3; j < len(fields); j++ {
+			if fields[j] == "Automatic" || fields[j] == "Manual" || fields[j] == "Static" || fields[j] == "Selecting" {
+				typeIndex = j
+				break
+			}
+		}
+		if typeIndex == -1 {
+			continue
+		}
+
+		binding := DhcpBinding{
+			IPAddress:       fields[0],
+			ClientID:        fields[1],
+			MacAddress:      macFromClientID(fields[1]),
+			LeaseExpiration: strings.Join(fields[2:typeIndex], " "),
+			Type:            fields[typeIndex],
+		}
+		if len(fields) > typeIndex+1 {
+			binding.State = fields[typeIndex+1]
+		}
+		if len(fields) > typeIndex+2 {
+			binding.Interface = normalizeInterfaceName(fields[typeIndex+2])
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	if !headerFound {
+		return nil, fmt.Errorf("could not find DHCP binding header in output")
+	}
+
+	return bindings, nil
+}
+
+// macFromClientID extracts the MAC address from a DHCP Client-ID. Ethernet clients
+// use hardware type 01 followed by the 6-byte MAC (e.g., 0100.5056.a10b.02). Any
+// other Client-ID format returns an empty string.
+func macFromClientID(clientID string) string {
+	hex := strings.ToLower(strings.ReplaceAll(clientID, ".", ""))
+	if len(hex) == 12 {
+		// Some releases print the bare hardware address.
+		return hex[0:4] + "." + hex[4:8] + "." + hex[8:12]
+	}
+	if len(hex) != 14 || !strings.HasPrefix(hex, "01") {
+		return ""
+	}
+	hex = hex[2:]
+	return hex[0:4] + "." + hex[4:8] + "." + hex[8:12]
+}
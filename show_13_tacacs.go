@@ -0,0 +1,135 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// TacacsServer defines the connection statistics for a single TACACS+ server.
+type TacacsServer struct {
+	Name                  string
+	Address               string
+	Port                  string
+	SocketOpens           string
+	SocketCloses          string
+	SocketAborts          string
+	SocketErrors          string
+	SocketTimeouts        string
+	FailedConnectAttempts string
+	PacketsSent           string
+	PacketsReceived       string
+	Status                string // e.g., Alive, Dead (IOS-XE only)
+}
+
+// Show_tacacs runs "show tacacs" and returns per-server connection statistics and failures.
+func Show_tacacs(switch_hostname string) ([]TacacsServer, error) {
+	return Show_tacacs_context(context.Background(), switch_hostname)
+}
+
+// Show_tacacs_context is Show_tacacs with a context that cancels the command.
+func Show_tacacs_context(ctx context.Context, switch_hostname string) ([]TacacsServer, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowTacacsContext(ctx)
+}
+
+// ShowTacacs is Show_tacacs over the connection of the client.
+func (c *Client) ShowTacacs() ([]TacacsServer, error) {
+	return c.ShowTacacsContext(context.Background())
+}
+
+// ShowTacacsContext is ShowTacacs with a context that cancels the command.
+func (c *Client) ShowTacacsContext(ctx context.Context) ([]TacacsServer, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show tacacs")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	tacacs_data, err := parseWith(c.platform(), "show tacacs", outputString, ParseTacacs)
+	err = reportParse(switch_hostname, "show tacacs", outputString, len(tacacs_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Tacacs :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	return tacacs_data, nil
+}
+
+// ParseTacacs processes the raw CLI output from "show tacacs". Each server block starts
+// with a "Tacacs+ Server - <name> :" line followed by "Key: value" statistics lines.
+func ParseTacacs(rawOutput string) ([]TacacsServer, error) {
+	var servers []TacacsServer
+	var current *TacacsServer
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "Tacacs+ Server") {
+			if current != nil {
+				servers = append(servers, *current)
+			}
+			current = &TacacsServer{}
+			// "Tacacs+ Server -  public  :" carries the server group/name on IOS.
+			if _, name, ok := strings.Cut(line, "-"); ok {
+				current.Name = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), ":"))
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case "Server name":
+			current.Name = value
+		case "Server address":
+			current.Address = value
+		case "Server port":
+			current.Port = value
+		case "Socket opens":
+			current.SocketOpens = value
+		case "Socket closes":
+			current.SocketCloses = value
+		case "Socket aborts":
+			current.SocketAborts = value
+		case "Socket errors":
+			current.SocketErrors = value
+		case "Socket Timeouts":
+			current.SocketTimeouts = value
+		case "Failed Connect Attempts":
+			current.FailedConnectAttempts = value
+		case "Total Packets Sent":
+			current.PacketsSent = value
+		case "Total Packets Recv":
+			current.PacketsReceived = value
+		case "Server Status":
+			current.Status = value
+		}
+	}
+
+	if current != nil {
+		servers = append(servers, *current)
+	}
+
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no TACACS+ servers found in output")
+	}
+
+	return servers, nil
+}
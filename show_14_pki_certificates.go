@@ -0,0 +1,169 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// PkiCertificate defines a single certificate from "show crypto pki certificates".
+type PkiCertificate struct {
+	Name         string // Associated trustpoint(s)
+	Type         string // e.g., Certificate, CA Certificate, Router Self-Signed Certificate
+	Status       string
+	SerialNumber string
+	Usage        string
+	Subject      string // Comma separated RDNs, e.g., cn=switch01.example.com,o=Example
+	Issuer       string
+	StartDate    string
+	EndDate      string
+	NotBefore    time.Time // Parsed StartDate, zero if the format was not recognized
+	NotAfter     time.Time // Parsed EndDate, zero if the format was not recognized
+}
+
+// ExpiresWithin reports whether the certificate expires within d of now (or already has).
+// Certificates whose end date could not be parsed are never reported as expiring.
+func (c PkiCertificate) ExpiresWithin(d time.Duration) bool {
+	if c.NotAfter.IsZero() {
+		return false
+	}
+	return time.Until(c.NotAfter) <= d
+}
+
+// pkiDateLayouts are the validity date formats used by IOS, IOS-XE and NX-OS.
+var pkiDateLayouts = []string{
+	"15:04:05 MST Jan 2 2006",
+	"15:04:05 MST Jan _2 2006",
+	"Jan 2 15:04:05 2006 MST",
+}
+
+// Show_pki_certificates runs "show crypto pki certificates" and returns every certificate
+// with its subject, issuer, serial and validity dates.
+func Show_pki_certificates(switch_hostname string) ([]PkiCertificate, error) {
+	return Show_pki_certificates_context(context.Background(), switch_hostname)
+}
+
+// Show_pki_certificates_context is Show_pki_certificates with a context that cancels the command.
+func Show_pki_certificates_context(ctx context.Context, switch_hostname string) ([]PkiCertificate, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowPkiCertificatesContext(ctx)
+}
+
+// ShowPkiCertificates is Show_pki_certificates over the connection of the client.
+func (c *Client) ShowPkiCertificates() ([]PkiCertificate, error) {
+	return c.ShowPkiCertificatesContext(context.Background())
+}
+
+// ShowPkiCertificatesContext is ShowPkiCertificates with a context that cancels the command.
+func (c *Client) ShowPkiCertificatesContext(ctx context.Context) ([]PkiCertificate, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show crypto pki certificates")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	pki_certificates_data, err := parseWith(c.platform(), "show crypto pki certificates", outputString, ParsePkiCertificates)
+	err = reportParse(switch_hostname, "show crypto pki certificates", outputString, len(pki_certificates_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Crypto PKI Certificates :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	return pki_certificates_data, nil
+}
+
+// ParsePkiCertificates processes the raw CLI output from "show crypto pki certificates".
+// Certificates start with an unindented "...Certificate" line; Issuer and Subject are
+// followed by indented "key=value" lines, and Validity Date by start/end date lines.
+func ParsePkiCertificates(rawOutput string) ([]PkiCertificate, error) {
+	var certificates []PkiCertificate
+	var current *PkiCertificate
+	var currentSection string // "Issuer", "Subject" or "Validity Date"
+	var subject, issuer []string
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		current.Subject = strings.Join(subject, ",")
+		current.Issuer = strings.Join(issuer, ",")
+		current.NotBefore = parsePkiDate(current.StartDate)
+		current.NotAfter = parsePkiDate(current.EndDate)
+		certificates = append(certificates, *current)
+	}
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r ")
+		trimmedLine := strings.TrimSpace(line)
+
+		if trimmedLine == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && strings.HasSuffix(trimmedLine, "Certificate") {
+			finish()
+			current = &PkiCertificate{Type: trimmedLine}
+			currentSection = ""
+			subject, issuer = nil, nil
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		key, value, hasColon := strings.Cut(trimmedLine, ":")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case hasColon && key == "Status":
+			current.Status = value
+		case hasColon && (key == "Certificate Serial Number (hex)" || key == "Certificate Serial Number"):
+			current.SerialNumber = value
+		case hasColon && key == "Certificate Usage":
+			current.Usage = value
+		case hasColon && key == "Associated Trustpoints":
+			current.Name = value
+		case hasColon && (key == "Issuer" || key == "Subject" || key == "Validity Date"):
+			currentSection = key
+		case hasColon && key == "start date":
+			current.StartDate = value
+		case hasColon && (key == "end   date" || key == "end date"):
+			current.EndDate = value
+		case strings.Contains(trimmedLine, "="):
+			if currentSection == "Issuer" {
+				issuer = append(issuer, trimmedLine)
+			} else if currentSection == "Subject" {
+				subject = append(subject, trimmedLine)
+			}
+		}
+	}
+	finish()
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no certificates found in output")
+	}
+
+	return certificates, nil
+}
+
+// parsePkiDate parses a certificate validity date, returning the zero time if the
+// format is not recognized.
+func parsePkiDate(value string) time.Time {
+	value = strings.Join(strings.Fields(value), " ")
+	for _, layout := range pkiDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,208 @@
+package cisco
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// minimumSSHModulusBits is the smallest RSA host key and Diffie-Hellman group size
+// considered acceptable by Weaknesses.
+const minimumSSHModulusBits = 2048
+
+// IPSSHInfo defines the SSH server settings reported by "show ip ssh".
+type IPSSHInfo struct {
+	Enabled               bool
+	Version               string // 1.5, 1.99 (v1 and v2) or 2.0
+	AuthenticationTimeout string // Seconds
+	AuthenticationRetries string
+	MinDHKeySize          string // Bits
+	HostKeyBits           string // RSA host key modulus size in bits, derived from the advertised key
+	AuthenticationMethods []string
+	HostkeyAlgorithms     []string
+	EncryptionAlgorithms  []string
+	MacAlgorithms         []string
+	KexAlgorithms         []string
+}
+
+// Weaknesses returns a human readable finding for every hardening problem in the SSH
+// server settings: SSHv1 still allowed, small RSA host keys or Diffie-Hellman groups,
+// and legacy key exchange or cipher algorithms. An empty result means no findings.
+func (info IPSSHInfo) Weaknesses() []string {
+	var findings []string
+
+	if !info.Enabled {
+		return append(findings, "SSH server is disabled")
+	}
+	if info.Version == "1.5" || info.Version == "1.99" {
+		findings = append(findings, fmt.Sprintf("SSHv1 is allowed (version %s)", info.Version))
+	}
+	if bits, err := strconv.Atoi(info.HostKeyBits); err == nil && bits < minimumSSHModulusBits {
+		findings = append(findings, fmt.Sprintf("RSA host key is %d bits, below %d", bits, minimumSSHModulusBits))
+	}
+	if bits, err := strconv.Atoi(info.MinDHKeySize); err == nil && bits < minimumSSHModulusBits {
+		findings = append(findings, fmt.Sprintf("minimum Diffie-Hellman key size is %d bits, below %d", bits, minimumSSHModulusBits))
+	}
+	for _, kex := range info.KexAlgorithms {
+		if strings.HasPrefix(kex, "diffie-hellman-group1-") {
+			findings = append(findings, fmt.Sprintf("weak key exchange algorithm %s is enabled", kex))
+		}
+	}
+	for _, cipher := range info.EncryptionAlgorithms {
+		if strings.HasSuffix(cipher, "-cbc") {
+			findings = append(findings, fmt.Sprintf("CBC cipher %s is enabled", cipher))
+		}
+	}
+
+	return findings
+}
+
+// Show_ip_ssh runs "show ip ssh" and returns the SSH server settings.
+func Show_ip_ssh(switch_hostname string) (IPSSHInfo, error) {
+	return Show_ip_ssh_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_ssh_context is Show_ip_ssh with a context that cancels the command.
+func Show_ip_ssh_context(ctx context.Context, switch_hostname string) (IPSSHInfo, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return IPSSHInfo{}, err
+	}
+	defer client.Close()
+
+	return client.ShowIpSshContext(ctx)
+}
+
+// ShowIpSsh is Show_ip_ssh over the connection of the client.
+func (c *Client) ShowIpSsh() (IPSSHInfo, error) {
+	return c.ShowIpSshContext(context.Background())
+}
+
+// ShowIpSshContext is ShowIpSsh with a context that cancels the command.
+func (c *Client) ShowIpSshContext(ctx context.Context) (IPSSHInfo, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip ssh")
+	if err != nil {
+		return IPSSHInfo{}, err
+	}
+
+	parseStart := time.Now()
+	ip_ssh_data, err := parseWith(c.platform(), "show ip ssh", outputString, ParseIPSSH)
+	err = reportParse(switch_hostname, "show ip ssh", outputString, 1, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show IP SSH :: Error during parsing: %v", switch_hostname, err)
+		return IPSSHInfo{}, err
+	}
+
+	return ip_ssh_data, nil
+}
+
+// Audit_ip_ssh runs "show ip ssh" and returns the hardening findings from IPSSHInfo.Weaknesses.
+func Audit_ip_ssh(switch_hostname string) ([]string, error) {
+	info, err := Show_ip_ssh(switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	return info.Weaknesses(), nil
+}
+
+// ParseIPSSH processes the raw CLI output from "show ip ssh".
+func ParseIPSSH(rawOutput string) (IPSSHInfo, error) {
+	var info IPSSHInfo
+	found := false
+
+	splitList := func(value string) []string {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "SSH Enabled"):
+			// "SSH Enabled - version 2.0"
+			found = true
+			info.Enabled = true
+			if _, version, ok := strings.Cut(line, "version"); ok {
+				info.Version = strings.TrimSpace(version)
+			}
+		case strings.HasPrefix(line, "SSH Disabled"):
+			found = true
+			if _, version, ok := strings.Cut(line, "version"); ok {
+				info.Version = strings.TrimSpace(version)
+			}
+		case strings.HasPrefix(line, "Authentication timeout"):
+			// "Authentication timeout: 120 secs; Authentication retries: 3"
+			for _, part := range strings.Split(line, ";") {
+				key, value, _ := strings.Cut(part, ":")
+				fields := strings.Fields(value)
+				if len(fields) == 0 {
+					continue
+				}
+				if strings.Contains(key, "timeout") {
+					info.AuthenticationTimeout = fields[0]
+				} else if strings.Contains(key, "retries") {
+					info.AuthenticationRetries = fields[0]
+				}
+			}
+		case strings.HasPrefix(line, "Minimum expected Diffie Hellman key size"):
+			_, value, _ := strings.Cut(line, ":")
+			if fields := strings.Fields(value); len(fields) > 0 {
+				info.MinDHKeySize = fields[0]
+			}
+		case strings.HasPrefix(line, "Authentication methods:"):
+			info.AuthenticationMethods = splitList(strings.TrimPrefix(line, "Authentication methods:"))
+		case strings.HasPrefix(line, "Hostkey Algorithms:"):
+			info.HostkeyAlgorithms = splitList(strings.TrimPrefix(line, "Hostkey Algorithms:"))
+		case strings.HasPrefix(line, "Encryption Algorithms:"):
+			info.EncryptionAlgorithms = splitList(strings.TrimPrefix(line, "Encryption Algorithms:"))
+		case strings.HasPrefix(line, "MAC Algorithms:"):
+			info.MacAlgorithms = splitList(strings.TrimPrefix(line, "MAC Algorithms:"))
+		case strings.HasPrefix(line, "KEX Algorithms:"):
+			info.KexAlgorithms = splitList(strings.TrimPrefix(line, "KEX Algorithms:"))
+		case strings.HasPrefix(line, "ssh-rsa ") && info.HostKeyBits == "":
+			info.HostKeyBits = rsaKeyBits(strings.Fields(line)[1])
+		}
+	}
+
+	if !found {
+		return IPSSHInfo{}, fmt.Errorf("could not find SSH status line in output")
+	}
+
+	return info, nil
+}
+
+// rsaKeyBits returns the modulus size of a base64 encoded ssh-rsa public key, or an
+// empty string if the key cannot be decoded.
+func rsaKeyBits(encodedKey string) string {
+	keyBytes, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return ""
+	}
+	publicKey, err := ssh.ParsePublicKey(keyBytes)
+	if err != nil {
+		return ""
+	}
+	cryptoKey, ok := publicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return ""
+	}
+	rsaKey, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+	if !ok {
+		return ""
+	}
+	return strconv.Itoa(rsaKey.N.BitLen())
+}
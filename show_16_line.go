@@ -0,0 +1,208 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TerminalLine defines a single console, AUX or VTY line with its usage and access settings.
+type TerminalLine struct {
+	Tty             string
+	Line            string
+	Type            string // e.g., CTY, AUX, VTY
+	Active          bool   // The line is currently in use
+	TxRx            string
+	Modem           string
+	AccessClassOut  string
+	AccessClassIn   string
+	Uses            string
+	Noise           string
+	Overruns        string
+	ExecTimeout     string // "minutes seconds" from the line configuration; empty means the default (10 0)
+	TransportInput  string // e.g., ssh, telnet ssh, none; empty means the platform default
+	TransportOutput string
+}
+
+// Show_line runs "show line" and merges in the exec-timeout and transport settings from
+// the "line" sections of the running configuration, over a single connection.
+func Show_line(switch_hostname string) ([]TerminalLine, error) {
+	return Show_line_context(context.Background(), switch_hostname)
+}
+
+// Show_line_context is Show_line with a context that cancels the command.
+func Show_line_context(ctx context.Context, switch_hostname string) ([]TerminalLine, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowLineContext(ctx)
+}
+
+// ShowLine is Show_line over the connection of the client.
+func (c *Client) ShowLine() ([]TerminalLine, error) {
+	return c.ShowLineContext(context.Background())
+}
+
+// ShowLineContext is ShowLine with a context that cancels the command.
+func (c *Client) ShowLineContext(ctx context.Context) ([]TerminalLine, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show line")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	line_data, err := parseWith(c.platform(), "show line", outputString, ParseTerminalLines)
+	err = reportParse(switch_hostname, "show line", outputString, len(line_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Line :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	configOutput, err := c.RunCommandContext(ctx, "show running-config | section ^line")
+	if err != nil {
+		c.logf(slog.LevelWarn, "%s :: Show Line :: Unable to read line configuration: %v", switch_hostname, err)
+		return line_data, nil
+	}
+	applyLineConfig(line_data, configOutput)
+
+	return line_data, nil
+}
+
+// ParseTerminalLines processes the raw CLI output from "show line". The Tx/Rx column is blank
+// for CTY and VTY lines, so rows have either 12 or 13 fields after the status marker.
+func ParseTerminalLines(rawOutput string) ([]TerminalLine, error) {
+	var lines []TerminalLine
+	headerFound := false
+
+	for _, rawLine := range strings.Split(rawOutput, "\n") {
+		fields := strings.Fields(rawLine)
+
+		if len(fields) > 2 && fields[0] == "Tty" && fields[1] == "Line" {
+			headerFound = true
+			continue
+		}
+		if !headerFound || len(fields) == 0 {
+			continue
+		}
+
+		line := TerminalLine{}
+		// A leading "*" marks an active line; "A" and "I" mark modem states.
+		if fields[0] == "*" || fields[0] == "A" || fields[0] == "I" {
+			line.Active = fields[0] == "*"
+			fields = fields[1:]
+		} else if strings.HasPrefix(fields[0], "*") {
+			line.Active = true
+			fields[0] = strings.TrimPrefix(fields[0], "*")
+		}
+
+		if len(fields) != 12 && len(fields) != 13 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+
+		line.Tty = fields[0]
+		line.Line = fields[1]
+		line.Type = fields[2]
+		rest := fields[3:]
+		if len(fields) == 13 {
+			line.TxRx = fields[3]
+			rest = fields[4:]
+		}
+		// rest: A, Modem, Roty, AccO, AccI, Uses, Noise, Overruns, Int
+		line.Modem = rest[1]
+		if rest[3] != "-" {
+			line.AccessClassOut = rest[3]
+		}
+		if rest[4] != "-" {
+			line.AccessClassIn = rest[4]
+		}
+		line.Uses = rest[5]
+		line.Noise = rest[6]
+		line.Overruns = rest[7]
+
+		lines = append(lines, line)
+	}
+
+	if !headerFound {
+		return nil, fmt.Errorf("could not find show line header in output")
+	}
+
+	return lines, nil
+}
+
+// applyLineConfig copies exec-timeout and transport settings from "line" configuration
+// sections (e.g., "line vty 0 4") onto the matching lines. VTY and AUX numbers in the
+// configuration are relative to the first line of that type.
+func applyLineConfig(lines []TerminalLine, rawConfig string) {
+	lineTypes := map[string]string{"con": "CTY", "console": "CTY", "aux": "AUX", "vty": "VTY"}
+
+	// First absolute line number per type, to translate relative configuration numbers.
+	firstLine := make(map[string]int)
+	for _, line := range lines {
+		n, err := strconv.Atoi(line.Line)
+		if err != nil {
+			continue
+		}
+		if first, ok := firstLine[line.Type]; !ok || n < first {
+			firstLine[line.Type] = n
+		}
+	}
+
+	var targets []int // indexes into lines for the current configuration section
+
+	for _, configLine := range strings.Split(rawConfig, "\n") {
+		fields := strings.Fields(configLine)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if fields[0] == "line" && len(fields) >= 3 {
+			targets = nil
+			lineType, ok := lineTypes[fields[1]]
+			if !ok {
+				continue
+			}
+			from, err := strconv.Atoi(fields[2])
+			if err != nil {
+				continue
+			}
+			to := from
+			if len(fields) >= 4 {
+				if n, err := strconv.Atoi(fields[3]); err == nil {
+					to = n
+				}
+			}
+			for i, line := range lines {
+				n, err := strconv.Atoi(line.Line)
+				if err != nil || line.Type != lineType {
+					continue
+				}
+				relative := n - firstLine[lineType]
+				if relative >= from && relative <= to {
+					targets = append(targets, i)
+				}
+			}
+			continue
+		}
+
+		for _, i := range targets {
+			switch {
+			case fields[0] == "exec-timeout" && len(fields) >= 2:
+				lines[i].ExecTimeout = strings.Join(fields[1:], " ")
+			case fields[0] == "transport" && len(fields) >= 3 && fields[1] == "input":
+				lines[i].TransportInput = strings.Join(fields[2:], " ")
+			case fields[0] == "transport" && len(fields) >= 3 && fields[1] == "output":
+				lines[i].TransportOutput = strings.Join(fields[2:], " ")
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DeviceClock defines the parsed "show clock" output.
+type DeviceClock struct {
+	Time          time.Time
+	Timezone      string // Abbreviation as configured with "clock timezone", e.g., UTC, EST
+	Authoritative bool   // False when IOS prefixes the time with "*" (clock not set or not synchronized)
+	Synchronized  bool   // False when IOS prefixes the time with "*" or "." (NTP not in sync)
+	Source        string // e.g., NTP, hardware calendar (only shown by "show clock detail" and NX-OS)
+}
+
+// Drift returns how far the device clock is ahead of reference (negative when behind).
+func (c DeviceClock) Drift(reference time.Time) time.Duration {
+	return c.Time.Sub(reference)
+}
+
+// reClock matches "*10:15:32.123 UTC Fri Oct 16 2026" with an optional "*" or "." prefix.
+var reClock = regexp.MustCompile(`^([*.]?)(\d{1,2}:\d{2}:\d{2}(?:\.\d+)?)\s+(\S+)\s+\w{3}\s+(\w{3})\s+(\d{1,2})\s+(\d{4})`)
+
+// reClockSource matches "Time source is NTP".
+var reClockSource = regexp.MustCompile(`Time source is (.+)`)
+
+// clockZoneOffsets maps timezone abbreviations commonly configured on switches to their
+// UTC offset, because time.Parse only knows the abbreviations of the local zone.
+var clockZoneOffsets = map[string]int{
+	"UTC": 0, "GMT": 0, "WET": 0, "BST": 1 * 3600, "IST": 1 * 3600,
+	"CET": 1 * 3600, "CEST": 2 * 3600, "EET": 2 * 3600, "EEST": 3 * 3600,
+	"EST": -5 * 3600, "EDT": -4 * 3600, "CST": -6 * 3600, "CDT": -5 * 3600,
+	"MST": -7 * 3600, "MDT": -6 * 3600, "PST": -8 * 3600, "PDT": -7 * 3600,
+	"AKST": -9 * 3600, "AKDT": -8 * 3600, "HST": -10 * 3600,
+	"JST": 9 * 3600, "AEST": 10 * 3600, "AEDT": 11 * 3600,
+}
+
+// Show_clock runs "show clock detail" and returns the device time.
+func Show_clock(switch_hostname string) (DeviceClock, error) {
+	return Show_clock_context(context.Background(), switch_hostname)
+}
+
+// Show_clock_context is Show_clock with a context that cancels the command.
+func Show_clock_context(ctx context.Context, switch_hostname string) (DeviceClock, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return DeviceClock{}, err
+	}
+	defer client.Close()
+
+	return client.ShowClockContext(ctx)
+}
+
+// ShowClock is Show_clock over the connection of the client.
+func (c *Client) ShowClock() (DeviceClock, error) {
+	return c.ShowClockContext(context.Background())
+}
+
+// ShowClockContext is ShowClock with a context that cancels the command.
+func (c *Client) ShowClockContext(ctx context.Context) (DeviceClock, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show clock detail")
+	if err != nil {
+		return DeviceClock{}, err
+	}
+
+	parseStart := time.Now()
+	clock_data, err := parseWith(c.platform(), "show clock detail", outputString, ParseClock)
+	err = reportParse(switch_hostname, "show clock detail", outputString, 1, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Clock :: Error during parsing: %v", switch_hostname, err)
+		return DeviceClock{}, err
+	}
+
+	return clock_data, nil
+}
+
+// Measure_clock_drift reads the device clock and returns how far it is ahead of the
+// local clock (negative when behind). The local reference is the midpoint of the
+// command round trip, so the result is accurate to roughly half the command latency.
+func Measure_clock_drift(switch_hostname string) (time.Duration, DeviceClock, error) {
+	return Measure_clock_drift_context(context.Background(), switch_hostname)
+}
+
+// Measure_clock_drift_context is Measure_clock_drift with a context that cancels the command.
+func Measure_clock_drift_context(ctx context.Context, switch_hostname string) (time.Duration, DeviceClock, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return 0, DeviceClock{}, err
+	}
+	defer client.Close()
+
+	sent := time.Now()
+	outputString, err := client.RunCommandContext(ctx, "show clock detail")
+	if err != nil {
+		return 0, DeviceClock{}, err
+	}
+	received := time.Now()
+
+	clock_data, err := parseWith(client.platform(), "show clock detail", outputString, ParseClock)
+	if err != nil {
+		client.logf(slog.LevelError, "%s :: Measure Clock Drift :: Error during parsing: %v", switch_hostname, err)
+		return 0, DeviceClock{}, err
+	}
+
+	reference := sent.Add(received.Sub(sent) / 2)
+	return clock_data.Drift(reference), clock_data, nil
+}
+
+// ParseClock processes the raw CLI output from "show clock" or "show clock detail".
+func ParseClock(rawOutput string) (DeviceClock, error) {
+	var clock DeviceClock
+	found := false
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+
+		if matches := reClockSource.FindStringSubmatch(line); len(matches) > 1 {
+			clock.Source = strings.TrimSpace(matches[1])
+			continue
+		}
+
+		matches := reClock.FindStringSubmatch(line)
+		if len(matches) < 7 || found {
+			continue
+		}
+
+		value := fmt.Sprintf("%s %s %s %s", matches[2], matches[4], matches[5], matches[6])
+		t, err := time.Parse("15:04:05 Jan 2 2006", value)
+		if err != nil {
+			return DeviceClock{}, fmt.Errorf("could not parse clock %q: %w", line, err)
+		}
+
+		clock.Timezone = matches[3]
+		offset, known := clockZoneOffsets[strings.ToUpper(clock.Timezone)]
+		if !known {
+			// Unknown abbreviation: keep the wall clock and treat it as UTC.
+			logf(slog.LevelWarn, "Show Clock :: Warning: unknown timezone %q, assuming UTC offset", clock.Timezone)
+		}
+		clock.Time = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.FixedZone(clock.Timezone, offset))
+		clock.Authoritative = matches[1] != "*"
+		clock.Synchronized = matches[1] == ""
+		found = true
+	}
+
+	if !found {
+		return DeviceClock{}, fmt.Errorf("could not find clock in output")
+	}
+
+	return clock, nil
+}
@@ -0,0 +1,126 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// AccessPoint defines a single access point joined to a Catalyst 9800 wireless controller.
+type AccessPoint struct {
+	Name             string
+	Slots            string // Number of radios
+	Model            string
+	EthernetMac      string
+	RadioMac         string
+	CountryCode      string
+	RegulatoryDomain string // Not shown before IOS-XE 17.3
+	IPAddress        string
+	State            string // e.g., Registered, Downloading
+	Location         string
+}
+
+// apSummaryColumns are the "show ap summary" column titles. IOS-XE 17.3 and later
+// print "CC" and "RD" before the IP address and move Location to the end; older
+// releases print Location and Country after the radio MAC.
+var apSummaryColumns = []string{"AP Name", "Slots", "AP Model", "Ethernet MAC", "Radio MAC", "Location", "Country", "CC", "RD", "IP Address", "State"}
+
+func Show_ap_summary(switch_hostname string) ([]AccessPoint, error) {
+	return Show_ap_summary_context(context.Background(), switch_hostname)
+}
+
+// Show_ap_summary_context is Show_ap_summary with a context that cancels the command.
+func Show_ap_summary_context(ctx context.Context, switch_hostname string) ([]AccessPoint, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowApSummaryContext(ctx)
+}
+
+// ShowApSummary is Show_ap_summary over the connection of the client.
+func (c *Client) ShowApSummary() ([]AccessPoint, error) {
+	return c.ShowApSummaryContext(context.Background())
+}
+
+// ShowApSummaryContext is ShowApSummary with a context that cancels the command.
+func (c *Client) ShowApSummaryContext(ctx context.Context) ([]AccessPoint, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ap summary")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	ap_data, err := parseWith(c.platform(), "show ap summary", outputString, ParseApSummary)
+	err = reportParse(switch_hostname, "show ap summary", outputString, len(ap_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show AP Summary :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	return ap_data, nil
+}
+
+// ParseApSummary processes the raw CLI output from "show ap summary" on a Catalyst 9800
+// wireless controller.
+func ParseApSummary(rawOutput string) ([]AccessPoint, error) {
+	var accessPoints []AccessPoint
+	var columns map[string][2]int
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if columns == nil {
+			if strings.HasPrefix(line, "AP Name") {
+				columns = headerColumns(line, apSummaryColumns...)
+				if _, ok := columns["Ethernet MAC"]; !ok {
+					return nil, fmt.Errorf("could not parse AP summary header columns")
+				}
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "---") {
+			continue
+		}
+
+		field := func(title string) string {
+			column, ok := columns[title]
+			if !ok {
+				return ""
+			}
+			return columnField(line, column[0], column[1])
+		}
+
+		ap := AccessPoint{
+			Name:             field("AP Name"),
+			Slots:            field("Slots"),
+			Model:            field("AP Model"),
+			EthernetMac:      field("Ethernet MAC"),
+			RadioMac:         field("Radio MAC"),
+			CountryCode:      field("CC"),
+			RegulatoryDomain: field("RD"),
+			IPAddress:        field("IP Address"),
+			State:            field("State"),
+			Location:         field("Location"),
+		}
+		if ap.CountryCode == "" {
+			ap.CountryCode = field("Country")
+		}
+		if ap.Name == "" || normalizeMacAddress(ap.EthernetMac) == "" {
+			continue
+		}
+
+		accessPoints = append(accessPoints, ap)
+	}
+
+	if columns == nil {
+		return nil, fmt.Errorf("could not find AP summary header in output")
+	}
+
+	return accessPoints, nil
+}
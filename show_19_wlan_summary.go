@@ -0,0 +1,111 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Wlan defines a single WLAN profile on a Catalyst 9800 wireless controller.
+type Wlan struct {
+	ID          string
+	ProfileName string
+	SSID        string
+	Status      string // UP or DOWN
+	Security    string // e.g., [WPA2][802.1x][AES]
+}
+
+// Enabled reports whether the WLAN is broadcasting.
+func (w Wlan) Enabled() bool {
+	return strings.EqualFold(w.Status, "UP")
+}
+
+func Show_wlan_summary(switch_hostname string) ([]Wlan, error) {
+	return Show_wlan_summary_context(context.Background(), switch_hostname)
+}
+
+// Show_wlan_summary_context is Show_wlan_summary with a context that cancels the command.
+func Show_wlan_summary_context(ctx context.Context, switch_hostname string) ([]Wlan, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowWlanSummaryContext(ctx)
+}
+
+// ShowWlanSummary is Show_wlan_summary over the connection of the client.
+func (c *Client) ShowWlanSummary() ([]Wlan, error) {
+	return c.ShowWlanSummaryContext(context.Background())
+}
+
+// ShowWlanSummaryContext is ShowWlanSummary with a context that cancels the command.
+func (c *Client) ShowWlanSummaryContext(ctx context.Context) ([]Wlan, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show wlan summary")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	wlan_data, err := parseWith(c.platform(), "show wlan summary", outputString, ParseWlanSummary)
+	err = reportParse(switch_hostname, "show wlan summary", outputString, len(wlan_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show WLAN Summary :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	return wlan_data, nil
+}
+
+// ParseWlanSummary processes the raw CLI output from "show wlan summary" on a Catalyst
+// 9800 wireless controller. SSIDs may contain spaces, so the columns are cut at the
+// header positions.
+func ParseWlanSummary(rawOutput string) ([]Wlan, error) {
+	var wlans []Wlan
+	var columns map[string][2]int
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if columns == nil {
+			if strings.HasPrefix(line, "ID") && strings.Contains(line, "SSID") {
+				columns = headerColumns(line, "ID", "Profile Name", "SSID", "Status", "Security")
+				if len(columns) != 5 {
+					return nil, fmt.Errorf("could not parse WLAN summary header columns")
+				}
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "---") {
+			continue
+		}
+
+		field := func(title string) string {
+			return columnField(line, columns[title][0], columns[title][1])
+		}
+
+		wlan := Wlan{
+			ID:          field("ID"),
+			ProfileName: field("Profile Name"),
+			SSID:        field("SSID"),
+			Status:      field("Status"),
+			Security:    field("Security"),
+		}
+		if _, err := strconv.Atoi(wlan.ID); err != nil {
+			continue
+		}
+
+		wlans = append(wlans, wlan)
+	}
+
+	if columns == nil {
+		return nil, fmt.Errorf("could not find WLAN summary header in output")
+	}
+
+	return wlans, nil
+}
@@ -0,0 +1,110 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// WirelessClient defines a single client associated to a Catalyst 9800 wireless controller.
+type WirelessClient struct {
+	MacAddress string
+	APName     string
+	Type       string // e.g., WLAN
+	WlanID     string
+	State      string // e.g., Run, IP Learn, Webauth Pending
+	Protocol   string // e.g., 11ax(5), 11n(2.4)
+	Method     string // e.g., Dot1x, PSK, None
+	Role       string // Local, Anchor or Foreign
+}
+
+func Show_client_summary(switch_hostname string) ([]WirelessClient, error) {
+	return Show_client_summary_context(context.Background(), switch_hostname)
+}
+
+// Show_client_summary_context is Show_client_summary with a context that cancels the command.
+func Show_client_summary_context(ctx context.Context, switch_hostname string) ([]WirelessClient, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowClientSummaryContext(ctx)
+}
+
+// ShowClientSummary is Show_client_summary over the connection of the client.
+func (c *Client) ShowClientSummary() ([]WirelessClient, error) {
+	return c.ShowClientSummaryContext(context.Background())
+}
+
+// ShowClientSummaryContext is ShowClientSummary with a context that cancels the command.
+func (c *Client) ShowClientSummaryContext(ctx context.Context) ([]WirelessClient, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show wireless client summary")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	client_data, err := parseWith(c.platform(), "show wireless client summary", outputString, ParseClientSummary)
+	err = reportParse(switch_hostname, "show wireless client summary", outputString, len(client_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Client Summary :: Error during parsing: %v", switch_hostname, err)
+		return nil, err
+	}
+
+	return client_data, nil
+}
+
+// ParseClientSummary processes the raw CLI output from "show wireless client summary"
+// on a Catalyst 9800 wireless controller.
+func ParseClientSummary(rawOutput string) ([]WirelessClient, error) {
+	var clients []WirelessClient
+	var columns map[string][2]int
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if columns == nil {
+			if strings.HasPrefix(line, "MAC Address") && strings.Contains(line, "AP Name") {
+				columns = headerColumns(line, "MAC Address", "AP Name", "Type", "ID", "State", "Protocol", "Method", "Role")
+				if len(columns) != 8 {
+					return nil, fmt.Errorf("could not parse client summary header columns")
+				}
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "---") {
+			continue
+		}
+
+		field := func(title string) string {
+			return columnField(line, columns[title][0], columns[title][1])
+		}
+
+		client := WirelessClient{
+			MacAddress: field("MAC Address"),
+			APName:     field("AP Name"),
+			Type:       field("Type"),
+			WlanID:     field("ID"),
+			State:      field("State"),
+			Protocol:   field("Protocol"),
+			Method:     field("Method"),
+			Role:       field("Role"),
+		}
+		if normalizeMacAddress(client.MacAddress) == "" {
+			continue
+		}
+
+		clients = append(clients, client)
+	}
+
+	if columns == nil {
+		return nil, fmt.Errorf("could not find client summary header in output")
+	}
+
+	return clients, nil
+}
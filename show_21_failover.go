@@ -0,0 +1,157 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FailoverInterface defines a monitored interface of one ASA failover unit.
+type FailoverInterface struct {
+	Name      string // nameif
+	IPAddress string
+	Status    string // e.g., Normal, Failed, No Link
+	Monitor   string // e.g., Monitored, Waiting, Not-Monitored
+}
+
+// FailoverUnit defines one unit of an ASA failover pair as seen from the unit queried.
+type FailoverUnit struct {
+	Unit       string // Primary or Secondary
+	State      string // e.g., Active, Standby Ready, Failed, Cold Standby
+	ActiveTime string // Seconds this unit has been active
+	Interfaces []FailoverInterface
+}
+
+// FailoverStatus defines the parsed "show failover" output of an ASA.
+type FailoverStatus struct {
+	Enabled            bool
+	Unit               string // Primary or Secondary
+	LanInterface       string // e.g., folink GigabitEthernet0/3
+	LanInterfaceStatus string // e.g., up
+	LastFailover       string
+	ThisHost           FailoverUnit
+	OtherHost          FailoverUnit
+}
+
+// Healthy reports whether failover is on with one unit active and the other ready to
+// take over, and no monitored interface has failed on either unit.
+func (f FailoverStatus) Healthy() bool {
+	if !f.Enabled {
+		return false
+	}
+	states := f.ThisHost.State + "/" + f.OtherHost.State
+	if states != "Active/Standby Ready" && states != "Standby Ready/Active" {
+		return false
+	}
+	for _, unit := range []FailoverUnit{f.ThisHost, f.OtherHost} {
+		for _, iface := range unit.Interfaces {
+			if iface.Status != "Normal" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var (
+	reFailoverState     = regexp.MustCompile(`^Failover (On|Off)\s*$`)
+	reFailoverUnit      = regexp.MustCompile(`^Failover unit (\S+)`)
+	reFailoverLan       = regexp.MustCompile(`^Failover LAN Interface:\s*(.+?)\s*\((\w+)\)\s*$`)
+	reFailoverLast      = regexp.MustCompile(`^Last Failover at:\s*(.+)`)
+	reFailoverHost      = regexp.MustCompile(`^(This|Other) host:\s*(\S+)\s*-\s*(.+)$`)
+	reFailoverActive    = regexp.MustCompile(`^Active time:\s*(\d+)`)
+	reFailoverInterface = regexp.MustCompile(`^Interface (\S+) \(([^)]*)\):\s*([^(]+?)\s*(?:\(([^)]+)\))?$`)
+)
+
+func Show_failover(switch_hostname string) (FailoverStatus, error) {
+	return Show_failover_context(context.Background(), switch_hostname)
+}
+
+// Show_failover_context is Show_failover with a context that cancels the command.
+func Show_failover_context(ctx context.Context, switch_hostname string) (FailoverStatus, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return FailoverStatus{}, err
+	}
+	defer client.Close()
+
+	return client.ShowFailoverContext(ctx)
+}
+
+// ShowFailover is Show_failover over the connection of the client.
+func (c *Client) ShowFailover() (FailoverStatus, error) {
+	return c.ShowFailoverContext(context.Background())
+}
+
+// ShowFailoverContext is ShowFailover with a context that cancels the command.
+func (c *Client) ShowFailoverContext(ctx context.Context) (FailoverStatus, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show failover")
+	if err != nil {
+		return FailoverStatus{}, err
+	}
+
+	parseStart := time.Now()
+	failover_data, err := parseWith(c.platform(), "show failover", outputString, ParseFailover)
+	err = reportParse(switch_hostname, "show failover", outputString, 1, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Failover :: Error during parsing: %v", switch_hostname, err)
+		return FailoverStatus{}, err
+	}
+
+	return failover_data, nil
+}
+
+// ParseFailover processes the raw CLI output from "show failover" on an ASA. Only the
+// summary and the per-unit sections are read; the stateful failover statistics that
+// follow are ignored.
+func ParseFailover(rawOutput string) (FailoverStatus, error) {
+	var status FailoverStatus
+	found := false
+	var unit *FailoverUnit
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+
+		if matches := reFailoverState.FindStringSubmatch(line); matches != nil {
+			status.Enabled = matches[1] == "On"
+			found = true
+		} else if matches := reFailoverUnit.FindStringSubmatch(line); matches != nil {
+			status.Unit = matches[1]
+		} else if matches := reFailoverLan.FindStringSubmatch(line); matches != nil {
+			status.LanInterface = matches[1]
+			status.LanInterfaceStatus = matches[2]
+		} else if matches := reFailoverLast.FindStringSubmatch(line); matches != nil {
+			status.LastFailover = strings.TrimSpace(matches[1])
+		} else if matches := reFailoverHost.FindStringSubmatch(line); matches != nil {
+			unit = &status.OtherHost
+			if matches[1] == "This" {
+				unit = &status.ThisHost
+			}
+			unit.Unit = matches[2]
+			unit.State = strings.TrimSpace(matches[3])
+		} else if strings.HasPrefix(line, "Stateful Failover") {
+			unit = nil
+		} else if unit == nil {
+			continue
+		} else if matches := reFailoverActive.FindStringSubmatch(line); matches != nil {
+			unit.ActiveTime = matches[1]
+		} else if matches := reFailoverInterface.FindStringSubmatch(line); matches != nil {
+			unit.Interfaces = append(unit.Interfaces, FailoverInterface{
+				Name:      matches[1],
+				IPAddress: matches[2],
+				Status:    matches[3],
+				Monitor:   matches[4],
+			})
+		}
+	}
+
+	if !found {
+		return FailoverStatus{}, fmt.Errorf("could not find failover state in output")
+	}
+
+	return status, nil
+}
@@ -0,0 +1,70 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ConnectionCount defines the parsed "show conn count" output of an ASA.
+type ConnectionCount struct {
+	InUse    int
+	MostUsed int // High-water mark since the counters were last cleared
+}
+
+// reConnCount matches "54 in use, 2310 most used".
+var reConnCount = regexp.MustCompile(`(\d+) in use, (\d+) most used`)
+
+func Show_conn_count(switch_hostname string) (ConnectionCount, error) {
+	return Show_conn_count_context(context.Background(), switch_hostname)
+}
+
+// Show_conn_count_context is Show_conn_count with a context that cancels the command.
+func Show_conn_count_context(ctx context.Context, switch_hostname string) (ConnectionCount, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return ConnectionCount{}, err
+	}
+	defer client.Close()
+
+	return client.ShowConnCountContext(ctx)
+}
+
+// ShowConnCount is Show_conn_count over the connection of the client.
+func (c *Client) ShowConnCount() (ConnectionCount, error) {
+	return c.ShowConnCountContext(context.Background())
+}
+
+// ShowConnCountContext is ShowConnCount with a context that cancels the command.
+func (c *Client) ShowConnCountContext(ctx context.Context) (ConnectionCount, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show conn count")
+	if err != nil {
+		return ConnectionCount{}, err
+	}
+
+	parseStart := time.Now()
+	conn_data, err := parseWith(c.platform(), "show conn count", outputString, ParseConnCount)
+	err = reportParse(switch_hostname, "show conn count", outputString, 1, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Conn Count :: Error during parsing: %v", switch_hostname, err)
+		return ConnectionCount{}, err
+	}
+
+	return conn_data, nil
+}
+
+// ParseConnCount processes the raw CLI output from "show conn count" on an ASA.
+func ParseConnCount(rawOutput string) (ConnectionCount, error) {
+	matches := reConnCount.FindStringSubmatch(rawOutput)
+	if matches == nil {
+		return ConnectionCount{}, fmt.Errorf("could not find connection count in output")
+	}
+
+	inUse, _ := strconv.Atoi(matches[1])
+	mostUsed, _ := strconv.Atoi(matches[2])
+	return ConnectionCount{InUse: inUse, MostUsed: mostUsed}, nil
+}
@@ -0,0 +1,134 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Show_lldp_neighbors_detail runs "show lldp neighbors detail", which adds the system
+// description and LLDP-MED data to each neighbor, so phones and cameras behind a port
+// can be told apart.
+func Show_lldp_neighbors_detail(switch_hostname string) ([]LldpNeighbor, error) {
+	return Show_lldp_neighbors_detail_context(context.Background(), switch_hostname)
+}
+
+// Show_lldp_neighbors_detail_context is Show_lldp_neighbors_detail with a context that cancels the command.
+func Show_lldp_neighbors_detail_context(ctx context.Context, switch_hostname string) ([]LldpNeighbor, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowLldpNeighborsDetailContext(ctx)
+}
+
+// ShowLldpNeighborsDetail is Show_lldp_neighbors_detail over the connection of the client.
+func (c *Client) ShowLldpNeighborsDetail() ([]LldpNeighbor, error) {
+	return c.ShowLldpNeighborsDetailContext(context.Background())
+}
+
+// ShowLldpNeighborsDetailContext is ShowLldpNeighborsDetail with a context that cancels the command.
+func (c *Client) ShowLldpNeighborsDetailContext(ctx context.Context) ([]LldpNeighbor, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show lldp neighbors detail")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	lldp_neighbors_data, err := parseWith(c.platform(), "show lldp neighbors detail", outputString, ParseLldpNeighborsDetail)
+	err = reportParse(switch_hostname, "show lldp neighbors detail", outputString, len(lldp_neighbors_data), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show LLDP Neighbors Detail :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show lldp neighbors detail' output for %s: %w", switch_hostname, err)
+	}
+
+	for i := range lldp_neighbors_data {
+		lldp_neighbors_data[i].Interface = normalizeInterfaceName(lldp_neighbors_data[i].Interface)
+		lldp_neighbors_data[i].NeighborInterface = normalizeInterfaceName(lldp_neighbors_data[i].NeighborInterface)
+	}
+
+	if len(lldp_neighbors_data) == 0 {
+		c.logf(slog.LevelWarn, "Show LLDP Neighbors Detail :: Warning: Parsing completed for %s, but no neighbors were found.", switch_hostname)
+		return nil, nil
+	}
+
+	return lldp_neighbors_data, nil
+}
+
+// ParseLldpNeighborsDetail processes the raw CLI output from "show lldp neighbors
+// detail" on IOS and IOS-XE, where every neighbor starts with its "Local Intf:" line.
+// The neighbor is named by its System Name, or its chassis ID when it has none.
+func ParseLldpNeighborsDetail(rawOutput string) ([]LldpNeighbor, error) {
+	var neighbors []LldpNeighbor
+	var current *LldpNeighbor
+	var chassisID string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if current.Neighbor == "" {
+			current.Neighbor = chassisID
+		}
+		current.Kind = ClassifyLldpNeighbor(*current)
+		neighbors = append(neighbors, *current)
+		current, chassisID = nil, ""
+	}
+
+	lines := strings.Split(rawOutput, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		if key == "Local Intf" {
+			flush()
+			current = &LldpNeighbor{Interface: value}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "Chassis id":
+			chassisID = value
+		case "Port id":
+			current.NeighborInterface = value
+		case "System Name":
+			current.Neighbor = value
+		case "System Description":
+			// The description follows on its own lines, up to a blank line.
+			var description []string
+			if value != "" {
+				description = append(description, value)
+			}
+			for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+				i++
+				description = append(description, strings.TrimSpace(lines[i]))
+			}
+			current.SystemDescription = strings.Join(description, " ")
+		case "Time remaining":
+			current.HoldTime = strings.TrimSuffix(value, " seconds")
+		case "Enabled Capabilities":
+			current.Capability = value
+		case "Device type":
+			current.MedDeviceType = value
+		}
+	}
+	flush()
+
+	if len(neighbors) == 0 && strings.Contains(rawOutput, "Chassis id") {
+		return nil, fmt.Errorf("LLDP neighbor details found but no \"Local Intf\" lines")
+	}
+
+	return neighbors, nil
+}
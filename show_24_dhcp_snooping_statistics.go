@@ -0,0 +1,119 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DhcpSnoopingStatistics holds the DHCP snooping packet counters of a switch. IOS
+// keeps them per switch, not per interface.
+type DhcpSnoopingStatistics struct {
+	Processed        int
+	Forwarded        int // Only in the summary form; the detail form has Processed instead
+	Dropped          int
+	DroppedUntrusted int // Server messages received on untrusted ports, e.g. from a rogue DHCP server
+
+	// DropReasons counts drops by reason, e.g. "No binding entry" or "Rate limit
+	// exceeded"; only the detail form has it.
+	DropReasons map[string]int
+}
+
+// Show_ip_dhcp_snooping_statistics runs "show ip dhcp snooping statistics detail" and
+// returns the counters with drops broken down by reason.
+func Show_ip_dhcp_snooping_statistics(switch_hostname string) (DhcpSnoopingStatistics, error) {
+	return Show_ip_dhcp_snooping_statistics_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_dhcp_snooping_statistics_context is Show_ip_dhcp_snooping_statistics with a context that cancels the command.
+func Show_ip_dhcp_snooping_statistics_context(ctx context.Context, switch_hostname string) (DhcpSnoopingStatistics, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return DhcpSnoopingStatistics{}, err
+	}
+	defer client.Close()
+
+	return client.ShowIpDhcpSnoopingStatisticsContext(ctx)
+}
+
+// ShowIpDhcpSnoopingStatistics is Show_ip_dhcp_snooping_statistics over the connection of the client.
+func (c *Client) ShowIpDhcpSnoopingStatistics() (DhcpSnoopingStatistics, error) {
+	return c.ShowIpDhcpSnoopingStatisticsContext(context.Background())
+}
+
+// ShowIpDhcpSnoopingStatisticsContext is ShowIpDhcpSnoopingStatistics with a context that cancels the command.
+func (c *Client) ShowIpDhcpSnoopingStatisticsContext(ctx context.Context) (DhcpSnoopingStatistics, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip dhcp snooping statistics detail")
+	if err != nil {
+		return DhcpSnoopingStatistics{}, err
+	}
+
+	parseStart := time.Now()
+	statistics, err := parseWith(c.platform(), "show ip dhcp snooping statistics detail", outputString, ParseDhcpSnoopingStatistics)
+	err = reportParse(switch_hostname, "show ip dhcp snooping statistics detail", outputString, 1, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show DHCP Snooping Statistics :: Error during parsing: %v", switch_hostname, err)
+		return DhcpSnoopingStatistics{}, fmt.Errorf("error during parsing 'show ip dhcp snooping statistics detail' output for %s: %w", switch_hostname, err)
+	}
+
+	return statistics, nil
+}
+
+// ParseDhcpSnoopingStatistics processes the raw CLI output from "show ip dhcp snooping
+// statistics", in its summary or detail form. In the detail form, Dropped is the sum
+// of the drop reasons.
+func ParseDhcpSnoopingStatistics(rawOutput string) (DhcpSnoopingStatistics, error) {
+	var statistics DhcpSnoopingStatistics
+	found := false
+	inReasons := false
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "Packets Dropped Because" {
+			inReasons = true
+			statistics.DropReasons = make(map[string]int)
+			found = true
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		found = true
+
+		if inReasons {
+			statistics.DropReasons[name] = count
+			statistics.Dropped += count
+			if name == "Received on untrusted ports" {
+				statistics.DroppedUntrusted = count
+			}
+			continue
+		}
+
+		switch name {
+		case "Packets Processed by DHCP Snooping":
+			statistics.Processed = count
+		case "Packets Forwarded":
+			statistics.Forwarded = count
+		case "Packets Dropped":
+			statistics.Dropped = count
+		case "Packets Dropped From untrusted ports":
+			statistics.DroppedUntrusted = count
+		}
+	}
+
+	if !found {
+		return DhcpSnoopingStatistics{}, fmt.Errorf("no DHCP snooping counters found")
+	}
+	return statistics, nil
+}
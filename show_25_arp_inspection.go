@@ -0,0 +1,218 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArpInspectionVlanStatistics holds the dynamic ARP inspection counters of one VLAN.
+type ArpInspectionVlanStatistics struct {
+	Vlan                 string
+	Forwarded            int
+	Dropped              int
+	DhcpDrops            int // No matching DHCP snooping binding: spoofed, or a static address
+	AclDrops             int
+	DhcpPermits          int
+	AclPermits           int
+	ProbePermits         int
+	SourceMacFailures    int
+	DestMacFailures      int
+	IPValidationFailures int
+	InvalidProtocolData  int
+}
+
+// ArpInspectionInterface is the dynamic ARP inspection setting of one interface.
+type ArpInspectionInterface struct {
+	Interface     string
+	Trusted       bool
+	RateLimit     string // Packets per second, or "None"
+	BurstInterval string
+}
+
+// reColumnGap splits a table header into its column titles.
+var reColumnGap = regexp.MustCompile(`\s{2,}`)
+
+// Show_ip_arp_inspection_statistics runs "show ip arp inspection statistics" and
+// returns the counters of every VLAN.
+func Show_ip_arp_inspection_statistics(switch_hostname string) ([]ArpInspectionVlanStatistics, error) {
+	return Show_ip_arp_inspection_statistics_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_arp_inspection_statistics_context is Show_ip_arp_inspection_statistics with a context that cancels the command.
+func Show_ip_arp_inspection_statistics_context(ctx context.Context, switch_hostname string) ([]ArpInspectionVlanStatistics, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowIpArpInspectionStatisticsContext(ctx)
+}
+
+// ShowIpArpInspectionStatistics is Show_ip_arp_inspection_statistics over the connection of the client.
+func (c *Client) ShowIpArpInspectionStatistics() ([]ArpInspectionVlanStatistics, error) {
+	return c.ShowIpArpInspectionStatisticsContext(context.Background())
+}
+
+// ShowIpArpInspectionStatisticsContext is ShowIpArpInspectionStatistics with a context that cancels the command.
+func (c *Client) ShowIpArpInspectionStatisticsContext(ctx context.Context) ([]ArpInspectionVlanStatistics, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip arp inspection statistics")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	statistics, err := parseWith(c.platform(), "show ip arp inspection statistics", outputString, ParseArpInspectionStatistics)
+	err = reportParse(switch_hostname, "show ip arp inspection statistics", outputString, len(statistics), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show ARP Inspection Statistics :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show ip arp inspection statistics' output for %s: %w", switch_hostname, err)
+	}
+
+	return statistics, nil
+}
+
+// ParseArpInspectionStatistics processes the raw CLI output from "show ip arp
+// inspection statistics". IOS prints the counters as several tables keyed by VLAN,
+// which are merged into one entry per VLAN.
+func ParseArpInspectionStatistics(rawOutput string) ([]ArpInspectionVlanStatistics, error) {
+	var statistics []ArpInspectionVlanStatistics
+	byVlan := make(map[string]int) // Index into statistics
+	var columns []string
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Vlan") {
+			columns = reColumnGap.Split(line, -1)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if columns == nil || len(fields) != len(columns) {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+
+		i, ok := byVlan[fields[0]]
+		if !ok {
+			i = len(statistics)
+			byVlan[fields[0]] = i
+			statistics = append(statistics, ArpInspectionVlanStatistics{Vlan: fields[0]})
+		}
+		entry := &statistics[i]
+		for c, title := range columns[1:] {
+			count, _ := strconv.Atoi(fields[c+1])
+			switch title {
+			case "Forwarded":
+				entry.Forwarded = count
+			case "Dropped":
+				entry.Dropped = count
+			case "DHCP Drops":
+				entry.DhcpDrops = count
+			case "ACL Drops":
+				entry.AclDrops = count
+			case "DHCP Permits":
+				entry.DhcpPermits = count
+			case "ACL Permits":
+				entry.AclPermits = count
+			case "Probe Permits":
+				entry.ProbePermits = count
+			case "Source MAC Failures":
+				entry.SourceMacFailures = count
+			case "Dest MAC Failures":
+				entry.DestMacFailures = count
+			case "IP Validation Failures":
+				entry.IPValidationFailures = count
+			case "Invalid Protocol Data":
+				entry.InvalidProtocolData = count
+			}
+		}
+	}
+
+	if columns == nil {
+		return nil, fmt.Errorf("ARP inspection statistics header not found")
+	}
+	return statistics, nil
+}
+
+// Show_ip_arp_inspection_interfaces runs "show ip arp inspection interfaces" and
+// returns the trust state and rate limit of every interface.
+func Show_ip_arp_inspection_interfaces(switch_hostname string) ([]ArpInspectionInterface, error) {
+	return Show_ip_arp_inspection_interfaces_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_arp_inspection_interfaces_context is Show_ip_arp_inspection_interfaces with a context that cancels the command.
+func Show_ip_arp_inspection_interfaces_context(ctx context.Context, switch_hostname string) ([]ArpInspectionInterface, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowIpArpInspectionInterfacesContext(ctx)
+}
+
+// ShowIpArpInspectionInterfaces is Show_ip_arp_inspection_interfaces over the connection of the client.
+func (c *Client) ShowIpArpInspectionInterfaces() ([]ArpInspectionInterface, error) {
+	return c.ShowIpArpInspectionInterfacesContext(context.Background())
+}
+
+// ShowIpArpInspectionInterfacesContext is ShowIpArpInspectionInterfaces with a context that cancels the command.
+func (c *Client) ShowIpArpInspectionInterfacesContext(ctx context.Context) ([]ArpInspectionInterface, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip arp inspection interfaces")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	interfaces, err := parseWith(c.platform(), "show ip arp inspection interfaces", outputString, ParseArpInspectionInterfaces)
+	err = reportParse(switch_hostname, "show ip arp inspection interfaces", outputString, len(interfaces), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show ARP Inspection Interfaces :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show ip arp inspection interfaces' output for %s: %w", switch_hostname, err)
+	}
+
+	for i := range interfaces {
+		interfaces[i].Interface = normalizeInterfaceName(interfaces[i].Interface)
+	}
+
+	return interfaces, nil
+}
+
+// ParseArpInspectionInterfaces processes the raw CLI output from "show ip arp
+// inspection interfaces".
+func ParseArpInspectionInterfaces(rawOutput string) ([]ArpInspectionInterface, error) {
+	var interfaces []ArpInspectionInterface
+	headerFound := false
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && fields[0] == "Interface" && fields[1] == "Trust" {
+			headerFound = true
+			continue
+		}
+		if !headerFound || len(fields) != 4 || (fields[1] != "Trusted" && fields[1] != "Untrusted") {
+			continue
+		}
+		interfaces = append(interfaces, ArpInspectionInterface{
+			Interface:     fields[0],
+			Trusted:       fields[1] == "Trusted",
+			RateLimit:     fields[2],
+			BurstInterval: fields[3],
+		})
+	}
+
+	if !headerFound {
+		return nil, fmt.Errorf("ARP inspection interfaces header not found")
+	}
+	return interfaces, nil
+}
@@ -0,0 +1,105 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// InterfaceDescription is one line of "show interfaces description". Unlike "show
+// interfaces status", which cuts descriptions at 18 characters, it has them in full.
+type InterfaceDescription struct {
+	Interface   string
+	Status      string // up, down or admin down
+	Protocol    string
+	Description string
+}
+
+func Show_interfaces_description(switch_hostname string) ([]InterfaceDescription, error) {
+	return Show_interfaces_description_context(context.Background(), switch_hostname)
+}
+
+// Show_interfaces_description_context is Show_interfaces_description with a context that cancels the command.
+func Show_interfaces_description_context(ctx context.Context, switch_hostname string) ([]InterfaceDescription, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowInterfacesDescriptionContext(ctx)
+}
+
+// ShowInterfacesDescription is Show_interfaces_description over the connection of the client.
+func (c *Client) ShowInterfacesDescription() ([]InterfaceDescription, error) {
+	return c.ShowInterfacesDescriptionContext(context.Background())
+}
+
+// ShowInterfacesDescriptionContext is ShowInterfacesDescription with a context that cancels the command.
+func (c *Client) ShowInterfacesDescriptionContext(ctx context.Context) ([]InterfaceDescription, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show interfaces description")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	descriptions, err := parseWith(c.platform(), "show interfaces description", outputString, ParseInterfaceDescriptions)
+	err = reportParse(switch_hostname, "show interfaces description", outputString, len(descriptions), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Interfaces Description :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show interfaces description' output for %s: %w", switch_hostname, err)
+	}
+
+	for i := range descriptions {
+		descriptions[i].Interface = normalizeInterfaceName(descriptions[i].Interface)
+	}
+
+	return descriptions, nil
+}
+
+// ParseInterfaceDescriptions processes the raw CLI output from "show interfaces
+// description". The status may be two words ("admin down") and the description may
+// contain spaces, so the columns are cut at the header positions.
+func ParseInterfaceDescriptions(rawOutput string) ([]InterfaceDescription, error) {
+	var descriptions []InterfaceDescription
+	var columns map[string][2]int
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if columns == nil {
+			if strings.HasPrefix(line, "Interface") && strings.Contains(line, "Description") {
+				columns = headerColumns(line, "Interface", "Status", "Protocol", "Description")
+				if len(columns) != 4 {
+					return nil, fmt.Errorf("could not parse interfaces description header columns")
+				}
+			}
+			continue
+		}
+
+		field := func(title string) string {
+			return columnField(line, columns[title][0], columns[title][1])
+		}
+
+		description := InterfaceDescription{
+			Interface:   field("Interface"),
+			Status:      field("Status"),
+			Protocol:    field("Protocol"),
+			Description: field("Description"),
+		}
+		if description.Interface == "" || description.Status == "" {
+			continue // Blank lines and the prompt
+		}
+
+		descriptions = append(descriptions, description)
+	}
+
+	if columns == nil {
+		return nil, fmt.Errorf("could not find interfaces description header in output")
+	}
+
+	return descriptions, nil
+}
@@ -0,0 +1,131 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// IPInterfaceBrief is one line of "show ip interface brief".
+type IPInterfaceBrief struct {
+	Interface string
+	IPAddress string // As printed, "unassigned" for interfaces without one
+	OK        string // YES or NO; empty on NX-OS and IOS-XR
+	Method    string // How the address was set, e.g. NVRAM, manual, DHCP or unset; empty on NX-OS and IOS-XR
+	Status    string // up, down or administratively down (Up, Down or Shutdown on IOS-XR)
+	Protocol  string
+}
+
+// Show_ip_interface_brief connects to a switch, runs "show ip interface brief", and
+// returns one entry per interface.
+func Show_ip_interface_brief(switch_hostname string) ([]IPInterfaceBrief, error) {
+	return Show_ip_interface_brief_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_interface_brief_context is Show_ip_interface_brief with a context that cancels the command.
+func Show_ip_interface_brief_context(ctx context.Context, switch_hostname string) ([]IPInterfaceBrief, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowIpInterfaceBriefContext(ctx)
+}
+
+// ShowIpInterfaceBrief is Show_ip_interface_brief over the connection of the client.
+func (c *Client) ShowIpInterfaceBrief() ([]IPInterfaceBrief, error) {
+	return c.ShowIpInterfaceBriefContext(context.Background())
+}
+
+// ShowIpInterfaceBriefContext is ShowIpInterfaceBrief with a context that cancels the command.
+func (c *Client) ShowIpInterfaceBriefContext(ctx context.Context) ([]IPInterfaceBrief, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip interface brief")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	interfaces, err := parseWith(c.platform(), "show ip interface brief", outputString, ParseIPInterfaceBrief)
+	err = reportParse(switch_hostname, "show ip interface brief", outputString, len(interfaces), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show IP Interface Brief :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show ip interface brief' output for %s: %w", switch_hostname, err)
+	}
+
+	for i := range interfaces {
+		interfaces[i].Interface = normalizeInterfaceName(interfaces[i].Interface)
+	}
+
+	return interfaces, nil
+}
+
+// ParseIPInterfaceBrief processes the raw CLI output from "show ip interface brief" on
+// IOS, IOS-XE, NX-OS and IOS-XR, telling them apart by the header. Long interface
+// names push the other columns to the right, so rows are split into words; on IOS the
+// words between the method and the protocol are the status, e.g. "administratively
+// down". NX-OS prints "protocol-up/link-up/admin-up", which is split into Status and
+// Protocol.
+func ParseIPInterfaceBrief(rawOutput string) ([]IPInterfaceBrief, error) {
+	var interfaces []IPInterfaceBrief
+	format := ""
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+		fields := strings.Fields(line)
+
+		if format == "" {
+			switch {
+			case !strings.HasPrefix(line, "Interface"):
+			case strings.Contains(line, "OK?"):
+				format = "ios"
+			case strings.Contains(line, "Interface Status"):
+				format = "nx-os"
+			case strings.Contains(line, "Protocol"):
+				format = "ios-xr"
+			}
+			continue
+		}
+
+		var entry IPInterfaceBrief
+		switch {
+		case format == "ios" && len(fields) >= 6:
+			entry = IPInterfaceBrief{
+				Interface: fields[0],
+				IPAddress: fields[1],
+				OK:        fields[2],
+				Method:    fields[3],
+				Status:    strings.Join(fields[4:len(fields)-1], " "),
+				Protocol:  fields[len(fields)-1],
+			}
+		case format == "nx-os" && len(fields) == 3 && strings.HasPrefix(fields[2], "protocol-"):
+			entry = IPInterfaceBrief{Interface: fields[0], IPAddress: fields[1]}
+			for _, state := range strings.Split(fields[2], "/") {
+				name, value, _ := strings.Cut(state, "-")
+				switch {
+				case name == "protocol":
+					entry.Protocol = value
+				case name == "link" && entry.Status == "":
+					entry.Status = value
+				case name == "admin" && value == "down":
+					entry.Status = "administratively down"
+				}
+			}
+		case format == "ios-xr" && len(fields) >= 4:
+			entry = IPInterfaceBrief{Interface: fields[0], IPAddress: fields[1], Status: fields[2], Protocol: fields[3]}
+		default:
+			continue // Blank lines, repeated headers and the prompt
+		}
+
+		interfaces = append(interfaces, entry)
+	}
+
+	if format == "" {
+		return nil, fmt.Errorf("could not find ip interface brief header in output")
+	}
+
+	return interfaces, nil
+}
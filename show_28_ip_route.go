@@ -0,0 +1,227 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RouteEntry is one next hop of a route in "show ip route". A route with several
+// equal-cost next hops yields one entry per next hop.
+type RouteEntry struct {
+	Protocol  string // The code on IOS, e.g. C, S, O IA or D EX; the protocol on NX-OS, e.g. direct, ospf-1 or bgp-65001
+	Default   bool   // Candidate default, marked with "*" on IOS
+	Network   string
+	PrefixLen int
+	Mask      string // PrefixLen as a dotted mask, e.g. 255.255.255.0
+	NextHop   string // Empty for directly connected and local routes
+	Interface string // Empty for recursive routes, e.g. from BGP
+	Metric    int
+	AD        int    // Administrative distance, the preference on NX-OS
+	Age       string // As printed, e.g. 3d04h or 00:12:01; empty for connected and static routes on IOS
+}
+
+// Regexes used by the "show ip route" parser.
+var (
+	// IOS: "O IA     10.2.0.0/24 [110/3] via 10.0.10.1, 3d04h, Vlan10", where the
+	// network may lack the length under an "is subnetted" line, and the rest may wrap
+	// to the next line on older releases.
+	reRouteIOS = regexp.MustCompile(`^([A-Za-z*+%&][A-Za-z0-9*+%&]*(?: [A-Za-z0-9]{1,2})?)\s+(\d+\.\d+\.\d+\.\d+)(?:/(\d+))?(.*)$`)
+
+	// IOS: "      10.0.0.0/8 is variably subnetted, 6 subnets, 3 masks" or
+	// "      192.168.1.0/24 is subnetted, 2 subnets"
+	reRouteSubnetted = regexp.MustCompile(`^\s+\d+\.\d+\.\d+\.\d+/(\d+) is (variably )?subnetted`)
+
+	// IOS: "[110/2] via 10.0.10.1, 3d04h, Vlan10", also alone on the continuation line
+	// of an equal-cost next hop.
+	reRouteVia = regexp.MustCompile(`^\s*\[(\d+)/(\d+)\] via ([^,\s]+)((?:,\s*[^,]+)*)$`)
+
+	// IOS: "is directly connected, Vlan10" and EIGRP "is a summary, 3d04h, Null0"
+	reRouteConnected = regexp.MustCompile(`^\s*is (?:directly connected|a summary)((?:,\s*[^,]+)*)$`)
+
+	// NX-OS: "10.255.0.0/32, ubest/mbest: 2/0" followed by one line per next hop:
+	// "    *via 10.1.0.2, Eth1/49, [110/41], 4w2d, ospf-1, intra"
+	reRouteNxos    = regexp.MustCompile(`^(\d+\.\d+\.\d+\.\d+)/(\d+), ubest/mbest:`)
+	reRouteNxosVia = regexp.MustCompile(`^\s+\*?via ([^,]+),(?: ([^,\[]+),)? \[(\d+)/(\d+)\], ([^,]+), ([^,]+)`)
+
+	// reRouteAge tells an age, e.g. 3d04h, 1w2d or 00:12:01, from an interface name.
+	reRouteAge = regexp.MustCompile(`^(?:\d+[ywdhms][\dywdhms]*|\d+:\d+:\d+|never)$`)
+)
+
+// Show_ip_route connects to a switch, runs "show ip route", and returns one entry per
+// next hop of every route.
+func Show_ip_route(switch_hostname string) ([]RouteEntry, error) {
+	return Show_ip_route_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_route_context is Show_ip_route with a context that cancels the command.
+func Show_ip_route_context(ctx context.Context, switch_hostname string) ([]RouteEntry, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowIpRouteContext(ctx)
+}
+
+// ShowIpRoute is Show_ip_route over the connection of the client.
+func (c *Client) ShowIpRoute() ([]RouteEntry, error) {
+	return c.ShowIpRouteContext(context.Background())
+}
+
+// ShowIpRouteContext is ShowIpRoute with a context that cancels the command.
+func (c *Client) ShowIpRouteContext(ctx context.Context) ([]RouteEntry, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip route")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	routes, err := parseWith(c.platform(), "show ip route", outputString, ParseIPRoute)
+	err = reportParse(switch_hostname, "show ip route", outputString, len(routes), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show IP Route :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show ip route' output for %s: %w", switch_hostname, err)
+	}
+
+	for i := range routes {
+		routes[i].Interface = normalizeInterfaceName(routes[i].Interface)
+	}
+
+	return routes, nil
+}
+
+// ParseIPRoute processes the raw CLI output from "show ip route" on IOS, IOS-XE and
+// NX-OS. Networks printed without a length under an "is subnetted" line get the
+// length of that line, and the rest their classful length, as IOS means them.
+func ParseIPRoute(rawOutput string) ([]RouteEntry, error) {
+	var routes []RouteEntry
+	var current RouteEntry // The route whose next hops follow
+	subnetLen := 0
+	found := false
+
+	addVia := func(ad string, metric string, nextHop string, rest string) {
+		entry := current
+		entry.AD, _ = strconv.Atoi(ad)
+		entry.Metric, _ = strconv.Atoi(metric)
+		entry.NextHop = nextHop
+		entry.Age, entry.Interface = routeAgeInterface(rest)
+		routes = append(routes, entry)
+	}
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(line, "Gateway of last resort") || strings.HasPrefix(line, "IP Route Table") {
+			found = true // Headers of a table that may be empty
+			continue
+		}
+
+		if matches := reRouteSubnetted.FindStringSubmatch(line); matches != nil {
+			subnetLen = 0
+			if matches[2] == "" {
+				subnetLen, _ = strconv.Atoi(matches[1])
+			}
+			continue
+		}
+
+		if matches := reRouteNxos.FindStringSubmatch(line); matches != nil {
+			prefixLen, _ := strconv.Atoi(matches[2])
+			current = newRouteEntry(matches[1], prefixLen)
+			continue
+		}
+		if matches := reRouteNxosVia.FindStringSubmatch(line); matches != nil && current.Network != "" {
+			entry := current
+			entry.NextHop = strings.TrimSpace(matches[1])
+			entry.Interface = strings.TrimSpace(matches[2])
+			entry.AD, _ = strconv.Atoi(matches[3])
+			entry.Metric, _ = strconv.Atoi(matches[4])
+			entry.Age = strings.TrimSpace(matches[5])
+			entry.Protocol = strings.TrimSpace(matches[6])
+			if entry.Protocol == "direct" || entry.Protocol == "local" {
+				entry.NextHop = "" // NX-OS prints the address of the interface itself
+			}
+			routes = append(routes, entry)
+			continue
+		}
+
+		if matches := reRouteVia.FindStringSubmatch(line); matches != nil && current.Network != "" {
+			addVia(matches[1], matches[2], matches[3], matches[4])
+			continue
+		}
+
+		matches := reRouteIOS.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		prefixLen := subnetLen
+		if matches[3] != "" {
+			prefixLen, _ = strconv.Atoi(matches[3])
+		} else if prefixLen == 0 {
+			prefixLen = classfulPrefixLen(matches[2])
+		}
+		current = newRouteEntry(matches[2], prefixLen)
+		current.Protocol = strings.Join(strings.Fields(strings.ReplaceAll(matches[1], "*", " ")), " ")
+		current.Default = strings.Contains(matches[1], "*")
+
+		rest := matches[4]
+		if via := reRouteVia.FindStringSubmatch(rest); via != nil {
+			addVia(via[1], via[2], via[3], via[4])
+		} else if connected := reRouteConnected.FindStringSubmatch(rest); connected != nil {
+			entry := current
+			entry.Age, entry.Interface = routeAgeInterface(connected[1])
+			routes = append(routes, entry)
+		}
+		// Otherwise the next hops follow on the next lines
+	}
+
+	if !found {
+		return nil, fmt.Errorf("could not find ip route table header in output")
+	}
+
+	return routes, nil
+}
+
+// newRouteEntry returns a route to network with its prefix length and mask filled in.
+func newRouteEntry(network string, prefixLen int) RouteEntry {
+	return RouteEntry{Network: network, PrefixLen: prefixLen, Mask: net.IP(net.CIDRMask(prefixLen, 32)).String()}
+}
+
+// routeAgeInterface splits the ", 3d04h, Vlan10" after the next hop of an IOS route
+// into the age and the interface, either of which may be missing.
+func routeAgeInterface(rest string) (string, string) {
+	var age, iface string
+	for _, part := range strings.Split(rest, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case reRouteAge.MatchString(part):
+			age = part
+		default:
+			iface = part
+		}
+	}
+	return age, iface
+}
+
+// classfulPrefixLen returns the length of the class A, B or C network of address.
+func classfulPrefixLen(address string) int {
+	ip := net.ParseIP(address).To4()
+	switch {
+	case ip == nil:
+		return 32
+	case ip[0] < 128:
+		return 8
+	case ip[0] < 192:
+		return 16
+	}
+	return 24
+}
@@ -0,0 +1,99 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ArpEntry is one entry of the ARP table.
+type ArpEntry struct {
+	IPAddress  string
+	Age        string // Minutes on IOS, hh:mm:ss on NX-OS, seconds on ASA; "-" for the device's own addresses
+	MacAddress string // As printed, e.g. Incomplete while the address is being resolved
+	Type       string // ARPA on IOS; empty on NX-OS and ASA
+	Interface  string // The nameif on ASA, e.g. outside
+}
+
+// Regexes used by the ARP table parser, one per platform. IOS prints
+// "Internet  10.0.10.25   12   0011.2233.4455  ARPA   Vlan10", NX-OS
+// "10.100.0.10     00:12:44  0050.56a1.0001  Vlan100" and ASA
+// "outside 10.0.0.1 0011.2233.4455 12".
+var (
+	reArpEntry     = regexp.MustCompile(`^\s*Internet\s+(\S+)\s+(\S+)\s+(\S+)\s+(\S+)\s*(\S*)`)
+	reArpEntryNxos = regexp.MustCompile(`^(\d+\.\d+\.\d+\.\d+)\s+(\S+)\s+([0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}|INCOMPLETE)\s+(\S+)`)
+	reArpEntryAsa  = regexp.MustCompile(`^\s*(\S+)\s+(\d+\.\d+\.\d+\.\d+)\s+([0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4})\s+(\S+)\s*$`)
+)
+
+// Show_arp connects to a switch and returns its ARP table, from "show ip arp", or
+// "show arp" on ASA.
+func Show_arp(switch_hostname string) ([]ArpEntry, error) {
+	return Show_arp_context(context.Background(), switch_hostname)
+}
+
+// Show_arp_context is Show_arp with a context that cancels the command.
+func Show_arp_context(ctx context.Context, switch_hostname string) ([]ArpEntry, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowArpContext(ctx)
+}
+
+// ShowArp is Show_arp over the connection of the client.
+func (c *Client) ShowArp() ([]ArpEntry, error) {
+	return c.ShowArpContext(context.Background())
+}
+
+// ShowArpContext is ShowArp with a context that cancels the command.
+func (c *Client) ShowArpContext(ctx context.Context) ([]ArpEntry, error) {
+	switch_hostname := c.SwitchHostname
+	command := "show ip arp"
+	if c.platform() == PlatformASA {
+		command = "show arp"
+	}
+	outputString, err := c.RunCommandContext(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	entries, err := parseWith(c.platform(), command, outputString, ParseArp)
+	err = reportParse(switch_hostname, command, outputString, len(entries), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show ARP :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing '%s' output for %s: %w", command, switch_hostname, err)
+	}
+
+	for i := range entries {
+		entries[i].Interface = normalizeInterfaceName(entries[i].Interface)
+	}
+
+	return entries, nil
+}
+
+// ParseArp processes the raw CLI output from "show ip arp" on IOS, IOS-XE and NX-OS,
+// or "show arp" on IOS and ASA. Lines that are not entries, such as headers and
+// totals, are skipped, so output without entries yields none.
+func ParseArp(rawOutput string) ([]ArpEntry, error) {
+	var entries []ArpEntry
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if matches := reArpEntry.FindStringSubmatch(line); matches != nil {
+			entries = append(entries, ArpEntry{IPAddress: matches[1], Age: matches[2], MacAddress: matches[3], Type: matches[4], Interface: matches[5]})
+		} else if matches := reArpEntryNxos.FindStringSubmatch(line); matches != nil {
+			entries = append(entries, ArpEntry{IPAddress: matches[1], Age: matches[2], MacAddress: matches[3], Interface: matches[4]})
+		} else if matches := reArpEntryAsa.FindStringSubmatch(line); matches != nil {
+			entries = append(entries, ArpEntry{Interface: matches[1], IPAddress: matches[2], MacAddress: matches[3], Age: matches[4]})
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,94 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// InventoryItem is one entry of "show inventory": the chassis, each stack member,
+// module, power supply, fan tray and transceiver with its part and serial number.
+type InventoryItem struct {
+	Name         string // e.g. Switch 1, Switch 1 - Power Supply A or TenGigabitEthernet1/1/1
+	Description  string
+	PID          string // Product ID, the orderable part number
+	VID          string // Version ID, the hardware revision
+	SerialNumber string
+}
+
+// Regexes used by the "show inventory" parser. Each item is a NAME/DESCR line followed
+// by a PID/VID/SN line; ASA writes "Name:", and NX-OS pads the commas with spaces.
+var (
+	reInventoryName = regexp.MustCompile(`(?i)^\s*NAME:\s*"([^"]*)"\s*,\s*DESCR:\s*"([^"]*)"`)
+	reInventoryPID  = regexp.MustCompile(`(?i)^\s*PID:\s*([^,]*?)\s*,\s*VID:\s*([^,]*?)\s*,\s*SN:\s*(\S*)`)
+)
+
+// Show_inventory connects to a switch, runs "show inventory", and returns its
+// hardware items, including every member of a stack.
+func Show_inventory(switch_hostname string) ([]InventoryItem, error) {
+	return Show_inventory_context(context.Background(), switch_hostname)
+}
+
+// Show_inventory_context is Show_inventory with a context that cancels the command.
+func Show_inventory_context(ctx context.Context, switch_hostname string) ([]InventoryItem, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowInventoryContext(ctx)
+}
+
+// ShowInventory is Show_inventory over the connection of the client.
+func (c *Client) ShowInventory() ([]InventoryItem, error) {
+	return c.ShowInventoryContext(context.Background())
+}
+
+// ShowInventoryContext is ShowInventory with a context that cancels the command.
+func (c *Client) ShowInventoryContext(ctx context.Context) ([]InventoryItem, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show inventory")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	items, err := parseWith(c.platform(), "show inventory", outputString, ParseInventory)
+	err = reportParse(switch_hostname, "show inventory", outputString, len(items), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Inventory :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show inventory' output for %s: %w", switch_hostname, err)
+	}
+
+	return items, nil
+}
+
+// ParseInventory processes the raw CLI output from "show inventory" on IOS, IOS-XE,
+// NX-OS, IOS-XR and ASA. Items without a PID line, which some modules print, are kept
+// with their name and description only.
+func ParseInventory(rawOutput string) ([]InventoryItem, error) {
+	var items []InventoryItem
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if matches := reInventoryName.FindStringSubmatch(line); matches != nil {
+			items = append(items, InventoryItem{Name: matches[1], Description: strings.TrimSpace(matches[2])})
+			continue
+		}
+		if matches := reInventoryPID.FindStringSubmatch(line); matches != nil && len(items) > 0 {
+			item := &items[len(items)-1]
+			item.PID, item.VID, item.SerialNumber = matches[1], matches[2], matches[3]
+		}
+	}
+
+	if len(items) == 0 && strings.Contains(rawOutput, "DESCR") {
+		return nil, fmt.Errorf("could not parse any inventory item in output")
+	}
+
+	return items, nil
+}
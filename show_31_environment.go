@@ -0,0 +1,187 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment is the state of the fans, power supplies and temperature sensors of a
+// switch, or of every member of a stack.
+type Environment struct {
+	Fans          []EnvironmentFan
+	PowerSupplies []EnvironmentPowerSupply
+	Temperatures  []EnvironmentTemperature
+}
+
+// EnvironmentFan is one fan, e.g. "Switch 1 FAN 1" on IOS or "Fan1(sys_fan1)" on NX-OS.
+type EnvironmentFan struct {
+	Name   string
+	Status string // As printed, e.g. OK, NOT PRESENT or Ok
+	Speed  int    // RPM, where the switch prints it; zero otherwise
+}
+
+// EnvironmentPowerSupply is one power supply slot, e.g. "1A" for the first one of
+// stack member 1 on IOS or "1" on NX-OS.
+type EnvironmentPowerSupply struct {
+	Name        string
+	Model       string // PID; empty for empty slots
+	Status      string // As printed, e.g. OK, Not Present, No Input Power or Ok
+	Watts       int    // Capacity
+	OutputWatts int    // Current draw, NX-OS only
+}
+
+// EnvironmentTemperature is one temperature sensor with its reading and thresholds in
+// degrees Celsius.
+type EnvironmentTemperature struct {
+	Name     string // e.g. "Switch 1 Inlet" on IOS or "Module 1 FRONT" on NX-OS
+	Status   string // GREEN, YELLOW or RED on IOS; Normal, Minor or Major on NX-OS
+	Celsius  int
+	Warning  int // The yellow threshold on IOS, minor on NX-OS
+	Critical int // The red threshold on IOS, major on NX-OS
+}
+
+// Regexes used by the environment parser for IOS "show env all" and NX-OS "show
+// environment".
+var (
+	reEnvFan         = regexp.MustCompile(`^\s*((?:Switch \d+ )?FAN\b.*?) is (.+?)\s*$`)                  // IOS: "Switch 1 FAN 1 is OK", "FAN PS-2 is NOT PRESENT"
+	reEnvFanSpeed    = regexp.MustCompile(`^\s*(\d+)\s+(\d+)\s+(\d+)\s+(\S+)`)                            // IOS-XE: "  1       1     5440     OK     Front to Back"
+	reEnvSwitch      = regexp.MustCompile(`^\s*Switch (\d+): SYSTEM TEMPERATURE`)                         // IOS
+	reEnvTemperature = regexp.MustCompile(`^\s*(\w[\w ]*?)?\s*Temperature Value:\s*(\d+)`)                // IOS: "Inlet Temperature Value: 30 Degree Celsius"
+	reEnvState       = regexp.MustCompile(`^\s*Temperature State:\s*(\S+)`)                               // IOS
+	reEnvThreshold   = regexp.MustCompile(`^\s*(Yellow|Red) Threshold\s*:\s*(\d+)`)                       // IOS
+	reEnvPowerSupply = regexp.MustCompile(`^\s*(\d+[A-Z])\s+(\S+)\s+\S+\s+(.+?)\s+\S+\s+\S+\s+(\d+)\s*$`) // IOS: "1A  PWR-C1-715WAC  LIT2330A1B2  OK  Good  Good  715"
+	reEnvNoSupply    = regexp.MustCompile(`^\s*(\d+[A-Z])\s+(Not Present)\s*$`)                           // IOS
+	reEnvPowerNxos   = regexp.MustCompile(`^(\d+)\s+(\S+)\s+(\d+) W\s+\d+ W\s+(\d+) W\s+(.+?)\s*$`)       // NX-OS: "1  NXA-PAC-650W-PE  142 W  154 W  650 W  Ok"
+	reEnvFanNxos     = regexp.MustCompile(`^(Fan\S*\d\S*)\s+\S+\s+\S+\s+\S+\s+(\S+)\s*$`)                 // NX-OS: "Fan1(sys_fan1)  NXA-FAN-30CFM-B  --  back-to-front  Ok"
+	reEnvTempNxos    = regexp.MustCompile(`^(\d+)\s+(\S+)\s+(\d+)\s+(\d+)\s+(\d+)\s+(\S+)\s*$`)           // NX-OS: "1  FRONT  80  70  31  Normal"
+)
+
+// Show_environment connects to a switch and returns the state of its fans, power
+// supplies and temperature sensors, from "show env all", or "show environment" on
+// NX-OS.
+func Show_environment(switch_hostname string) (Environment, error) {
+	return Show_environment_context(context.Background(), switch_hostname)
+}
+
+// Show_environment_context is Show_environment with a context that cancels the command.
+func Show_environment_context(ctx context.Context, switch_hostname string) (Environment, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return Environment{}, err
+	}
+	defer client.Close()
+
+	return client.ShowEnvironmentContext(ctx)
+}
+
+// ShowEnvironment is Show_environment over the connection of the client.
+func (c *Client) ShowEnvironment() (Environment, error) {
+	return c.ShowEnvironmentContext(context.Background())
+}
+
+// ShowEnvironmentContext is ShowEnvironment with a context that cancels the command.
+func (c *Client) ShowEnvironmentContext(ctx context.Context) (Environment, error) {
+	switch_hostname := c.SwitchHostname
+	command := "show env all"
+	if c.platform() == PlatformNXOS {
+		command = "show environment"
+	}
+	outputString, err := c.RunCommandContext(ctx, command)
+	if err != nil {
+		return Environment{}, err
+	}
+
+	parseStart := time.Now()
+	environment, err := parseWith(c.platform(), command, outputString, ParseEnvironment)
+	records := len(environment.Fans) + len(environment.PowerSupplies) + len(environment.Temperatures)
+	err = reportParse(switch_hostname, command, outputString, records, parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Environment :: Error during parsing: %v", switch_hostname, err)
+		return Environment{}, fmt.Errorf("error during parsing '%s' output for %s: %w", command, switch_hostname, err)
+	}
+
+	return environment, nil
+}
+
+// ParseEnvironment processes the raw CLI output from "show env all" on IOS and IOS-XE
+// or "show environment" on NX-OS. On IOS, each temperature reading is followed by its
+// state and thresholds, and the sensors are named after the stack member they are in.
+func ParseEnvironment(rawOutput string) (Environment, error) {
+	var environment Environment
+	member := ""
+
+	// lastTemperature returns the sensor the state and threshold lines belong to.
+	lastTemperature := func() *EnvironmentTemperature {
+		if len(environment.Temperatures) == 0 {
+			return nil
+		}
+		return &environment.Temperatures[len(environment.Temperatures)-1]
+	}
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if matches := reEnvFan.FindStringSubmatch(line); matches != nil {
+			environment.Fans = append(environment.Fans, EnvironmentFan{Name: matches[1], Status: matches[2]})
+		} else if matches := reEnvFanSpeed.FindStringSubmatch(line); matches != nil {
+			name := fmt.Sprintf("Switch %s FAN %s", matches[1], matches[2])
+			for i := range environment.Fans {
+				if environment.Fans[i].Name == name {
+					environment.Fans[i].Speed, _ = strconv.Atoi(matches[3])
+				}
+			}
+		} else if matches := reEnvSwitch.FindStringSubmatch(line); matches != nil {
+			member = "Switch " + matches[1]
+		} else if matches := reEnvTemperature.FindStringSubmatch(line); matches != nil {
+			sensor := strings.TrimSpace(matches[1])
+			if sensor == "" {
+				sensor = "System"
+			}
+			temperature := EnvironmentTemperature{Name: strings.TrimSpace(member + " " + sensor)}
+			temperature.Celsius, _ = strconv.Atoi(matches[2])
+			environment.Temperatures = append(environment.Temperatures, temperature)
+		} else if matches := reEnvState.FindStringSubmatch(line); matches != nil {
+			if temperature := lastTemperature(); temperature != nil {
+				temperature.Status = matches[1]
+			}
+		} else if matches := reEnvThreshold.FindStringSubmatch(line); matches != nil {
+			if temperature := lastTemperature(); temperature != nil {
+				value, _ := strconv.Atoi(matches[2])
+				if matches[1] == "Yellow" {
+					temperature.Warning = value
+				} else {
+					temperature.Critical = value
+				}
+			}
+		} else if matches := reEnvPowerSupply.FindStringSubmatch(line); matches != nil {
+			watts, _ := strconv.Atoi(matches[4])
+			environment.PowerSupplies = append(environment.PowerSupplies, EnvironmentPowerSupply{Name: matches[1], Model: matches[2], Status: matches[3], Watts: watts})
+		} else if matches := reEnvNoSupply.FindStringSubmatch(line); matches != nil {
+			environment.PowerSupplies = append(environment.PowerSupplies, EnvironmentPowerSupply{Name: matches[1], Status: matches[2]})
+		} else if matches := reEnvPowerNxos.FindStringSubmatch(line); matches != nil {
+			supply := EnvironmentPowerSupply{Name: matches[1], Model: matches[2], Status: matches[5]}
+			supply.OutputWatts, _ = strconv.Atoi(matches[3])
+			supply.Watts, _ = strconv.Atoi(matches[4])
+			environment.PowerSupplies = append(environment.PowerSupplies, supply)
+		} else if matches := reEnvFanNxos.FindStringSubmatch(line); matches != nil {
+			environment.Fans = append(environment.Fans, EnvironmentFan{Name: matches[1], Status: matches[2]})
+		} else if matches := reEnvTempNxos.FindStringSubmatch(line); matches != nil {
+			temperature := EnvironmentTemperature{Name: fmt.Sprintf("Module %s %s", matches[1], matches[2]), Status: matches[6]}
+			temperature.Critical, _ = strconv.Atoi(matches[3])
+			temperature.Warning, _ = strconv.Atoi(matches[4])
+			temperature.Celsius, _ = strconv.Atoi(matches[5])
+			environment.Temperatures = append(environment.Temperatures, temperature)
+		}
+	}
+
+	if len(environment.Fans)+len(environment.PowerSupplies)+len(environment.Temperatures) == 0 {
+		return Environment{}, fmt.Errorf("could not find any fan, power supply or temperature in output")
+	}
+
+	return environment, nil
+}
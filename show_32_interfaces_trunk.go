@@ -0,0 +1,200 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrunkInterface is one trunk port of "show interfaces trunk", with its VLAN lists
+// expanded into IDs, e.g. "10-12,20" into 10, 11, 12 and 20.
+type TrunkInterface struct {
+	Interface       string
+	Mode            string // How trunking was negotiated, e.g. on, desirable or auto; empty on NX-OS
+	Encapsulation   string // e.g. 802.1q or n-802.1q when negotiated; empty on NX-OS
+	Status          string // e.g. trunking, or trnk-bndl for a member of a port channel on NX-OS
+	NativeVlan      int
+	AllowedVlans    []int
+	ActiveVlans     []int // Allowed and active in the management domain; nil on NX-OS
+	ForwardingVlans []int // Forwarding in spanning tree and not pruned
+}
+
+// Sections of "show interfaces trunk", each introduced by a header starting with "Port".
+// The VLAN list sections index the lists of a port.
+const (
+	trunkSectionNone = iota - 1
+	trunkSectionAllowed
+	trunkSectionActive
+	trunkSectionForwarding
+	trunkSectionNotPruned
+	trunkSectionSummaryIOS
+	trunkSectionSummaryNxos
+)
+
+// Show_interfaces_trunk connects to a switch and returns its trunk ports, from "show
+// interfaces trunk", or "show interface trunk" on NX-OS.
+func Show_interfaces_trunk(switch_hostname string) ([]TrunkInterface, error) {
+	return Show_interfaces_trunk_context(context.Background(), switch_hostname)
+}
+
+// Show_interfaces_trunk_context is Show_interfaces_trunk with a context that cancels the command.
+func Show_interfaces_trunk_context(ctx context.Context, switch_hostname string) ([]TrunkInterface, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowInterfacesTrunkContext(ctx)
+}
+
+// ShowInterfacesTrunk is Show_interfaces_trunk over the connection of the client.
+func (c *Client) ShowInterfacesTrunk() ([]TrunkInterface, error) {
+	return c.ShowInterfacesTrunkContext(context.Background())
+}
+
+// ShowInterfacesTrunkContext is ShowInterfacesTrunk with a context that cancels the command.
+func (c *Client) ShowInterfacesTrunkContext(ctx context.Context) ([]TrunkInterface, error) {
+	switch_hostname := c.SwitchHostname
+	command := "show interfaces trunk"
+	if c.platform() == PlatformNXOS {
+		command = "show interface trunk"
+	}
+	outputString, err := c.RunCommandContext(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	trunks, err := parseWith(c.platform(), command, outputString, ParseInterfacesTrunk)
+	err = reportParse(switch_hostname, command, outputString, len(trunks), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Interfaces Trunk :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing '%s' output for %s: %w", command, switch_hostname, err)
+	}
+
+	for i := range trunks {
+		trunks[i].Interface = normalizeInterfaceName(trunks[i].Interface)
+	}
+
+	return trunks, nil
+}
+
+// ParseInterfacesTrunk processes the raw CLI output from "show interfaces trunk" on IOS
+// and IOS-XE or "show interface trunk" on NX-OS. The output is a summary table followed
+// by one table per VLAN list; long lists wrap onto indented lines, which are joined
+// before the list is expanded. A switch without trunks prints nothing, which yields no
+// entries. Rows that are messages rather than lists, such as "Feature VTP is not
+// enabled" on NX-OS, are skipped.
+func ParseInterfacesTrunk(rawOutput string) ([]TrunkInterface, error) {
+	var trunks []TrunkInterface
+	lists := map[string]*[3]string{} // The allowed, active and forwarding lists of each port, as printed
+	section := trunkSectionNone
+	var last *string // The list an indented line continues
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "Port") {
+			section = trunkSectionOf(line)
+			last = nil
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "---") {
+			last = nil
+			continue
+		}
+		if trimmed != line {
+			if last != nil {
+				*last = joinVlanList(*last, trimmed)
+			}
+			continue // Otherwise the second line of a header
+		}
+
+		fields := strings.Fields(line)
+		switch section {
+		case trunkSectionSummaryIOS:
+			if len(fields) >= 5 {
+				trunk := TrunkInterface{Interface: fields[0], Mode: fields[1], Encapsulation: fields[2], Status: fields[3]}
+				trunk.NativeVlan, _ = strconv.Atoi(fields[4])
+				trunks = append(trunks, trunk)
+			}
+		case trunkSectionSummaryNxos:
+			if len(fields) >= 3 {
+				trunk := TrunkInterface{Interface: fields[0], Status: fields[2]}
+				trunk.NativeVlan, _ = strconv.Atoi(fields[1])
+				trunks = append(trunks, trunk)
+			}
+		case trunkSectionAllowed, trunkSectionActive, trunkSectionForwarding, trunkSectionNotPruned:
+			value := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+			if len(fields) < 2 || (value != "none" && (value[0] < '0' || value[0] > '9')) {
+				continue
+			}
+			if lists[fields[0]] == nil {
+				lists[fields[0]] = &[3]string{}
+			}
+			index := section
+			if section == trunkSectionNotPruned {
+				// NX-OS prints the forwarding VLANs in an "STP Forwarding" table first
+				index = trunkSectionForwarding
+				if lists[fields[0]][index] != "" {
+					continue
+				}
+			}
+			lists[fields[0]][index] = value
+			last = &lists[fields[0]][index]
+		}
+	}
+
+	for i := range trunks {
+		portLists := lists[trunks[i].Interface]
+		if portLists == nil {
+			continue
+		}
+		for index, vlans := range []*[]int{&trunks[i].AllowedVlans, &trunks[i].ActiveVlans, &trunks[i].ForwardingVlans} {
+			if portLists[index] == "" || portLists[index] == "none" {
+				continue
+			}
+			expanded, err := parseVlanList(portLists[index])
+			if err != nil {
+				return nil, fmt.Errorf("trunk %s: %w", trunks[i].Interface, err)
+			}
+			*vlans = expanded
+		}
+	}
+
+	return trunks, nil
+}
+
+// trunkSectionOf returns the section of "show interfaces trunk" a header starts.
+func trunkSectionOf(header string) int {
+	header = strings.ToLower(header)
+	switch {
+	case strings.Contains(header, "encapsulation"):
+		return trunkSectionSummaryIOS
+	case strings.Contains(header, "native"):
+		return trunkSectionSummaryNxos
+	case strings.Contains(header, "allowed and active"):
+		return trunkSectionActive
+	case strings.Contains(header, "allowed on trunk"):
+		return trunkSectionAllowed
+	case strings.Contains(header, "stp forwarding"):
+		return trunkSectionForwarding
+	case strings.Contains(header, "not pruned"):
+		return trunkSectionNotPruned
+	}
+	return trunkSectionNone // e.g. "Vlans Err-disabled on Trunk" on NX-OS
+}
+
+// joinVlanList appends the wrapped rest of a VLAN list to its first line, which IOS
+// breaks either before or after a comma.
+func joinVlanList(list string, rest string) string {
+	if strings.HasSuffix(list, ",") || strings.HasPrefix(rest, ",") {
+		return list + rest
+	}
+	return list + "," + rest
+}
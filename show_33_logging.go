@@ -0,0 +1,213 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogSeverity is the syslog severity of a message, from LogEmergency (0) to
+// LogDebugging (7). Lower values are more severe.
+type LogSeverity int
+
+// Syslog severities, named as IOS names them in "logging buffered <level>".
+const (
+	LogEmergency LogSeverity = iota
+	LogAlert
+	LogCritical
+	LogError
+	LogWarning
+	LogNotice
+	LogInformational
+	LogDebugging
+)
+
+var logSeverityNames = []string{"emergencies", "alerts", "critical", "errors", "warnings", "notifications", "informational", "debugging"}
+
+// String returns the name IOS uses for the severity, e.g. "warnings".
+func (s LogSeverity) String() string {
+	if s < 0 || int(s) >= len(logSeverityNames) {
+		return "severity " + strconv.Itoa(int(s))
+	}
+	return logSeverityNames[s]
+}
+
+// LogEntry is one message of the logging buffer, e.g.
+// "%LINK-3-UPDOWN: Interface GigabitEthernet1/0/5, changed state to down".
+type LogEntry struct {
+	Sequence  int       // With "service sequence-numbers"; zero otherwise
+	Timestamp time.Time // Zero when the switch logs uptime instead of the date
+	Facility  string    // e.g. LINK
+	Severity  LogSeverity
+	Mnemonic  string // e.g. UPDOWN
+	Message   string
+}
+
+// Regexes used by the logging buffer parser.
+var (
+	// The optional sequence number, the timestamp, which is prefixed with "*" or "." when
+	// the clock is not synchronized, and the message: IOS
+	// "000123: *Oct 16 10:02:03.123 EST: %LINK-3-UPDOWN: Interface ...", NX-OS
+	// "2024 Oct 16 10:02:03 nxos01 %ETHPORT-5-IF_UP: Interface ..." and IOS-XR
+	// "RP/0/RSP0/CPU0:Oct 16 10:02:03.123 UTC: ifmgr[257]: %PKT_INFRA-LINK-3-UPDOWN : Interface ..."
+	reLogEntry = regexp.MustCompile(`^(?:(\d+):\s+)?[*.]?(.*?):?\s*%([A-Z][A-Z0-9_-]*?)-([0-7])-([A-Z0-9_]+)\s*:\s*(.*)$`)
+
+	// reLogTime matches "Oct 16 10:02:03.123 EST", "Oct 16 2024 10:02:03" and NX-OS
+	// "2024 Oct 16 10:02:03"; uptime stamps such as "1w2d" do not match.
+	reLogTime = regexp.MustCompile(`(?:(\d{4}) )?([A-Z][a-z]{2})\s+(\d{1,2})(?: (\d{4}))?\s+(\d{1,2}:\d{2}:\d{2}(?:\.\d+)?)(?:\s+([A-Z]{2,5})(?::|$))?`)
+)
+
+// Show_logging connects to a switch and returns the messages of its logging buffer,
+// oldest first, from "show logging", or "show logging logfile" on NX-OS.
+func Show_logging(switch_hostname string) ([]LogEntry, error) {
+	return Show_logging_context(context.Background(), switch_hostname)
+}
+
+// Show_logging_context is Show_logging with a context that cancels the command.
+func Show_logging_context(ctx context.Context, switch_hostname string) ([]LogEntry, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowLoggingContext(ctx)
+}
+
+// Show_logging_severity is Show_logging keeping only the messages at or above
+// severity, e.g. LogWarning for warnings, errors and worse.
+func Show_logging_severity(switch_hostname string, severity LogSeverity) ([]LogEntry, error) {
+	return Show_logging_severity_context(context.Background(), switch_hostname, severity)
+}
+
+// Show_logging_severity_context is Show_logging_severity with a context that cancels the command.
+func Show_logging_severity_context(ctx context.Context, switch_hostname string, severity LogSeverity) ([]LogEntry, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowLoggingSeverityContext(ctx, severity)
+}
+
+// ShowLogging is Show_logging over the connection of the client.
+func (c *Client) ShowLogging() ([]LogEntry, error) {
+	return c.ShowLoggingContext(context.Background())
+}
+
+// ShowLoggingContext is ShowLogging with a context that cancels the command.
+func (c *Client) ShowLoggingContext(ctx context.Context) ([]LogEntry, error) {
+	switch_hostname := c.SwitchHostname
+	command := "show logging"
+	if c.platform() == PlatformNXOS {
+		command = "show logging logfile"
+	}
+	outputString, err := c.RunCommandContext(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	entries, err := parseWith(c.platform(), command, outputString, ParseLogging)
+	err = reportParse(switch_hostname, command, outputString, len(entries), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Logging :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing '%s' output for %s: %w", command, switch_hostname, err)
+	}
+
+	return entries, nil
+}
+
+// ShowLoggingSeverity is Show_logging_severity over the connection of the client.
+func (c *Client) ShowLoggingSeverity(severity LogSeverity) ([]LogEntry, error) {
+	return c.ShowLoggingSeverityContext(context.Background(), severity)
+}
+
+// ShowLoggingSeverityContext is ShowLoggingSeverity with a context that cancels the command.
+func (c *Client) ShowLoggingSeverityContext(ctx context.Context, severity LogSeverity) ([]LogEntry, error) {
+	entries, err := c.ShowLoggingContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FilterLogSeverity(entries, severity), nil
+}
+
+// FilterLogSeverity returns the entries at or above severity, that is with a severity
+// value of at most severity.
+func FilterLogSeverity(entries []LogEntry, severity LogSeverity) []LogEntry {
+	var filtered []LogEntry
+	for _, entry := range entries {
+		if entry.Severity <= severity {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// ParseLogging processes the raw CLI output from "show logging" on IOS, IOS-XE, IOS-XR
+// and ASA, or "show logging logfile" on NX-OS, with or without sequence numbers. The
+// settings printed before the buffer are skipped. Timestamps without a year are placed
+// in the last twelve months, and the timezone is read like ParseClock reads it; stamps
+// without one, or with one it does not know, are taken as UTC.
+func ParseLogging(rawOutput string) ([]LogEntry, error) {
+	var entries []LogEntry
+	now := time.Now()
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		matches := reLogEntry.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		entry := LogEntry{Facility: matches[3], Mnemonic: matches[5], Message: strings.TrimSpace(matches[6])}
+		entry.Sequence, _ = strconv.Atoi(matches[1])
+		severity, _ := strconv.Atoi(matches[4])
+		entry.Severity = LogSeverity(severity)
+		entry.Timestamp = parseLogTime(matches[2], now)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseLogTime returns the time of the timestamp of a log message, or the zero time if
+// it is an uptime or missing.
+func parseLogTime(stamp string, now time.Time) time.Time {
+	matches := reLogTime.FindStringSubmatch(stamp)
+	if matches == nil {
+		return time.Time{}
+	}
+
+	year := matches[1]
+	if year == "" {
+		year = matches[4]
+	}
+	inferYear := year == ""
+	if inferYear {
+		year = strconv.Itoa(now.Year())
+	}
+
+	t, err := time.Parse("Jan 2 2006 15:04:05", fmt.Sprintf("%s %s %s %s", matches[2], matches[3], year, matches[5]))
+	if err != nil {
+		return time.Time{}
+	}
+
+	location := time.UTC
+	if offset, known := clockZoneOffsets[strings.ToUpper(matches[6])]; known {
+		location = time.FixedZone(matches[6], offset)
+	}
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
+
+	// A December message read in January is from last year.
+	if inferYear && t.After(now.Add(24*time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t
+}
@@ -0,0 +1,141 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OSPFNeighbor is one adjacency of "show ip ospf neighbor".
+type OSPFNeighbor struct {
+	NeighborID string // The router ID of the neighbor
+	Priority   int
+	State      string // e.g. FULL/DR, FULL/BDR, 2WAY/DROTHER or FULL/- on point-to-point links
+	DeadTime   string // Time left before the adjacency is dropped, e.g. 00:00:35; empty on NX-OS
+	UpTime     string // Time since the adjacency came up, e.g. 4w2d; NX-OS and IOS-XR only
+	Address    string // The address of the neighbor on the link
+	Interface  string
+}
+
+// reOspfStateSpace matches the padding IOS puts in the state of neighbors without a DR
+// role, e.g. "FULL/  -", so that every row splits into the same number of words.
+var reOspfStateSpace = regexp.MustCompile(`/\s+`)
+
+// Show_ip_ospf_neighbor connects to a switch, runs "show ip ospf neighbor", and
+// returns its OSPF adjacencies.
+func Show_ip_ospf_neighbor(switch_hostname string) ([]OSPFNeighbor, error) {
+	return Show_ip_ospf_neighbor_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_ospf_neighbor_context is Show_ip_ospf_neighbor with a context that cancels the command.
+func Show_ip_ospf_neighbor_context(ctx context.Context, switch_hostname string) ([]OSPFNeighbor, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowIpOspfNeighborContext(ctx)
+}
+
+// ShowIpOspfNeighbor is Show_ip_ospf_neighbor over the connection of the client.
+func (c *Client) ShowIpOspfNeighbor() ([]OSPFNeighbor, error) {
+	return c.ShowIpOspfNeighborContext(context.Background())
+}
+
+// ShowIpOspfNeighborContext is ShowIpOspfNeighbor with a context that cancels the command.
+func (c *Client) ShowIpOspfNeighborContext(ctx context.Context) ([]OSPFNeighbor, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip ospf neighbor")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	neighbors, err := parseWith(c.platform(), "show ip ospf neighbor", outputString, ParseOSPFNeighbors)
+	err = reportParse(switch_hostname, "show ip ospf neighbor", outputString, len(neighbors), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show IP OSPF Neighbor :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show ip ospf neighbor' output for %s: %w", switch_hostname, err)
+	}
+
+	for i := range neighbors {
+		neighbors[i].Interface = normalizeInterfaceName(neighbors[i].Interface)
+	}
+
+	return neighbors, nil
+}
+
+// ParseOSPFNeighbors processes the raw CLI output from "show ip ospf neighbor" on IOS,
+// IOS-XE, NX-OS and IOS-XR. The platforms order the time columns differently, and
+// NX-OS prints the up time instead of the dead time, so the times of each row are
+// assigned in the order of the header. IOS prints nothing without neighbors, which
+// yields none.
+func ParseOSPFNeighbors(rawOutput string) ([]OSPFNeighbor, error) {
+	var neighbors []OSPFNeighbor
+	var timeColumns []string // "Dead Time" and "Up Time" in the order of the header
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "Neighbor ID") {
+			timeColumns = ospfTimeColumns(trimmed)
+			continue
+		}
+
+		fields := strings.Fields(reOspfStateSpace.ReplaceAllString(trimmed, "/"))
+		if timeColumns == nil || len(fields) < 5 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		priority, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+
+		neighbor := OSPFNeighbor{NeighborID: fields[0], Priority: priority, State: fields[2], Interface: fields[len(fields)-1]}
+		var times []string
+		for _, field := range fields[3 : len(fields)-1] {
+			if neighbor.Address == "" && net.ParseIP(field) != nil {
+				neighbor.Address = field
+			} else {
+				times = append(times, field)
+			}
+		}
+		for i, value := range times {
+			if i >= len(timeColumns) {
+				break
+			}
+			if timeColumns[i] == "Dead Time" {
+				neighbor.DeadTime = value
+			} else {
+				neighbor.UpTime = value
+			}
+		}
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors, nil
+}
+
+// ospfTimeColumns returns the time columns of a "show ip ospf neighbor" header in the
+// order they appear in.
+func ospfTimeColumns(header string) []string {
+	columns := []string{}
+	for _, name := range []string{"Dead Time", "Up Time"} {
+		if strings.Contains(header, name) {
+			columns = append(columns, name)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return strings.Index(header, columns[i]) < strings.Index(header, columns[j])
+	})
+	return columns
+}
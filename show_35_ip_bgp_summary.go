@@ -0,0 +1,160 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BGPNeighbor is one peer of "show ip bgp summary".
+type BGPNeighbor struct {
+	Neighbor         string
+	Version          int    // The BGP version, the speaker process (Spk) on IOS-XR
+	AS               uint32 // Read from asplain or asdot, e.g. 4200000001 or 64086.59905
+	MsgRcvd          int
+	MsgSent          int
+	InQ              int
+	OutQ             int
+	UpDown           string // How long the session has been up, or down when not established, e.g. 1w2d, 12:01:33 or never
+	State            string // Established, or the state printed instead of the prefix count, e.g. Idle, Active or Idle (Admin)
+	PrefixesReceived int    // Zero unless Established
+}
+
+// Show_ip_bgp_summary connects to a switch, runs "show ip bgp summary", and returns
+// its BGP peers.
+func Show_ip_bgp_summary(switch_hostname string) ([]BGPNeighbor, error) {
+	return Show_ip_bgp_summary_context(context.Background(), switch_hostname)
+}
+
+// Show_ip_bgp_summary_context is Show_ip_bgp_summary with a context that cancels the command.
+func Show_ip_bgp_summary_context(ctx context.Context, switch_hostname string) ([]BGPNeighbor, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowIpBgpSummaryContext(ctx)
+}
+
+// ShowIpBgpSummary is Show_ip_bgp_summary over the connection of the client.
+func (c *Client) ShowIpBgpSummary() ([]BGPNeighbor, error) {
+	return c.ShowIpBgpSummaryContext(context.Background())
+}
+
+// ShowIpBgpSummaryContext is ShowIpBgpSummary with a context that cancels the command.
+func (c *Client) ShowIpBgpSummaryContext(ctx context.Context) ([]BGPNeighbor, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show ip bgp summary")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	neighbors, err := parseWith(c.platform(), "show ip bgp summary", outputString, ParseBGPSummary)
+	err = reportParse(switch_hostname, "show ip bgp summary", outputString, len(neighbors), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show IP BGP Summary :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show ip bgp summary' output for %s: %w", switch_hostname, err)
+	}
+
+	return neighbors, nil
+}
+
+// ParseBGPSummary processes the raw CLI output from "show ip bgp summary" on IOS,
+// IOS-XE, NX-OS and IOS-XR. A neighbor address too long for its column, as IPv6
+// addresses are, is printed alone with the rest of the row on the next line. A switch
+// without BGP running prints "% BGP not active", which yields no neighbors.
+func ParseBGPSummary(rawOutput string) ([]BGPNeighbor, error) {
+	var neighbors []BGPNeighbor
+	found := false
+	inTable := false
+	pending := "" // A neighbor whose row continues on the next line
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+		fields := strings.Fields(line)
+
+		switch {
+		case strings.Contains(line, "BGP router identifier"), strings.Contains(line, "BGP not active"):
+			found = true
+			continue
+		case len(fields) > 0 && fields[0] == "Neighbor":
+			inTable = true
+			continue
+		case !inTable || len(fields) == 0:
+			continue
+		}
+
+		if len(fields) == 1 && net.ParseIP(fields[0]) != nil {
+			pending = fields[0]
+			continue
+		}
+		if pending != "" && strings.TrimSpace(line) != line {
+			fields = append([]string{pending}, fields...)
+		}
+		pending = ""
+
+		neighbor, ok := parseBGPNeighbor(fields)
+		if ok {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("could not find BGP router identifier in output")
+	}
+
+	return neighbors, nil
+}
+
+// parseBGPNeighbor returns the neighbor of the words of a row of the summary table:
+// Neighbor, V, AS, MsgRcvd, MsgSent, TblVer, InQ, OutQ, Up/Down and State/PfxRcd, the
+// last of which may be several words.
+func parseBGPNeighbor(fields []string) (BGPNeighbor, bool) {
+	if len(fields) < 10 || net.ParseIP(fields[0]) == nil {
+		return BGPNeighbor{}, false
+	}
+	as, err := parseASN(fields[2])
+	if err != nil {
+		return BGPNeighbor{}, false
+	}
+
+	neighbor := BGPNeighbor{Neighbor: fields[0], AS: as, UpDown: fields[8]}
+	neighbor.Version, _ = strconv.Atoi(fields[1])
+	neighbor.MsgRcvd, _ = strconv.Atoi(fields[3])
+	neighbor.MsgSent, _ = strconv.Atoi(fields[4])
+	neighbor.InQ, _ = strconv.Atoi(fields[6])
+	neighbor.OutQ, _ = strconv.Atoi(fields[7])
+
+	state := strings.Join(fields[9:], " ")
+	if prefixes, err := strconv.Atoi(state); err == nil {
+		neighbor.State = "Established"
+		neighbor.PrefixesReceived = prefixes
+	} else {
+		neighbor.State = state
+	}
+	return neighbor, true
+}
+
+// parseASN reads an AS number in asplain, e.g. 4200000001, or asdot, e.g. 64086.59905.
+func parseASN(value string) (uint32, error) {
+	high, low, dotted := strings.Cut(value, ".")
+	if !dotted {
+		as, err := strconv.ParseUint(value, 10, 32)
+		return uint32(as), err
+	}
+	h, err := strconv.ParseUint(high, 10, 16)
+	if err != nil {
+		return 0, err
+	}
+	l, err := strconv.ParseUint(low, 10, 16)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(h<<16 | l), nil
+}
@@ -0,0 +1,114 @@
+package cisco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StackSwitch is one member of a Catalyst stack in "show switch".
+type StackSwitch struct {
+	Switch     string // The member number, as in StackMember.Switch
+	Local      bool   // Marked with "*" (the switch the session is connected to)
+	Role       string // Active, Standby or Member on IOS-XE; Master or Member on IOS
+	MacAddress string // 0000.0000.0000 for provisioned members that are not present
+	Priority   int
+	HWVersion  string
+	State      string // e.g. Ready, Provisioned, Version Mismatch, Progressing or Removed
+}
+
+// Ready reports whether the member is up and part of the stack. Members stuck in
+// "Version Mismatch", "Provisioned" or any other state are not.
+func (s StackSwitch) Ready() bool {
+	return strings.EqualFold(s.State, "Ready")
+}
+
+// reStackSwitch matches a row of the "show switch" table, e.g.
+// "*1       Active   00a1.b2c3.d400     15     V02     Ready" or
+// " 3       Member   00a1.b2c3.f600     1      V01     Version Mismatch".
+var reStackSwitch = regexp.MustCompile(`^\s*(\*?)\s*(\d+)\s+(\S+)\s+([0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4})\s+(\d+)\s+(\S+)\s+(.+?)\s*$`)
+
+// Show_switch connects to a stacked Catalyst, runs "show switch", and returns its
+// members with their role and state.
+func Show_switch(switch_hostname string) ([]StackSwitch, error) {
+	return Show_switch_context(context.Background(), switch_hostname)
+}
+
+// Show_switch_context is Show_switch with a context that cancels the command.
+func Show_switch_context(ctx context.Context, switch_hostname string) ([]StackSwitch, error) {
+	client, err := connectToSwitchContext(ctx, switch_hostname)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return client.ShowSwitchContext(ctx)
+}
+
+// ShowSwitch is Show_switch over the connection of the client.
+func (c *Client) ShowSwitch() ([]StackSwitch, error) {
+	return c.ShowSwitchContext(context.Background())
+}
+
+// ShowSwitchContext is ShowSwitch with a context that cancels the command.
+func (c *Client) ShowSwitchContext(ctx context.Context) ([]StackSwitch, error) {
+	switch_hostname := c.SwitchHostname
+	outputString, err := c.RunCommandContext(ctx, "show switch")
+	if err != nil {
+		return nil, err
+	}
+
+	parseStart := time.Now()
+	members, err := parseWith(c.platform(), "show switch", outputString, ParseSwitch)
+	err = reportParse(switch_hostname, "show switch", outputString, len(members), parseStart, err)
+	if err != nil {
+		c.logf(slog.LevelError, "%s :: Show Switch :: Error during parsing: %v", switch_hostname, err)
+		return nil, fmt.Errorf("error during parsing 'show switch' output for %s: %w", switch_hostname, err)
+	}
+
+	return members, nil
+}
+
+// ParseSwitch processes the raw CLI output from "show switch" on IOS and IOS-XE. A
+// switch that is not stacked prints a table with itself alone.
+func ParseSwitch(rawOutput string) ([]StackSwitch, error) {
+	var members []StackSwitch
+	found := false
+
+	for _, line := range strings.Split(rawOutput, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if strings.HasPrefix(strings.TrimSpace(line), "Switch#") {
+			found = true
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		matches := reStackSwitch.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		member := StackSwitch{
+			Switch:     matches[2],
+			Local:      matches[1] == "*",
+			Role:       matches[3],
+			MacAddress: matches[4],
+			HWVersion:  matches[6],
+			State:      matches[7],
+		}
+		member.Priority, _ = strconv.Atoi(matches[5])
+		members = append(members, member)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("could not find switch stack header in output")
+	}
+
+	return members, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+// schema creates one table per kind of collected data. Every row is keyed by switch
+// and collection time, so each snapshot is kept until Prune removes it. The statements
+// are valid for both SQLite and Postgres.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS snapshots (
+		switch         TEXT NOT NULL,
+		collected_at   TIMESTAMP NOT NULL,
+		parser_version TEXT NOT NULL,
+		PRIMARY KEY (switch, collected_at)
+	)`,
+	`CREATE TABLE IF NOT EXISTS interfaces (
+		switch       TEXT NOT NULL,
+		collected_at TIMESTAMP NOT NULL,
+		interface    TEXT NOT NULL,
+		description  TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		vlan         TEXT NOT NULL,
+		duplex       TEXT NOT NULL,
+		speed        TEXT NOT NULL,
+		type         TEXT NOT NULL,
+		PRIMARY KEY (switch, collected_at, interface)
+	)`,
+	`CREATE TABLE IF NOT EXISTS neighbors (
+		switch             TEXT NOT NULL,
+		collected_at       TIMESTAMP NOT NULL,
+		protocol           TEXT NOT NULL,
+		interface          TEXT NOT NULL,
+		neighbor           TEXT NOT NULL,
+		neighbor_interface TEXT NOT NULL,
+		platform           TEXT NOT NULL,
+		capability         TEXT NOT NULL,
+		PRIMARY KEY (switch, collected_at, protocol, interface, neighbor)
+	)`,
+	`CREATE TABLE IF NOT EXISTS mac_entries (
+		switch       TEXT NOT NULL,
+		collected_at TIMESTAMP NOT NULL,
+		vlan         TEXT NOT NULL,
+		mac_address  TEXT NOT NULL,
+		interface    TEXT NOT NULL,
+		type         TEXT NOT NULL,
+		PRIMARY KEY (switch, collected_at, vlan, mac_address)
+	)`,
+	`CREATE TABLE IF NOT EXISTS interface_configs (
+		switch       TEXT NOT NULL,
+		collected_at TIMESTAMP NOT NULL,
+		interface    TEXT NOT NULL,
+		config       TEXT NOT NULL,
+		PRIMARY KEY (switch, collected_at, interface)
+	)`,
+	`CREATE INDEX IF NOT EXISTS mac_entries_mac_address ON mac_entries (mac_address)`,
+}
+
+// tables lists the tables in the order rows are pruned.
+var tables = []string{"interfaces", "neighbors", "mac_entries", "interface_configs", "snapshots"}
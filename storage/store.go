@@ -0,0 +1,171 @@
+// Package storage persists snapshots collected with the cisco package into SQLite or
+// Postgres through database/sql. It does not import a driver: open the database with
+// the driver of your choice and pass the *sql.DB to New.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xtokio/cisco"
+)
+
+// Dialect selects the SQL placeholder syntax of the database.
+type Dialect int
+
+const (
+	SQLite   Dialect = iota // "?" placeholders
+	Postgres                // "$1" placeholders
+)
+
+// Store writes snapshots to a database.
+type Store struct {
+	DB      *sql.DB
+	Dialect Dialect
+}
+
+// New returns a Store for db. Call CreateSchema once before saving.
+func New(db *sql.DB, dialect Dialect) *Store {
+	return &Store{DB: db, Dialect: dialect}
+}
+
+// CreateSchema creates the tables if they don't exist yet.
+func (s *Store) CreateSchema(ctx context.Context) error {
+	for _, statement := range schema {
+		if _, err := s.DB.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("creating schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot writes a snapshot's interface status, CDP/LLDP neighbors, MAC entries
+// and interface configuration in one transaction. Rows are upserted, so saving the
+// same snapshot again updates it in place instead of failing.
+func (s *Store) SaveSnapshot(ctx context.Context, snapshot cisco.DeviceSnapshot) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("saving snapshot of %s: %w", snapshot.SwitchHostname, err)
+	}
+	defer tx.Rollback()
+
+	switch_hostname, collectedAt := snapshot.SwitchHostname, snapshot.CollectedAt.UTC()
+
+	exec := func(statement string, args ...any) error {
+		_, err := tx.ExecContext(ctx, s.rebind(statement), args...)
+		return err
+	}
+
+	err = exec(`INSERT INTO snapshots (switch, collected_at, parser_version) VALUES (?, ?, ?)
+		ON CONFLICT (switch, collected_at) DO UPDATE SET parser_version = excluded.parser_version`,
+		switch_hostname, collectedAt, snapshot.ParserVersion)
+
+	for _, status := range snapshot.InterfacesStatus {
+		if err != nil {
+			break
+		}
+		err = exec(`INSERT INTO interfaces (switch, collected_at, interface, description, status, vlan, duplex, speed, type)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (switch, collected_at, interface) DO UPDATE SET description = excluded.description,
+			status = excluded.status, vlan = excluded.vlan, duplex = excluded.duplex, speed = excluded.speed, type = excluded.type`,
+			switch_hostname, collectedAt, status.Interface, status.Description, status.Status, status.VlanID, status.Duplex, status.Speed, status.Type)
+	}
+
+	saveNeighbor := func(protocol string, localInterface string, neighbor string, neighborInterface string, platform string, capability string) error {
+		return exec(`INSERT INTO neighbors (switch, collected_at, protocol, interface, neighbor, neighbor_interface, platform, capability)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (switch, collected_at, protocol, interface, neighbor) DO UPDATE SET
+			neighbor_interface = excluded.neighbor_interface, platform = excluded.platform, capability = excluded.capability`,
+			switch_hostname, collectedAt, protocol, localInterface, neighbor, neighborInterface, platform, capability)
+	}
+	for _, n := range snapshot.CdpNeighbors {
+		if err != nil {
+			break
+		}
+		err = saveNeighbor("cdp", n.Interface, n.Neighbor, n.NeighborInterface, n.Platform, n.Capability)
+	}
+	for _, n := range snapshot.LldpNeighbors {
+		if err != nil {
+			break
+		}
+		err = saveNeighbor("lldp", n.Interface, n.Neighbor, n.NeighborInterface, "", n.Capability)
+	}
+
+	for _, entry := range snapshot.MacAddressTable {
+		if err != nil {
+			break
+		}
+		err = exec(`INSERT INTO mac_entries (switch, collected_at, vlan, mac_address, interface, type)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (switch, collected_at, vlan, mac_address) DO UPDATE SET interface = excluded.interface, type = excluded.type`,
+			switch_hostname, collectedAt, entry.VlanID, entry.MacAddress, entry.Interface, entry.Type)
+	}
+
+	for _, config := range snapshot.RunningConfig {
+		if err != nil {
+			break
+		}
+		err = exec(`INSERT INTO interface_configs (switch, collected_at, interface, config) VALUES (?, ?, ?, ?)
+			ON CONFLICT (switch, collected_at, interface) DO UPDATE SET config = excluded.config`,
+			switch_hostname, collectedAt, config.Interface, strings.Join(config.ConfigLines, "\n"))
+	}
+
+	if err != nil {
+		return fmt.Errorf("saving snapshot of %s: %w", switch_hostname, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("saving snapshot of %s: %w", switch_hostname, err)
+	}
+	return nil
+}
+
+// Prune deletes every snapshot collected before cutoff and returns the number of
+// snapshots removed, e.g. Prune(ctx, time.Now().AddDate(0, 0, -90)) keeps 90 days.
+func (s *Store) Prune(ctx context.Context, cutoff time.Time) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("pruning snapshots: %w", err)
+	}
+	defer tx.Rollback()
+
+	var removed int64
+	for _, table := range tables {
+		result, err := tx.ExecContext(ctx, s.rebind("DELETE FROM "+table+" WHERE collected_at < ?"), cutoff.UTC())
+		if err != nil {
+			return 0, fmt.Errorf("pruning %s: %w", table, err)
+		}
+		if table == "snapshots" {
+			if removed, err = result.RowsAffected(); err != nil {
+				return 0, fmt.Errorf("pruning %s: %w", table, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("pruning snapshots: %w", err)
+	}
+	return removed, nil
+}
+
+// rebind rewrites "?" placeholders to "$1", "$2", ... for Postgres.
+func (s *Store) rebind(statement string) string {
+	if s.Dialect != Postgres {
+		return statement
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range statement {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}